@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cons-coder/parser"
+)
+
+// diagramFormat 状态机图的导出格式
+type diagramFormat struct {
+	Extension string                                   // 文件扩展名
+	Render    func(group *parser.ConstantGroup) string // 生成状态机组的状态图
+}
+
+// diagramFormats 支持的状态机图格式，键为格式名
+var diagramFormats = map[string]diagramFormat{
+	"mermaid": {Extension: ".mmd", Render: mermaidStateDiagram},
+	"dot":     {Extension: ".dot", Render: dotStateDiagram},
+}
+
+// DiagramFormatNames 返回支持的状态机图格式名，按名称排序
+func DiagramFormatNames() []string {
+	var names []string
+	for name := range diagramFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsDiagramFormat 判断是否为支持的状态机图格式
+func IsDiagramFormat(name string) bool {
+	_, exists := diagramFormats[name]
+	return exists
+}
+
+// WriteStateDiagrams 为文件中的每个状态机组按配置的格式导出状态图，与生成的代码放在同一目录，
+// 文件名为 <组名>.mmd、<组名>.dot
+func WriteStateDiagrams(config Config, constants *parser.ConstantsFile) error {
+	base := &BaseGenerator{Config: config}
+	for _, group := range constants.Groups {
+		if !parser.IsStateMachine(group) {
+			continue
+		}
+		for _, name := range config.Diagrams {
+			format := diagramFormats[name]
+			outputPath := filepath.Join(base.GetNamespaceDir(constants.Namespace), group.Name+format.Extension)
+			if err := WriteOutputFile(outputPath, format.Render(group)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mermaidStateDiagram 生成Mermaid状态图：[*] 指向初始状态，终止状态指向 [*]
+func mermaidStateDiagram(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("%%%% %s (%s)\n", commentText(group.Label), group.Name))
+	code.WriteString("stateDiagram-v2\n")
+	for _, constant := range group.Constants {
+		label := strings.ReplaceAll(constantLabel(constant), `"`, "#quot;")
+		code.WriteString(fmt.Sprintf("    state \"%s\" as %s\n", label, constant.Name))
+	}
+	code.WriteString(fmt.Sprintf("    [*] --> %s\n", parser.InitialState(group).Name))
+	for _, constant := range group.Constants {
+		next := nextStateConstants(group, constant)
+		for _, target := range next {
+			code.WriteString(fmt.Sprintf("    %s --> %s\n", constant.Name, target.Name))
+		}
+		if len(next) == 0 {
+			code.WriteString(fmt.Sprintf("    %s --> [*]\n", constant.Name))
+		}
+	}
+
+	return code.String()
+}
+
+// dotStateDiagram 生成Graphviz DOT状态图：初始状态由一个点指向，终止状态画双边框
+func dotStateDiagram(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	quote := func(text string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text) + `"`
+	}
+	code.WriteString(fmt.Sprintf("digraph %s {\n", quote(group.Name)))
+	code.WriteString(fmt.Sprintf("    label=%s;\n", quote(group.Label)))
+	code.WriteString("    labelloc=t;\n")
+	code.WriteString("    rankdir=LR;\n")
+	code.WriteString("    node [shape=box, style=rounded];\n")
+	code.WriteString("    __initial [shape=point, label=\"\"];\n")
+	for _, constant := range group.Constants {
+		attributes := "label=" + quote(constantLabel(constant))
+		if len(nextStateConstants(group, constant)) == 0 {
+			attributes += ", peripheries=2"
+		}
+		code.WriteString(fmt.Sprintf("    %s [%s];\n", quote(constant.Name), attributes))
+	}
+	code.WriteString(fmt.Sprintf("    __initial -> %s;\n", quote(parser.InitialState(group).Name)))
+	for _, constant := range group.Constants {
+		for _, target := range nextStateConstants(group, constant) {
+			code.WriteString(fmt.Sprintf("    %s -> %s;\n", quote(constant.Name), quote(target.Name)))
+		}
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
@@ -2,7 +2,11 @@ package generator
 
 import (
 	"fmt"
+	"math/big"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"cons-coder/parser"
@@ -10,12 +14,14 @@ import (
 
 // Config 生成器配置
 type Config struct {
-	Language      string // 目标语言
-	Mode          string // 生成模式 (class/const)
-	OutputDir     string // 输出目录
-	PackageName   string // 包名
-	HeaderComment string // 头部注释
-	Version       string // 生成器版本
+	Language      string   // 目标语言
+	Mode          string   // 生成模式 (class/const)
+	OutputDir     string   // 输出目录
+	PackageName   string   // 包名
+	HeaderComment string   // 头部注释
+	DefaultLocale string   // 默认语言，按语言查询标签时缺少翻译或语言未知则使用该语言的标签
+	Diagrams      []string // 状态机组导出的状态图格式（mermaid/dot），为空时不导出
+	Version       string   // 生成器版本
 }
 
 // Generator 代码生成器接口
@@ -73,10 +79,104 @@ func (g *BaseGenerator) GetOutputFileName(fileName string) string {
 	}
 }
 
-// GetOutputFilePath 获取完整的输出文件路径
-func (g *BaseGenerator) GetOutputFilePath(fileName string) string {
-	outputFileName := g.GetOutputFileName(fileName)
-	return filepath.Join(g.Config.OutputDir, outputFileName)
+// GetOutputFilePath 获取完整的输出文件路径（命名空间对应输出子目录）
+func (g *BaseGenerator) GetOutputFilePath(constants *parser.ConstantsFile) string {
+	outputFileName := g.GetOutputFileName(constants.FileName)
+	return filepath.Join(g.GetNamespaceDir(constants.Namespace), outputFileName)
+}
+
+// CheckOutputPaths 检查各源文件的输出文件是否重名：同一目录下的 a.json 与 a.yaml、CSV 中与其他文件同名的常量组
+// 会生成同一个输出文件，后生成的会覆盖先生成的，因此报告错误并指出两个来源
+func CheckOutputPaths(config Config, allConstants []*parser.ConstantsFile) parser.Diagnostics {
+	base := &BaseGenerator{Config: config}
+	sources := make(map[string]*parser.ConstantsFile)
+	var diags parser.Diagnostics
+	for _, constants := range allConstants {
+		outputPath := base.GetOutputFilePath(constants)
+		if first, exists := sources[outputPath]; exists {
+			diags = append(diags, &parser.Diagnostic{
+				File:     constants.FilePath,
+				Severity: parser.SeverityError,
+				Message: fmt.Sprintf("输出文件 '%s' 同时由 %s 和 %s 生成，后者会覆盖前者",
+					outputPath, outputSource(first), outputSource(constants)),
+			})
+			continue
+		}
+		sources[outputPath] = constants
+	}
+	return diags
+}
+
+// outputSource 返回生成输出文件的来源，CSV 文件的每个常量组各生成一个文件，附上常量组名
+func outputSource(constants *parser.ConstantsFile) string {
+	if strings.EqualFold(filepath.Ext(constants.FilePath), ".csv") {
+		return fmt.Sprintf("%s（常量组 '%s'）", constants.FilePath, constants.FileName)
+	}
+	return constants.FilePath
+}
+
+// GetNamespaceDir 获取命名空间对应的输出目录
+func (g *BaseGenerator) GetNamespaceDir(namespace string) string {
+	dirs := append([]string{g.Config.OutputDir}, parser.NamespaceSegments(namespace)...)
+	return filepath.Join(dirs...)
+}
+
+// GetQualifiedPackageName 获取带命名空间的点分包名（Java/Kotlin使用）
+func (g *BaseGenerator) GetQualifiedPackageName(namespace string) string {
+	segments := parser.NamespaceSegments(namespace)
+	if len(segments) == 0 {
+		return g.Config.PackageName
+	}
+	return g.Config.PackageName + "." + strings.Join(segments, ".")
+}
+
+// WriteOutputFile 写入生成的文件，必要时创建所在目录
+func WriteOutputFile(outputPath string, content string) error {
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, []byte(content), 0644)
+}
+
+// indexDir 需要生成索引文件的目录
+type indexDir struct {
+	Namespace string                  // 目录对应的命名空间
+	Files     []*parser.ConstantsFile // 目录下直接包含的常量文件
+	SubDirs   []string                // 直接子目录名（已规范化）
+}
+
+// buildIndexDirs 按命名空间整理出需要生成索引文件的所有目录（包括中间目录），按命名空间排序
+func buildIndexDirs(allConstants []*parser.ConstantsFile) []*indexDir {
+	dirs := map[string]*indexDir{"": {Namespace: ""}}
+
+	// 确保命名空间及其所有上级目录都存在，并登记父子关系
+	var ensure func(segments []string) *indexDir
+	ensure = func(segments []string) *indexDir {
+		namespace := strings.Join(segments, "/")
+		if dir, exists := dirs[namespace]; exists {
+			return dir
+		}
+		dir := &indexDir{Namespace: namespace}
+		dirs[namespace] = dir
+		parent := ensure(segments[:len(segments)-1])
+		parent.SubDirs = append(parent.SubDirs, segments[len(segments)-1])
+		return dir
+	}
+
+	for _, constants := range allConstants {
+		dir := ensure(parser.NamespaceSegments(constants.Namespace))
+		dir.Files = append(dir.Files, constants)
+	}
+
+	result := make([]*indexDir, 0, len(dirs))
+	for _, dir := range dirs {
+		sort.Strings(dir.SubDirs)
+		result = append(result, dir)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace < result[j].Namespace
+	})
+	return result
 }
 
 // FormatGenerationTime 格式化生成时间
@@ -113,14 +213,14 @@ func (g *BaseGenerator) GetFileHeader(constants *parser.ConstantsFile) string {
 	}
 
 	if commentLine != "" {
-		header += fmt.Sprintf("%s%s\n", commentLine, constants.Label)
+		header += fmt.Sprintf("%s%s\n", commentLine, commentText(constants.Label))
 		header += fmt.Sprintf("%s\n", commentLine)
 		header += fmt.Sprintf("%s源文件: %s\n", commentLine, filepath.Base(constants.FilePath))
 		header += fmt.Sprintf("%s最后修改: %s\n", commentLine, FormatGenerationTime(constants.LastModified))
 		header += fmt.Sprintf("%s生成时间: %s\n", commentLine, FormatGenerationTime(time.Now()))
 		header += fmt.Sprintf("%s生成工具: cons-coder v%s\n", commentLine, g.Config.Version)
 	} else {
-		header += fmt.Sprintf("%s\n", constants.Label)
+		header += fmt.Sprintf("%s\n", pythonDocText(constants.Label))
 		header += "\n"
 		header += fmt.Sprintf("源文件: %s\n", filepath.Base(constants.FilePath))
 		header += fmt.Sprintf("最后修改: %s\n", FormatGenerationTime(constants.LastModified))
@@ -131,3 +231,500 @@ func (g *BaseGenerator) GetFileHeader(constants *parser.ConstantsFile) string {
 
 	return header
 }
+
+// checkIntegerRange 检查目标语言能否精确表示文件中的整数常量
+//
+// 超出int64范围的整数只有Python的int和TypeScript/JavaScript的BigInt能精确表示，
+// 其他语言遇到时返回指向该常量的诊断，而不是生成无法编译或被截断的代码。
+func checkIntegerRange(constants *parser.ConstantsFile, language string) error {
+	for _, group := range constants.Groups {
+		for _, constant := range group.Constants {
+			if _, ok := constant.Value.(*big.Int); ok {
+				return &parser.Diagnostic{
+					File:     constants.FilePath,
+					Pos:      constant.Pos,
+					Severity: parser.SeverityError,
+					Message: fmt.Sprintf("常量 '%s.%s' 的值 %v 超出int64的范围，%s无法精确表示",
+						group.Name, constant.Name, constant.Value, language),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hasFlagsGroup 判断文件中是否有位标志组
+func hasFlagsGroup(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if group.Flags {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRecordGroup 判断文件中是否有记录组
+func hasRecordGroup(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if group.Record != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTreeGroup 判断文件中是否有树形常量组
+func hasTreeGroup(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if parser.IsTreeGroup(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasStateMachine 判断文件中是否有状态机组
+func hasStateMachine(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if parser.IsStateMachine(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSubsets 判断文件中是否有声明了命名子集的常量组
+func hasSubsets(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if len(group.Subsets) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCollectionGroup 判断文件中是否有集合值常量组
+func hasCollectionGroup(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if parser.IsCollectionGroup(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMapConstants 判断文件中是否有值为映射的常量
+func hasMapConstants(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		for _, constant := range group.Constants {
+			if kind, _, _ := parser.CollectionType(constant.Type); kind == "map" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// onlyDataGroups 判断文件中是否全部是记录组、集合值常量组或正则常量组
+func onlyDataGroups(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if group.Record == nil && !parser.IsCollectionGroup(group) && !parser.IsRegexGroup(group) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordFields 返回记录组的字段：主值字段在前，其后依次为附加属性
+func recordFields(group *parser.ConstantGroup) []*parser.Attribute {
+	primary := &parser.Attribute{
+		Name:  group.Record.Primary,
+		Type:  group.Constants[0].Type,
+		Label: group.Record.Label,
+	}
+	if primary.Label == "" {
+		primary.Label = "主值"
+	}
+	return append([]*parser.Attribute{primary}, group.Attributes...)
+}
+
+// recordFieldValue 返回常量在记录字段上的字面值，dataType 为字段在目标语言中使用的数据类型
+func recordFieldValue(group *parser.ConstantGroup, constant *parser.Constant, field *parser.Attribute,
+	dataType string, lang string) string {
+	if field.Name == group.Record.Primary {
+		return parser.FormatConstantValue(constant, dataType, lang)
+	}
+	return parser.FormatValue(constant.Attributes[field.Name], dataType, lang)
+}
+
+// hasLabelTemplates 判断文件中是否有标签含占位符的常量
+func hasLabelTemplates(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if len(templateConstants(group)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLocalizedLabels 判断文件中是否有按语言给出标签的常量
+func hasLocalizedLabels(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if hasGroupLocales(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGroupLocales 判断常量组中是否有按语言给出标签的常量
+//
+// 集合值常量组和正则常量组的标签只用作注释，不生成按语言查询标签的函数。
+func hasGroupLocales(group *parser.ConstantGroup) bool {
+	if parser.IsCollectionGroup(group) || parser.IsRegexGroup(group) {
+		return false
+	}
+	for _, constant := range group.Constants {
+		if constant.Labels != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// localizedLabel 返回常量在指定语言下的标签，缺少该语言的翻译时使用默认语言的标签
+//
+// 各语言的标签表均由它填充，因此表中包含每种语言下的全部常量，按语言查询标签时不会缺少常量。
+func localizedLabel(constant *parser.Constant, locale string) string {
+	if label, exists := constant.Labels[locale]; exists {
+		return label
+	}
+	return constantLabel(constant)
+}
+
+// templateConstants 返回标签含占位符的常量，按源文件顺序排列；别名与所属常量共用同一个格式化函数
+func templateConstants(group *parser.ConstantGroup) []*parser.Constant {
+	var constants []*parser.Constant
+	for _, constant := range group.Constants {
+		if constant.Template != nil {
+			constants = append(constants, constant)
+		}
+	}
+	return constants
+}
+
+// templateParams 返回格式化函数的参数列表，format 将参数名和类型格式化为目标语言的参数声明
+func templateParams(template *parser.LabelTemplate, format func(param parser.TemplateParam) string) string {
+	var params []string
+	for _, param := range template.Params {
+		params = append(params, format(param))
+	}
+	return strings.Join(params, ", ")
+}
+
+// templateDoc 返回格式化函数的说明
+func templateDoc(constant *parser.Constant) string {
+	return fmt.Sprintf("按标签模板“%s”生成文本", commentText(constant.Label))
+}
+
+// stringContent 返回字符串在目标语言中的字面值去掉两端引号后的内容，用于拼接模板字符串
+func stringContent(text string, lang string) string {
+	quoted := parser.FormatValue(text, "string", lang)
+	return quoted[1 : len(quoted)-1]
+}
+
+// parentConstant 返回树形常量组中常量的父常量，根常量返回nil
+func parentConstant(group *parser.ConstantGroup, constant *parser.Constant) *parser.Constant {
+	for _, candidate := range group.Constants {
+		if constant.Parent != "" && candidate.Name == constant.Parent {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// childConstants 返回树形常量组中常量的直接子常量，按源文件顺序排列
+func childConstants(group *parser.ConstantGroup, parent *parser.Constant) []*parser.Constant {
+	var children []*parser.Constant
+	for _, constant := range group.Constants {
+		if constant.Parent == parent.Name {
+			children = append(children, constant)
+		}
+	}
+	return children
+}
+
+// nextStateConstants 返回状态机组中状态允许转换到的目标状态，按transitions中的顺序排列，终止状态返回nil
+func nextStateConstants(group *parser.ConstantGroup, state *parser.Constant) []*parser.Constant {
+	var next []*parser.Constant
+	for _, name := range parser.NextStates(group, state.Name) {
+		for _, constant := range group.Constants {
+			if constant.Name == name {
+				next = append(next, constant)
+				break
+			}
+		}
+	}
+	return next
+}
+
+// stateTableEntries 返回状态机组转换表的各项（状态的键表达式及其目标状态的键表达式列表），
+// 终止状态也列入表中，以便区分终止状态和未知值
+func stateTableEntries(group *parser.ConstantGroup, keyExpr func(*parser.Constant) string) [][2]string {
+	var entries [][2]string
+	for _, constant := range group.Constants {
+		var next []string
+		for _, target := range nextStateConstants(group, constant) {
+			next = append(next, keyExpr(target))
+		}
+		entries = append(entries, [2]string{keyExpr(constant), strings.Join(next, ", ")})
+	}
+	return entries
+}
+
+// subsetKeys 返回子集成员的键表达式，以逗号分隔，按 parser.SubsetMembers 的顺序排列
+func subsetKeys(group *parser.ConstantGroup, subset *parser.Subset, keyExpr func(*parser.Constant) string) string {
+	var keys []string
+	for _, constant := range parser.SubsetMembers(group, subset) {
+		keys = append(keys, keyExpr(constant))
+	}
+	return strings.Join(keys, ", ")
+}
+
+// collectionItems 返回集合值常量的元素在目标语言中的字面值，按源文件顺序排列：
+// 列表为各元素，映射为各项的键（字符串字面值）与值以 separator 连接的文本
+func collectionItems(constant *parser.Constant, lang, separator string) []string {
+	_, elementType, _ := parser.CollectionType(constant.Type)
+	var items []string
+	switch value := constant.Value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			items = append(items, parser.FormatValue(item, elementType, lang))
+		}
+	case []parser.MapEntry:
+		for _, entry := range value {
+			items = append(items, parser.FormatValue(entry.Key, "string", lang)+separator+parser.FormatValue(entry.Value, elementType, lang))
+		}
+	}
+	return items
+}
+
+// flagConstants 返回位标志组的常量，按位从低到高排列
+func flagConstants(group *parser.ConstantGroup) []*parser.Constant {
+	constants := make([]*parser.Constant, len(group.Constants))
+	copy(constants, group.Constants)
+	sort.Slice(constants, func(i, j int) bool {
+		return constants[i].Value.(int64) < constants[j].Value.(int64)
+	})
+	return constants
+}
+
+// flagMask 返回位标志组所有已知位的并集
+func flagMask(group *parser.ConstantGroup) int64 {
+	var mask int64
+	for _, constant := range group.Constants {
+		mask |= constant.Value.(int64)
+	}
+	return mask
+}
+
+// constantLabel 返回常量的标签，未填写时使用常量名
+func constantLabel(constant *parser.Constant) string {
+	if constant.Label == "" {
+		return constant.Name
+	}
+	return constant.Label
+}
+
+// commentText 将标签等文本中的换行替换为空格，以便放入单行注释或文档注释的一行中，
+// 并拆开会提前结束块注释的 */；标签出现在字符串字面值中时使用 parser.FormatValue 转义，保留原有的换行
+func commentText(text string) string {
+	return commentReplacer.Replace(strings.TrimSpace(text))
+}
+
+// commentReplacer 把换行替换为空格，把 */ 替换为 * /
+var commentReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "*/", "* /")
+
+// attributeLabel 返回附加属性的说明，未填写时使用属性名
+func attributeLabel(attribute *parser.Attribute) string {
+	if attribute.Label == "" {
+		return attribute.Name
+	}
+	return attribute.Label
+}
+
+// symbolicReference 返回引用常量在目标语言中指向目标常量的表达式（如 UserRole.Normal）
+//
+// 只有目标与引用方位于同一命名空间、类型一致且都不在位标志组或记录组中，并且目标语言允许在该位置引用其他常量
+// （Go的const模式、Java、Kotlin，以及Swift的const模式和struct形式）时才输出符号引用；否则返回空，由调用方输出解析后的字面值。
+func symbolicReference(group *parser.ConstantGroup, constant *parser.Constant, lang string, mode string) string {
+	ref := constant.Ref
+	if ref == nil || ref.Target == nil || !ref.SameNamespace || group.Flags || ref.TargetGroup.Flags ||
+		group.Record != nil || ref.TargetGroup.Record != nil || constant.Type != ref.Target.Type {
+		return ""
+	}
+
+	groupName, key := ref.TargetGroup.Name, ref.Target.Name
+	constName := fmt.Sprintf("%s_%s", strings.ToUpper(groupName), strings.ToUpper(key))
+	switch lang {
+	case "go":
+		// class模式的常量组是包级变量，相互引用可能形成初始化循环
+		if mode == "const" {
+			return constName
+		}
+	case "java":
+		if mode == "const" {
+			return parser.ToJavaName(ref.TargetFile.FileName) + "." + constName
+		}
+		return parser.ToJavaName(ref.TargetFile.FileName) + "." + parser.ToJavaName(groupName) + "." + parser.ToJavaConstantName(key)
+	case "kotlin":
+		if mode == "const" {
+			return constName
+		}
+		return parser.ToKotlinName(groupName) + "." + parser.ToKotlinConstantName(key)
+	case "swift":
+		// enum的原始值必须是字面值，只有const模式和布尔常量组的struct形式可以引用
+		if mode == "const" {
+			return constName
+		}
+		if constant.Type == "bool" {
+			return parser.ToJavaName(groupName) + "." + parser.ToSwiftName(key)
+		}
+	}
+	return ""
+}
+
+// constantValue 返回常量值在目标语言中的表达式，能引用其他常量时输出符号引用，否则输出字面值
+func constantValue(group *parser.ConstantGroup, constant *parser.Constant, lang string, mode string) string {
+	if expr := symbolicReference(group, constant, lang, mode); expr != "" {
+		return expr
+	}
+	return parser.FormatConstantValue(constant, constant.Type, lang)
+}
+
+// deprecatedNote 废弃常量的默认说明
+const deprecatedNote = "已废弃"
+
+// deprecationMessage 返回废弃常量的说明：未填写时使用默认说明，指定了替代常量时附加“请使用 xxx”
+// 说明原样返回，放入字符串字面值时用 parser.FormatValue 转义，放入文档注释时经 commentText 处理
+func deprecationMessage(constant *parser.Constant) string {
+	message := constant.DeprecatedMessage
+	if message == "" {
+		message = deprecatedNote
+	}
+	if constant.ReplacedBy != "" {
+		message += "，请使用 " + constant.ReplacedBy
+	}
+	return message
+}
+
+// expandAliases 在每个常量之后插入其别名，用于生成常量定义和按名称查找；
+// 别名与常量同值同标签，废弃的别名以常量本身作为替代
+func expandAliases(constants []*parser.Constant) []*parser.Constant {
+	var expanded []*parser.Constant
+	for _, constant := range constants {
+		expanded = append(expanded, constant)
+		for _, alias := range constant.Aliases {
+			aliasConstant := &parser.Constant{
+				Name:        alias.Name,
+				Type:        constant.Type,
+				Label:       constant.Label,
+				Value:       constant.Value,
+				Literal:     constant.Literal,
+				Attributes:  constant.Attributes,
+				Description: constant.Name + " 的别名",
+				Deprecated:  constant.Deprecated || alias.Deprecated,
+				AliasOf:     constant.Name,
+				Pos:         constant.Pos,
+			}
+			if alias.Deprecated {
+				aliasConstant.ReplacedBy = constant.Name
+			} else {
+				aliasConstant.DeprecatedMessage = constant.DeprecatedMessage
+				aliasConstant.ReplacedBy = constant.ReplacedBy
+			}
+			expanded = append(expanded, aliasConstant)
+		}
+	}
+	return expanded
+}
+
+// canonicalConstants 返回按值反查（格式化标签、校验值）时使用的常量：别名不参与反查，
+// 多个常量同值时只保留一个，优先保留未废弃的常量
+func canonicalConstants(group *parser.ConstantGroup) []*parser.Constant {
+	var constants []*parser.Constant
+	indexes := make(map[string]int)
+	for _, constant := range group.Constants {
+		key := fmt.Sprint(constant.Value)
+		index, duplicated := indexes[key]
+		if !duplicated {
+			indexes[key] = len(constants)
+			constants = append(constants, constant)
+			continue
+		}
+		if constants[index].Deprecated && !constant.Deprecated {
+			constants[index] = constant
+		}
+	}
+	return constants
+}
+
+// hasDocDetails 判断常量是否需要输出完整的文档注释（而非单行标签注释）
+func hasDocDetails(constant *parser.Constant) bool {
+	return constant.Description != "" || constant.Deprecated || len(constant.Tags) > 0
+}
+
+// docDetails 返回常量标签之外的文档内容（描述、附加标记），段落之间以空行分隔
+func docDetails(constant *parser.Constant) []string {
+	var lines []string
+	if constant.Description != "" {
+		for _, line := range strings.Split(strings.TrimSpace(constant.Description), "\n") {
+			lines = append(lines, commentReplacer.Replace(line))
+		}
+	}
+	if len(constant.Tags) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "标记: "+strings.Join(constant.Tags, ", "))
+	}
+	return lines
+}
+
+// docLines 返回常量的完整文档注释内容：标签在首行，其后为详细信息
+func docLines(constant *parser.Constant) []string {
+	lines := []string{commentText(constant.Label)}
+	if details := docDetails(constant); len(details) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, details...)
+	}
+	return lines
+}
+
+// blockDoc 生成 /** ... */ 形式的文档注释（Javadoc/KDoc/TSDoc/JSDoc）
+func blockDoc(indent string, lines []string) string {
+	var code strings.Builder
+	code.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		if line == "" {
+			code.WriteString(indent + " *\n")
+		} else {
+			code.WriteString(indent + " * " + line + "\n")
+		}
+	}
+	code.WriteString(indent + " */\n")
+	return code.String()
+}
+
+// lineDoc 生成逐行前缀形式的文档注释（Go的//、Swift的///）
+func lineDoc(indent, prefix string, lines []string) string {
+	var code strings.Builder
+	for _, line := range lines {
+		if line == "" {
+			code.WriteString(indent + prefix + "\n")
+		} else {
+			code.WriteString(indent + prefix + " " + line + "\n")
+		}
+	}
+	return code.String()
+}
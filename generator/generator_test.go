@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"cons-coder/parser"
+)
+
+func TestCheckOutputPaths(t *testing.T) {
+	allConstants := []*parser.ConstantsFile{
+		{FileName: "a", FilePath: "data/a.json"},
+		{FileName: "a", FilePath: "data/a.yaml"},
+		{FileName: "a", FilePath: "data/sub/a.yaml", Namespace: "sub"},
+		{FileName: "order_status", FilePath: "data/order_status.yaml"},
+		{FileName: "ORDER_STATUS", FilePath: "data/tables.csv"},
+	}
+
+	diags := CheckOutputPaths(Config{Language: "go", OutputDir: "out"}, allConstants)
+	if len(diags) != 1 || diags[0].File != "data/a.yaml" || !strings.Contains(diags[0].Message, "data/a.json") {
+		t.Errorf("go: got %v, want one error for data/a.yaml naming data/a.json", diags)
+	}
+
+	// Java的类名由文件名转换而来，order_status 与 ORDER_STATUS 都生成 OrderStatus.java
+	diags = CheckOutputPaths(Config{Language: "java", OutputDir: "out"}, allConstants)
+	if len(diags) != 2 || !strings.Contains(diags[1].Message, "常量组 'ORDER_STATUS'") {
+		t.Errorf("java: got %v, want errors for a.yaml and the CSV group ORDER_STATUS", diags)
+	}
+}
+
+func TestGoIotaExpr(t *testing.T) {
+	tests := []struct {
+		start, step int64
+		want        string
+	}{
+		{0, 1, "iota"},
+		{1, 1, "iota + 1"},
+		{-1, 1, "iota - 1"},
+		{0, 2, "iota * 2"},
+		{10, 10, "iota*10 + 10"},
+		{-4, 2, "iota*2 - 4"},
+		{5, -1, "5 - iota"},
+		{5, -2, "5 - iota*2"},
+		{-5, -1, "-5 - iota"},
+		{0, -1, "-iota"},
+		{0, -3, "-iota * 3"},
+	}
+	for _, tt := range tests {
+		group := &parser.ConstantGroup{Name: "status", Auto: &parser.AutoIncrement{Start: tt.start, Step: tt.step}}
+		for i := int64(0); i < 3; i++ {
+			group.Constants = append(group.Constants, &parser.Constant{Name: fmt.Sprintf("c%d", i), Value: tt.start + tt.step*i})
+		}
+		if got := goIotaExpr(group); got != tt.want {
+			t.Errorf("goIotaExpr(start=%d, step=%d) = %q, want %q", tt.start, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestDeprecationDoc(t *testing.T) {
+	docs := map[string]func(*parser.Constant, string) string{
+		"go":         goDoc,
+		"java":       javaDoc,
+		"typescript": tsDoc,
+	}
+	for _, message := range []string{"line1\nline2", "old */ api"} {
+		constant := &parser.Constant{Name: "old", Label: "旧接口", Deprecated: true, DeprecatedMessage: message}
+		for lang, doc := range docs {
+			got := doc(constant, "")
+			if strings.Contains(got, "line1\nline2") || strings.Contains(got, "*/ api") {
+				t.Errorf("%s doc for deprecated message %q = %q, want the message kept inside the comment", lang, message, got)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+				if lang == "go" && !strings.HasPrefix(line, "//") {
+					t.Errorf("go doc line %q is outside the comment", line)
+				}
+			}
+			if lang != "go" && strings.Count(got, "*/") != 1 {
+				t.Errorf("%s doc = %q, want the block comment closed exactly once", lang, got)
+			}
+		}
+	}
+}
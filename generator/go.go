@@ -2,8 +2,8 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"cons-coder/parser"
@@ -23,6 +23,10 @@ func NewGoGenerator(config Config) *GoGenerator {
 
 // Generate 生成Go代码
 func (g *GoGenerator) Generate(constants *parser.ConstantsFile) error {
+	if err := checkIntegerRange(constants, g.Config.Language); err != nil {
+		return err
+	}
+
 	var code strings.Builder
 	
 	// 文件头注释 - 必须在package声明之前
@@ -30,10 +34,26 @@ func (g *GoGenerator) Generate(constants *parser.ConstantsFile) error {
 	code.WriteString("\n")
 	
 	// 包声明
-	code.WriteString(fmt.Sprintf("package %s\n\n", g.Config.PackageName))
+	code.WriteString(fmt.Sprintf("package %s\n\n", g.packageName(constants.Namespace)))
 	
 	if g.Config.Mode == "const" {
-		// const模式不需要导入fmt
+		// const模式只有标签模板的格式化函数需要导入fmt，正则常量需要导入regexp，按语言查询标签需要导入strings
+		var imports []string
+		if hasLabelTemplates(constants) {
+			imports = append(imports, "fmt")
+		}
+		if hasRegexGroup(constants) {
+			imports = append(imports, "regexp")
+		}
+		if hasLocalizedLabels(constants) {
+			imports = append(imports, "strings")
+		}
+		if len(imports) == 1 {
+			code.WriteString(fmt.Sprintf("import %q\n\n", imports[0]))
+		} else if len(imports) > 1 {
+			code.WriteString(goImportBlock(imports))
+		}
+
 		// 生成每个常量组
 		for _, group := range constants.Groups {
 			code.WriteString(g.generateConstGroup(group, constants.Label))
@@ -41,10 +61,20 @@ func (g *GoGenerator) Generate(constants *parser.ConstantsFile) error {
 		}
 	} else {
 		// class模式
-		// 导入
-		code.WriteString("import (\n")
-		code.WriteString("\t\"fmt\"\n")
-		code.WriteString(")\n\n")
+		// 导入；记录组只用到结构体和映射，集合值常量组只用到切片和映射，文件中全是这两种常量组且没有标签模板时不需要导入fmt
+		var imports []string
+		if !onlyDataGroups(constants) || hasLabelTemplates(constants) {
+			imports = append(imports, "fmt")
+		}
+		if hasRegexGroup(constants) {
+			imports = append(imports, "regexp")
+		}
+		if hasFlagsGroup(constants) || hasLocalizedLabels(constants) {
+			imports = append(imports, "strings")
+		}
+		if len(imports) > 0 {
+			code.WriteString(goImportBlock(imports))
+		}
 		
 		// 生成每个常量组
 		for _, group := range constants.Groups {
@@ -54,38 +84,194 @@ func (g *GoGenerator) Generate(constants *parser.ConstantsFile) error {
 	}
 	
 	// 写入文件
-	outputPath := g.GetOutputFilePath(constants.FileName)
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
+	outputPath := g.GetOutputFilePath(constants)
+	return WriteOutputFile(outputPath, code.String())
+}
+
+// packageName 获取命名空间对应的Go包名：根目录使用配置的包名，子目录使用最后一级目录名
+func (g *GoGenerator) packageName(namespace string) string {
+	segments := parser.NamespaceSegments(namespace)
+	if len(segments) == 0 {
+		return g.Config.PackageName
+	}
+	return strings.ReplaceAll(segments[len(segments)-1], "_", "")
 }
 
 // generateConstGroup 生成const模式的常量组
 func (g *GoGenerator) generateConstGroup(group *parser.ConstantGroup, _ string) string {
+	if group.Record != nil {
+		return g.generateRecordGroup(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionGroup(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexGroup(group)
+	}
+
 	var code strings.Builder
 	
 	// 生成常量组
 	code.WriteString("const (\n")
 	
-	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
-	sort.Slice(constants, func(i, j int) bool {
-		return parser.ToGoName(constants[i].Name) < parser.ToGoName(constants[j].Name)
-	})
+	// 按字母顺序排序常量；自增常量组保持源文件顺序，以便使用iota
+	iotaExpr := goIotaExpr(group)
+	constants := expandAliases(group.Constants)
+	if iotaExpr == "" {
+		sort.Slice(constants, func(i, j int) bool {
+			return parser.ToGoName(constants[i].Name) < parser.ToGoName(constants[j].Name)
+		})
+	}
 	
 	// 生成常量定义
-	for _, constant := range constants {
+	for i, constant := range constants {
 		constName := fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
-		value := parser.FormatValue(constant.Value, constant.Type, "go")
-		comment := constant.Label
+		value := constantValue(group, constant, "go", "const")
+		if iotaExpr != "" {
+			// 首个常量写出iota表达式，后续常量沿用
+			if i == 0 {
+				constName += " = " + iotaExpr
+			}
+			if hasDocDetails(constant) {
+				code.WriteString(goDoc(constant, "\t"))
+				code.WriteString(fmt.Sprintf("\t%s\n", constName))
+				continue
+			}
+			code.WriteString(fmt.Sprintf("\t%s // %s\n", constName, commentText(constant.Label)))
+			continue
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(goDoc(constant, "\t"))
+			code.WriteString(fmt.Sprintf("\t%s = %s\n", constName, value))
+			continue
+		}
+		comment := commentText(constant.Label)
 		code.WriteString(fmt.Sprintf("\t%s = %s // %s\n", constName, value, comment))
 	}
 	code.WriteString(")\n")
+
+	// 附加属性的查询函数
+	for _, attribute := range group.Attributes {
+		funcName := parser.ToGoName(group.Name) + parser.ToGoName(attribute.Name)
+		code.WriteString(fmt.Sprintf("\n// %s 返回值对应的%s，未知值返回默认值\n", funcName, commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("func %s(value %s) %s {\n", funcName, parser.GetGoType(group.Constants[0].Type), parser.GetGoType(attribute.Type)))
+		code.WriteString(goAttributeSwitch(group, attribute, "value", func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+		code.WriteString("}\n")
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		funcName := parser.ToGoName(group.Name) + "LocalizedLabel"
+		tableName := toCamelCase(group.Name) + "LocaleLabels"
+		code.WriteString("\n")
+		code.WriteString(g.goLocaleTable(group, tableName, parser.GetGoType(group.Constants[0].Type), canonicalConstants(group),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+		code.WriteString(fmt.Sprintf("\n// %s 返回值在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言，未知值返回空字符串\n", funcName))
+		code.WriteString(fmt.Sprintf("func %s(value %s, locale string) string {\n", funcName, parser.GetGoType(group.Constants[0].Type)))
+		code.WriteString(g.goLocaleLookup(tableName))
+		code.WriteString("\treturn labels[value]\n")
+		code.WriteString("}\n")
+	}
+
+	// 标签模板的格式化函数
+	code.WriteString(goTemplateFuncs(group, ""))
+
+	// 树形常量组的父子关系查询函数
+	if parser.IsTreeGroup(group) {
+		code.WriteString(goTreeFuncs(group, "", func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
+
+	// 状态机组的状态转换函数
+	if parser.IsStateMachine(group) {
+		code.WriteString(goStateFuncs(group, "", func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
+
+	// 命名子集的集合及判断函数
+	if len(group.Subsets) > 0 {
+		code.WriteString(goSubsetFuncs(group, "", func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
 	
 	return code.String()
 }
 
+// goAttributeSwitch 生成按值查询附加属性的函数体：对 subject 逐个匹配常量（caseExpr 返回常量在case中的表达式），
+// 未匹配时返回属性的默认值
+func goAttributeSwitch(group *parser.ConstantGroup, attribute *parser.Attribute, subject string,
+	caseExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("\tswitch %s {\n", subject))
+	for _, constant := range canonicalConstants(group) {
+		code.WriteString(fmt.Sprintf("\tcase %s:\n", caseExpr(constant)))
+		code.WriteString(fmt.Sprintf("\t\treturn %s\n", parser.FormatValue(constant.Attributes[attribute.Name], attribute.Type, "go")))
+	}
+	code.WriteString("\t}\n")
+	code.WriteString(fmt.Sprintf("\treturn %s\n", parser.FormatValue(attribute.Default, attribute.Type, "go")))
+
+	return code.String()
+}
+
+// goIotaExpr 返回自增常量组首个常量的iota表达式（如 iota + 1）；
+// 常量组未使用auto指令、显式给出的值打断了等差序列或包含别名时返回空，此时按字面值输出
+func goIotaExpr(group *parser.ConstantGroup) string {
+	if group.Auto == nil {
+		return ""
+	}
+	for i, constant := range group.Constants {
+		// 别名插在常量之间会打断iota的计数
+		if constant.Value != group.Auto.Start+group.Auto.Step*int64(i) || len(constant.Aliases) > 0 {
+			return ""
+		}
+	}
+
+	// 按步长的符号组织表达式：递减序列写作 5 - iota、5 - iota*2，而不是 iota*-1 + 5
+	start, step := group.Auto.Start, group.Auto.Step
+	term := "iota"
+	if step != 1 && step != -1 {
+		term = fmt.Sprintf("iota * %d", max(step, -step))
+	}
+	var expr string
+	switch {
+	case start == 0 && step > 0:
+		return term
+	case start == 0:
+		return "-" + term
+	case step > 0 && start > 0:
+		expr = fmt.Sprintf("%s + %d", term, start)
+	case step > 0:
+		expr = fmt.Sprintf("%s - %d", term, -start)
+	default:
+		expr = fmt.Sprintf("%d - %s", start, term)
+	}
+	// 与gofmt一致：与加减混合时乘法两侧不留空格
+	return strings.Replace(expr, " * ", "*", 1)
+}
+
 // generateGroup 生成常量组
 func (g *GoGenerator) generateGroup(group *parser.ConstantGroup, _ string) string {
+	if group.Flags {
+		return g.generateFlagsGroup(group)
+	}
+	if group.Record != nil {
+		return g.generateRecordGroup(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionGroup(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexGroup(group)
+	}
+
 	var code strings.Builder
 	
 	structName := toCamelCase(group.Name) + "Cons"
@@ -94,8 +280,7 @@ func (g *GoGenerator) generateGroup(group *parser.ConstantGroup, _ string) strin
 	code.WriteString(fmt.Sprintf("type %s struct {\n", structName))
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToGoName(constants[i].Name) < parser.ToGoName(constants[j].Name)
 	})
@@ -104,7 +289,12 @@ func (g *GoGenerator) generateGroup(group *parser.ConstantGroup, _ string) strin
 	for _, constant := range constants {
 		fieldName := parser.ToGoName(constant.Name)
 		fieldType := parser.GetGoType(constant.Type)
-		comment := constant.Label
+		if hasDocDetails(constant) {
+			code.WriteString(goDoc(constant, "\t"))
+			code.WriteString(fmt.Sprintf("\t%s %s\n", fieldName, fieldType))
+			continue
+		}
+		comment := commentText(constant.Label)
 		code.WriteString(fmt.Sprintf("\t%s %s // %s\n", fieldName, fieldType, comment))
 	}
 	code.WriteString("}\n\n")
@@ -115,7 +305,7 @@ func (g *GoGenerator) generateGroup(group *parser.ConstantGroup, _ string) strin
 	code.WriteString(fmt.Sprintf("var %s = %s{\n", groupName, structName))
 	for _, constant := range constants {
 		fieldName := parser.ToGoName(constant.Name)
-		value := parser.FormatValue(constant.Value, constant.Type, "go")
+		value := parser.FormatConstantValue(constant, constant.Type, "go")
 		code.WriteString(fmt.Sprintf("\t%s: %s,\n", fieldName, value))
 	}
 	code.WriteString("}\n\n")
@@ -132,10 +322,577 @@ func (g *GoGenerator) generateGroup(group *parser.ConstantGroup, _ string) strin
 	code.WriteString(g.generateIsValid(group, structName))
 	code.WriteString("\n")
 	code.WriteString(g.generateFromString(group, structName))
+
+	// 附加属性的查询方法
+	for _, attribute := range group.Attributes {
+		methodName := parser.ToGoName(attribute.Name)
+		code.WriteString(fmt.Sprintf("\n// %s 返回值对应的%s，未知值返回默认值\n", methodName, commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("func (s %s) %s(value %s) %s {\n", structName, methodName,
+			parser.GetGoType(group.Constants[0].Type), parser.GetGoType(attribute.Type)))
+		code.WriteString(goAttributeSwitch(group, attribute, "value", func(constant *parser.Constant) string {
+			return "s." + parser.ToGoName(constant.Name)
+		}))
+		code.WriteString("}\n")
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		tableName := toCamelCase(group.Name) + "LocaleLabels"
+		code.WriteString("\n")
+		code.WriteString(g.goLocaleTable(group, tableName, parser.GetGoType(group.Constants[0].Type), canonicalConstants(group),
+			func(constant *parser.Constant) string {
+				return groupName + "." + parser.ToGoName(constant.Name)
+			}))
+		code.WriteString("\n// LocalizedLabel 返回值在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言，未知值返回空字符串\n")
+		code.WriteString(fmt.Sprintf("func (s %s) LocalizedLabel(value %s, locale string) string {\n", structName,
+			parser.GetGoType(group.Constants[0].Type)))
+		code.WriteString(g.goLocaleLookup(tableName))
+		code.WriteString("\treturn labels[value]\n")
+		code.WriteString("}\n")
+	}
+
+	// 标签模板的格式化方法
+	code.WriteString(goTemplateFuncs(group, "s "+structName))
+
+	// 树形常量组的父子关系查询方法
+	if parser.IsTreeGroup(group) {
+		code.WriteString(goTreeFuncs(group, "s "+structName, func(constant *parser.Constant) string {
+			return groupName + "." + parser.ToGoName(constant.Name)
+		}))
+	}
+
+	// 状态机组的状态转换方法
+	if parser.IsStateMachine(group) {
+		code.WriteString(goStateFuncs(group, "s "+structName, func(constant *parser.Constant) string {
+			return groupName + "." + parser.ToGoName(constant.Name)
+		}))
+	}
+
+	// 命名子集的集合及判断方法
+	if len(group.Subsets) > 0 {
+		code.WriteString(goSubsetFuncs(group, "s "+structName, func(constant *parser.Constant) string {
+			return groupName + "." + parser.ToGoName(constant.Name)
+		}))
+	}
 	
 	return code.String()
 }
 
+// generateFlagsGroup 生成位标志组：具名的位掩码类型、各标志常量及组合操作方法
+func (g *GoGenerator) generateFlagsGroup(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	typeName := parser.ToGoName(group.Name)
+	valueType := parser.GetGoType(group.Constants[0].Type)
+	maskName := toCamelCase(group.Name) + "Mask"
+	labelsName := toCamelCase(group.Name) + "Labels"
+	constants := flagConstants(group)
+
+	code.WriteString(fmt.Sprintf("// %s %s（位标志，可按位组合）\n", typeName, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("type %s %s\n\n", typeName, valueType))
+
+	code.WriteString("const (\n")
+	for _, constant := range expandAliases(constants) {
+		constName := typeName + parser.ToGoName(constant.Name)
+		value := parser.FormatConstantValue(constant, constant.Type, "go")
+		if hasDocDetails(constant) {
+			code.WriteString(goDoc(constant, "\t"))
+			code.WriteString(fmt.Sprintf("\t%s %s = %s\n", constName, typeName, value))
+			continue
+		}
+		code.WriteString(fmt.Sprintf("\t%s %s = %s // %s\n", constName, typeName, value, commentText(constant.Label)))
+	}
+	code.WriteString(")\n\n")
+
+	code.WriteString(fmt.Sprintf("// %s 所有已知标志的并集\n", maskName))
+	code.WriteString(fmt.Sprintf("const %s %s = %d\n\n", maskName, typeName, flagMask(group)))
+
+	code.WriteString(fmt.Sprintf("// %s 各标志的标签，按位从低到高排列\n", labelsName))
+	code.WriteString(fmt.Sprintf("var %s = []struct {\n", labelsName))
+	code.WriteString(fmt.Sprintf("\tflag  %s\n", typeName))
+	code.WriteString("\tlabel string\n")
+	code.WriteString("}{\n")
+	for _, constant := range constants {
+		constName := typeName + parser.ToGoName(constant.Name)
+		code.WriteString(fmt.Sprintf("\t{%s, %s},\n", constName, parser.FormatValue(constantLabel(constant), "string", "go")))
+	}
+	code.WriteString("}\n\n")
+
+	code.WriteString("// Has 判断是否包含指定的全部标志\n")
+	code.WriteString(fmt.Sprintf("func (f %s) Has(flag %s) bool {\n", typeName, typeName))
+	code.WriteString("\treturn f&flag == flag\n")
+	code.WriteString("}\n\n")
+
+	code.WriteString("// Add 返回添加指定标志后的值\n")
+	code.WriteString(fmt.Sprintf("func (f %s) Add(flag %s) %s {\n", typeName, typeName, typeName))
+	code.WriteString("\treturn f | flag\n")
+	code.WriteString("}\n\n")
+
+	code.WriteString("// Remove 返回移除指定标志后的值\n")
+	code.WriteString(fmt.Sprintf("func (f %s) Remove(flag %s) %s {\n", typeName, typeName, typeName))
+	code.WriteString("\treturn f &^ flag\n")
+	code.WriteString("}\n\n")
+
+	code.WriteString("// ToList 拆分为包含的各个标志，按位从低到高排列\n")
+	code.WriteString(fmt.Sprintf("func (f %s) ToList() []%s {\n", typeName, typeName))
+	code.WriteString(fmt.Sprintf("\tvar flags []%s\n", typeName))
+	code.WriteString(fmt.Sprintf("\tfor _, item := range %s {\n", labelsName))
+	code.WriteString("\t\tif f.Has(item.flag) {\n")
+	code.WriteString("\t\t\tflags = append(flags, item.flag)\n")
+	code.WriteString("\t\t}\n")
+	code.WriteString("\t}\n")
+	code.WriteString("\treturn flags\n")
+	code.WriteString("}\n\n")
+
+	code.WriteString("// Format 格式化为以|分隔的标签，如 \"读|写\"；包含未知位时返回 Unknown(value)\n")
+	code.WriteString(fmt.Sprintf("func (f %s) Format() string {\n", typeName))
+	code.WriteString("\tif !f.IsValid() {\n")
+	code.WriteString("\t\treturn fmt.Sprintf(\"Unknown(%d)\", f)\n")
+	code.WriteString("\t}\n")
+	code.WriteString("\tvar labels []string\n")
+	code.WriteString(fmt.Sprintf("\tfor _, item := range %s {\n", labelsName))
+	code.WriteString("\t\tif f.Has(item.flag) {\n")
+	code.WriteString("\t\t\tlabels = append(labels, item.label)\n")
+	code.WriteString("\t\t}\n")
+	code.WriteString("\t}\n")
+	code.WriteString("\treturn strings.Join(labels, \"|\")\n")
+	code.WriteString("}\n\n")
+
+	code.WriteString("// String 实现fmt.Stringer\n")
+	code.WriteString(fmt.Sprintf("func (f %s) String() string {\n", typeName))
+	code.WriteString("\treturn f.Format()\n")
+	code.WriteString("}\n\n")
+
+	code.WriteString("// IsValid 判断值是否为已知标志的任意组合\n")
+	code.WriteString(fmt.Sprintf("func (f %s) IsValid() bool {\n", typeName))
+	code.WriteString(fmt.Sprintf("\treturn f&^%s == 0\n", maskName))
+	code.WriteString("}\n")
+
+	// 附加属性：单个标志返回其属性值，组合值和未知值返回默认值
+	for _, attribute := range group.Attributes {
+		methodName := parser.ToGoName(attribute.Name)
+		code.WriteString(fmt.Sprintf("\n// %s 返回单个标志的%s，组合值和未知值返回默认值\n", methodName, commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("func (f %s) %s() %s {\n", typeName, methodName, parser.GetGoType(attribute.Type)))
+		code.WriteString(goAttributeSwitch(group, attribute, "f", func(constant *parser.Constant) string {
+			return typeName + parser.ToGoName(constant.Name)
+		}))
+		code.WriteString("}\n")
+	}
+
+	// 按语言格式化组合值
+	if hasGroupLocales(group) {
+		tableName := toCamelCase(group.Name) + "LocaleLabels"
+		code.WriteString("\n")
+		code.WriteString(g.goLocaleTable(group, tableName, typeName, constants, func(constant *parser.Constant) string {
+			return typeName + parser.ToGoName(constant.Name)
+		}))
+		code.WriteString("\n// LocalizedLabel 格式化为指定语言（如 en、en-US）下以|分隔的标签，语言未知时使用默认语言；包含未知位时返回 Unknown(value)\n")
+		code.WriteString(fmt.Sprintf("func (f %s) LocalizedLabel(locale string) string {\n", typeName))
+		code.WriteString("\tif !f.IsValid() {\n")
+		code.WriteString("\t\treturn fmt.Sprintf(\"Unknown(%d)\", f)\n")
+		code.WriteString("\t}\n")
+		code.WriteString(g.goLocaleLookup(tableName))
+		code.WriteString("\tvar parts []string\n")
+		code.WriteString(fmt.Sprintf("\tfor _, item := range %s {\n", labelsName))
+		code.WriteString("\t\tif f.Has(item.flag) {\n")
+		code.WriteString("\t\t\tparts = append(parts, labels[item.flag])\n")
+		code.WriteString("\t\t}\n")
+		code.WriteString("\t}\n")
+		code.WriteString("\treturn strings.Join(parts, \"|\")\n")
+		code.WriteString("}\n")
+	}
+	code.WriteString(goTemplateFuncs(group, ""))
+
+	return code.String()
+}
+
+// generateRecordGroup 生成记录组：记录结构体、各常量对应的记录变量，以及按键名和按主值查找的映射
+func (g *GoGenerator) generateRecordGroup(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	typeName := parser.ToGoName(group.Name)
+	fields := recordFields(group)
+	primary := fields[0]
+	primaryType := parser.GetGoType(primary.Type)
+	byKeyName := toCamelCase(group.Name) + "ByKey"
+	byPrimaryName := toCamelCase(group.Name) + "By" + parser.ToGoName(primary.Name)
+
+	code.WriteString(fmt.Sprintf("// %s %s\n", typeName, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("type %s struct {\n", typeName))
+	code.WriteString("\tKey string // 键名\n")
+	code.WriteString("\tLabel string // 标签\n")
+	for _, field := range fields {
+		code.WriteString(fmt.Sprintf("\t%s %s // %s\n", parser.ToGoName(field.Name), parser.GetGoType(field.Type), commentText(attributeLabel(field))))
+	}
+	code.WriteString("}\n\n")
+
+	code.WriteString("var (\n")
+	for _, constant := range expandAliases(group.Constants) {
+		varName := typeName + parser.ToGoName(constant.Name)
+		value := typeName + parser.ToGoName(constant.AliasOf)
+		if constant.AliasOf == "" {
+			items := []string{
+				"Key: " + parser.FormatValue(constant.Name, "string", "go"),
+				"Label: " + parser.FormatValue(constant.Label, "string", "go"),
+			}
+			for _, field := range fields {
+				items = append(items, parser.ToGoName(field.Name)+": "+recordFieldValue(group, constant, field, field.Type, "go"))
+			}
+			value = fmt.Sprintf("%s{%s}", typeName, strings.Join(items, ", "))
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(goDoc(constant, "\t"))
+			code.WriteString(fmt.Sprintf("\t%s = %s\n", varName, value))
+			continue
+		}
+		code.WriteString(fmt.Sprintf("\t%s = %s // %s\n", varName, value, commentText(constant.Label)))
+	}
+	code.WriteString(")\n\n")
+
+	code.WriteString(fmt.Sprintf("// %s 按键名（含别名）索引的%s\n", byKeyName, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("var %s = map[string]%s{\n", byKeyName, typeName))
+	for _, constant := range expandAliases(group.Constants) {
+		code.WriteString(fmt.Sprintf("\t%s: %s,\n", parser.FormatValue(constant.Name, "string", "go"), typeName+parser.ToGoName(constant.Name)))
+	}
+	code.WriteString("}\n\n")
+
+	code.WriteString(fmt.Sprintf("// %s 按%s索引的%s\n", byPrimaryName, commentText(attributeLabel(primary)), commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("var %s = map[%s]%s{\n", byPrimaryName, primaryType, typeName))
+	for _, constant := range group.Constants {
+		code.WriteString(fmt.Sprintf("\t%s: %s,\n", recordFieldValue(group, constant, primary, primary.Type, "go"), typeName+parser.ToGoName(constant.Name)))
+	}
+	code.WriteString("}\n\n")
+
+	code.WriteString(fmt.Sprintf("// All%sRecords 返回所有%s，按源文件顺序排列\n", typeName, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("func All%sRecords() []%s {\n", typeName, typeName))
+	code.WriteString(fmt.Sprintf("\treturn []%s{\n", typeName))
+	for _, constant := range group.Constants {
+		code.WriteString(fmt.Sprintf("\t\t%s,\n", typeName+parser.ToGoName(constant.Name)))
+	}
+	code.WriteString("\t}\n")
+	code.WriteString("}\n\n")
+
+	code.WriteString(fmt.Sprintf("// Lookup%s 按键名（含别名）查找%s\n", typeName, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("func Lookup%s(key string) (%s, bool) {\n", typeName, typeName))
+	code.WriteString(fmt.Sprintf("\trecord, ok := %s[key]\n", byKeyName))
+	code.WriteString("\treturn record, ok\n")
+	code.WriteString("}\n\n")
+
+	code.WriteString(fmt.Sprintf("// Lookup%sBy%s 按%s查找%s\n", typeName, parser.ToGoName(primary.Name), commentText(attributeLabel(primary)), commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("func Lookup%sBy%s(value %s) (%s, bool) {\n", typeName, parser.ToGoName(primary.Name),
+		primaryType, typeName))
+	code.WriteString(fmt.Sprintf("\trecord, ok := %s[value]\n", byPrimaryName))
+	code.WriteString("\treturn record, ok\n")
+	code.WriteString("}\n")
+
+	// 按语言查询记录的标签
+	if hasGroupLocales(group) {
+		tableName := toCamelCase(group.Name) + "LocaleLabels"
+		code.WriteString("\n")
+		code.WriteString(g.goLocaleTable(group, tableName, primaryType, group.Constants, func(constant *parser.Constant) string {
+			return recordFieldValue(group, constant, primary, primary.Type, "go")
+		}))
+		code.WriteString("\n// LocalizedLabel 返回记录在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言\n")
+		code.WriteString(fmt.Sprintf("func (r %s) LocalizedLabel(locale string) string {\n", typeName))
+		code.WriteString(g.goLocaleLookup(tableName))
+		code.WriteString(fmt.Sprintf("\treturn labels[r.%s]\n", parser.ToGoName(primary.Name)))
+		code.WriteString("}\n")
+	}
+	code.WriteString(goTemplateFuncs(group, ""))
+
+	return code.String()
+}
+
+// generateCollectionGroup 生成集合值常量组：每个常量是一个返回切片或映射的函数，每次调用返回新的副本，
+// 调用方修改返回值不会影响常量本身
+func (g *GoGenerator) generateCollectionGroup(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("// %s %s（集合值，每次调用返回新的副本）\n", parser.ToGoName(group.Name), commentText(group.Label)))
+	for _, constant := range expandAliases(group.Constants) {
+		kind, elementType, _ := parser.CollectionType(constant.Type)
+		valueType := "[]" + parser.GetGoType(elementType)
+		if kind == "map" {
+			valueType = "map[string]" + parser.GetGoType(elementType)
+		}
+		funcName := parser.ToGoName(group.Name) + parser.ToGoName(constant.Name)
+
+		code.WriteString("\n")
+		if hasDocDetails(constant) {
+			doc := *constant
+			doc.Label = funcName + " " + constant.Label
+			code.WriteString(goDoc(&doc, ""))
+		} else {
+			code.WriteString(fmt.Sprintf("// %s %s\n", funcName, commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("func %s() %s {\n", funcName, valueType))
+		code.WriteString(fmt.Sprintf("\treturn %s{%s}\n", valueType, strings.Join(collectionItems(constant, "go", ": "), ", ")))
+		code.WriteString("}\n")
+	}
+
+	return code.String()
+}
+
+// generateRegexGroup 生成正则常量组：每个常量是一个用 regexp.MustCompile 预编译的包级变量
+func (g *GoGenerator) generateRegexGroup(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("// %s %s（预编译的正则表达式）\n", parser.ToGoName(group.Name), commentText(group.Label)))
+	code.WriteString("var (\n")
+	for _, constant := range expandAliases(group.Constants) {
+		varName := parser.ToGoName(group.Name) + parser.ToGoName(constant.Name)
+		value := fmt.Sprintf("regexp.MustCompile(%s)", regexLiteral(constant.Value.(string), "go"))
+		if hasDocDetails(constant) {
+			code.WriteString(goDoc(constant, "\t"))
+			code.WriteString(fmt.Sprintf("\t%s = %s\n", varName, value))
+			continue
+		}
+		code.WriteString(fmt.Sprintf("\t%s = %s // %s\n", varName, value, commentText(constant.Label)))
+	}
+	code.WriteString(")\n")
+
+	return code.String()
+}
+
+// goImportBlock 生成分组形式的import声明
+func goImportBlock(imports []string) string {
+	var code strings.Builder
+	code.WriteString("import (\n")
+	for _, path := range imports {
+		code.WriteString(fmt.Sprintf("\t%q\n", path))
+	}
+	code.WriteString(")\n\n")
+	return code.String()
+}
+
+// goLocaleTable 生成各语言的标签表（语言代码 -> 键 -> 标签），keyExpr 返回常量作为键的表达式
+func (g *GoGenerator) goLocaleTable(group *parser.ConstantGroup, tableName, keyType string, constants []*parser.Constant,
+	keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("// %s %s各语言的标签，缺少翻译的常量使用默认语言的标签\n", tableName, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("var %s = map[string]map[%s]string{\n", tableName, keyType))
+	for _, locale := range parser.LabelLocales(group, g.Config.DefaultLocale) {
+		code.WriteString(fmt.Sprintf("\t%q: {\n", locale))
+		for _, constant := range constants {
+			code.WriteString(fmt.Sprintf("\t\t%s: %s,\n", keyExpr(constant), strconv.Quote(localizedLabel(constant, locale))))
+		}
+		code.WriteString("\t},\n")
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// goLocaleLookup 生成按语言选取标签表的语句：先按完整的语言代码查找，再按语言部分（如 en-US 的 en），最后使用默认语言
+func (g *GoGenerator) goLocaleLookup(tableName string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("\tlabels, ok := %s[locale]\n", tableName))
+	code.WriteString("\tif !ok {\n")
+	code.WriteString("\t\tlanguage, _, _ := strings.Cut(strings.ReplaceAll(locale, \"_\", \"-\"), \"-\")\n")
+	code.WriteString(fmt.Sprintf("\t\tif labels, ok = %s[language]; !ok {\n", tableName))
+	code.WriteString(fmt.Sprintf("\t\t\tlabels = %s[%q]\n", tableName, g.Config.DefaultLocale))
+	code.WriteString("\t\t}\n")
+	code.WriteString("\t}\n")
+
+	return code.String()
+}
+
+// goTemplateFuncs 为标签含占位符的常量生成格式化函数：receiver 非空时生成为常量组结构体的方法 FormatXxx，
+// 否则生成包级函数 Format<组名><常量名>
+func goTemplateFuncs(group *parser.ConstantGroup, receiver string) string {
+	var code strings.Builder
+
+	for _, constant := range templateConstants(group) {
+		template := constant.Template
+		funcName := "Format" + parser.ToGoName(group.Name) + parser.ToGoName(constant.Name)
+		signature := "func "
+		if receiver != "" {
+			funcName = "Format" + parser.ToGoName(constant.Name)
+			signature = fmt.Sprintf("func (%s) ", receiver)
+		}
+
+		types := make(map[string]string)
+		for _, param := range template.Params {
+			types[param.Name] = param.Type
+		}
+		var format strings.Builder
+		var args []string
+		for _, part := range template.Parts {
+			if part.Param == "" {
+				format.WriteString(strings.ReplaceAll(part.Text, "%", "%%"))
+				continue
+			}
+			switch types[part.Param] {
+			case "int", "int64":
+				format.WriteString("%d")
+			case "float":
+				format.WriteString("%g")
+			case "bool":
+				format.WriteString("%t")
+			default:
+				format.WriteString("%s")
+			}
+			args = append(args, toCamelCase(part.Param))
+		}
+
+		code.WriteString(fmt.Sprintf("\n// %s %s\n", funcName, templateDoc(constant)))
+		code.WriteString(fmt.Sprintf("%s%s(%s) string {\n", signature, funcName, templateParams(template, func(param parser.TemplateParam) string {
+			return toCamelCase(param.Name) + " " + parser.GetGoType(param.Type)
+		})))
+		code.WriteString(fmt.Sprintf("\treturn fmt.Sprintf(%s, %s)\n", strconv.Quote(format.String()), strings.Join(args, ", ")))
+		code.WriteString("}\n")
+	}
+
+	return code.String()
+}
+
+// goTreeFuncs 生成树形常量组的父子关系表及查询父节点、子节点、祖先的函数：receiver 非空时生成为常量组结构体的方法
+// Parent、Children 等，否则生成包级函数 <组名>Parent 等；keyExpr 返回常量在表中的表达式
+func goTreeFuncs(group *parser.ConstantGroup, receiver string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	valueType := parser.GetGoType(group.Constants[0].Type)
+	parentsName, childrenName := toCamelCase(group.Name)+"Parents", toCamelCase(group.Name)+"Children"
+	code.WriteString(fmt.Sprintf("\n// %s %s中各常量的父常量\n", parentsName, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("var %s = map[%s]%s{\n", parentsName, valueType, valueType))
+	for _, constant := range group.Constants {
+		if parent := parentConstant(group, constant); parent != nil {
+			code.WriteString(fmt.Sprintf("\t%s: %s,\n", keyExpr(constant), keyExpr(parent)))
+		}
+	}
+	code.WriteString("}\n")
+	code.WriteString(fmt.Sprintf("\n// %s %s中各常量的直接子常量\n", childrenName, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("var %s = map[%s][]%s{\n", childrenName, valueType, valueType))
+	for _, constant := range group.Constants {
+		var children []string
+		for _, child := range childConstants(group, constant) {
+			children = append(children, keyExpr(child))
+		}
+		if len(children) > 0 {
+			code.WriteString(fmt.Sprintf("\t%s: {%s},\n", keyExpr(constant), strings.Join(children, ", ")))
+		}
+	}
+	code.WriteString("}\n")
+
+	prefix, signature := parser.ToGoName(group.Name), "func "
+	if receiver != "" {
+		prefix, signature = "", fmt.Sprintf("func (%s) ", receiver)
+	}
+	code.WriteString(fmt.Sprintf("\n// %sParent 返回值的父节点，根节点和未知值返回false\n", prefix))
+	code.WriteString(fmt.Sprintf("%s%sParent(value %s) (%s, bool) {\n", signature, prefix, valueType, valueType))
+	code.WriteString(fmt.Sprintf("\tparent, ok := %s[value]\n", parentsName))
+	code.WriteString("\treturn parent, ok\n")
+	code.WriteString("}\n")
+	code.WriteString(fmt.Sprintf("\n// %sChildren 返回值的直接子节点，按定义顺序排列\n", prefix))
+	code.WriteString(fmt.Sprintf("%s%sChildren(value %s) []%s {\n", signature, prefix, valueType, valueType))
+	code.WriteString(fmt.Sprintf("\treturn append([]%s(nil), %s[value]...)\n", valueType, childrenName))
+	code.WriteString("}\n")
+	code.WriteString(fmt.Sprintf("\n// %sAncestors 返回值的全部祖先节点，从父节点到根节点排列\n", prefix))
+	code.WriteString(fmt.Sprintf("%s%sAncestors(value %s) []%s {\n", signature, prefix, valueType, valueType))
+	code.WriteString(fmt.Sprintf("\tvar ancestors []%s\n", valueType))
+	code.WriteString(fmt.Sprintf("\tfor parent, ok := %s[value]; ok; parent, ok = %s[parent] {\n", parentsName, parentsName))
+	code.WriteString("\t\tancestors = append(ancestors, parent)\n")
+	code.WriteString("\t}\n")
+	code.WriteString("\treturn ancestors\n")
+	code.WriteString("}\n")
+	code.WriteString(fmt.Sprintf("\n// %sIsDescendantOf 判断值是否为 ancestor 的后代节点（不含自身）\n", prefix))
+	code.WriteString(fmt.Sprintf("%s%sIsDescendantOf(value, ancestor %s) bool {\n", signature, prefix, valueType))
+	code.WriteString(fmt.Sprintf("\tfor parent, ok := %s[value]; ok; parent, ok = %s[parent] {\n", parentsName, parentsName))
+	code.WriteString("\t\tif parent == ancestor {\n")
+	code.WriteString("\t\t\treturn true\n")
+	code.WriteString("\t\t}\n")
+	code.WriteString("\t}\n")
+	code.WriteString("\treturn false\n")
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// goStateFuncs 生成状态机组的转换表及判断能否转换、查询后继状态和终止状态的函数：receiver 非空时生成为常量组结构体的方法，
+// 否则生成以组名为前缀的包级函数（如 OrderStatusCanTransition）；keyExpr 返回常量作为键的表达式
+func goStateFuncs(group *parser.ConstantGroup, receiver string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	valueType := parser.GetGoType(group.Constants[0].Type)
+	tableName := toCamelCase(group.Name) + "Transitions"
+	code.WriteString(fmt.Sprintf("\n// %s %s中各状态允许转换到的目标状态\n", tableName, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("var %s = map[%s][]%s{\n", tableName, valueType, valueType))
+	for _, entry := range stateTableEntries(group, keyExpr) {
+		code.WriteString(fmt.Sprintf("\t%s: {%s},\n", entry[0], entry[1]))
+	}
+	code.WriteString("}\n")
+
+	prefix, signature := parser.ToGoName(group.Name), "func "
+	if receiver != "" {
+		prefix, signature = "", fmt.Sprintf("func (%s) ", receiver)
+	}
+	code.WriteString(fmt.Sprintf("\n// %sCanTransition 判断能否从状态 from 转换到状态 to\n", prefix))
+	code.WriteString(fmt.Sprintf("%s%sCanTransition(from, to %s) bool {\n", signature, prefix, valueType))
+	code.WriteString(fmt.Sprintf("\tfor _, next := range %s[from] {\n", tableName))
+	code.WriteString("\t\tif next == to {\n")
+	code.WriteString("\t\t\treturn true\n")
+	code.WriteString("\t\t}\n")
+	code.WriteString("\t}\n")
+	code.WriteString("\treturn false\n")
+	code.WriteString("}\n")
+	code.WriteString(fmt.Sprintf("\n// %sNextStates 返回状态 from 允许转换到的目标状态，终止状态和未知值返回空\n", prefix))
+	code.WriteString(fmt.Sprintf("%s%sNextStates(from %s) []%s {\n", signature, prefix, valueType, valueType))
+	code.WriteString(fmt.Sprintf("\treturn append([]%s(nil), %s[from]...)\n", valueType, tableName))
+	code.WriteString("}\n")
+	code.WriteString(fmt.Sprintf("\n// %sIsTerminal 判断值是否为终止状态（没有可转换到的目标状态），未知值返回false\n", prefix))
+	code.WriteString(fmt.Sprintf("%s%sIsTerminal(value %s) bool {\n", signature, prefix, valueType))
+	code.WriteString(fmt.Sprintf("\tnext, ok := %s[value]\n", tableName))
+	code.WriteString("\treturn ok && len(next) == 0\n")
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// goSubsetFuncs 生成命名子集的成员列表及返回成员、判断是否属于子集的函数：receiver 非空时生成为常量组结构体的方法，
+// keyExpr 返回常量在成员列表中的表达式
+func goSubsetFuncs(group *parser.ConstantGroup, receiver string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	valueType := parser.GetGoType(group.Constants[0].Type)
+	prefix, signature := parser.ToGoName(group.Name), "func "
+	if receiver != "" {
+		prefix, signature = "", fmt.Sprintf("func (%s) ", receiver)
+	}
+	for _, subset := range group.Subsets {
+		tableName := toCamelCase(group.Name) + parser.ToGoName(subset.Name) + "Subset"
+		code.WriteString(fmt.Sprintf("\n// %s %s的子集：%s\n", tableName, commentText(group.Label), commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("var %s = []%s{%s}\n", tableName, valueType, subsetKeys(group, subset, keyExpr)))
+
+		name := parser.ToGoName(subset.Name)
+		code.WriteString(fmt.Sprintf("\n// %s%sSet 返回子集“%s”的成员\n", prefix, name, commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("%s%s%sSet() []%s {\n", signature, prefix, name, valueType))
+		code.WriteString(fmt.Sprintf("\treturn append([]%s(nil), %s...)\n", valueType, tableName))
+		code.WriteString("}\n")
+		code.WriteString(fmt.Sprintf("\n// %sIs%s 判断值是否属于子集“%s”\n", prefix, name, commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("%s%sIs%s(value %s) bool {\n", signature, prefix, name, valueType))
+		code.WriteString(fmt.Sprintf("\tfor _, member := range %s {\n", tableName))
+		code.WriteString("\t\tif member == value {\n")
+		code.WriteString("\t\t\treturn true\n")
+		code.WriteString("\t\t}\n")
+		code.WriteString("\t}\n")
+		code.WriteString("\treturn false\n")
+		code.WriteString("}\n")
+	}
+
+	return code.String()
+}
+
+// goDoc 生成常量的Go文档注释，废弃常量附加 Deprecated 段落
+func goDoc(constant *parser.Constant, indent string) string {
+	lines := docLines(constant)
+	if constant.Deprecated {
+		lines = append(lines, "", "Deprecated: "+commentText(deprecationMessage(constant)))
+	}
+	return lineDoc(indent, "//", lines)
+}
+
 // toCamelCase 转换为小驼峰命名
 func toCamelCase(name string) string {
 	parts := strings.Split(name, "_")
@@ -219,8 +976,7 @@ func (g *GoGenerator) generateKeyValuePairs(group *parser.ConstantGroup, structN
 	code.WriteString(fmt.Sprintf("\treturn map[string]%s{\n", valueType))
 	
 	// 按字母顺序排序
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToGoName(constants[i].Name) < parser.ToGoName(constants[j].Name)
 	})
@@ -245,13 +1001,13 @@ func (g *GoGenerator) generateFormat(group *parser.ConstantGroup, structName str
 	code.WriteString(fmt.Sprintf("// Format 根据值格式化%s的标签\n", groupName))
 	code.WriteString(fmt.Sprintf("func (s %s) Format(value %s) string {\n", structName, valueType))
 	code.WriteString(fmt.Sprintf("\tlabels := map[%s]string{\n", valueType))
-	for _, constant := range group.Constants {
+	for _, constant := range canonicalConstants(group) {
 		fieldName := parser.ToGoName(constant.Name)
 		label := constant.Label
 		if label == "" {
 			label = constant.Name
 		}
-		code.WriteString(fmt.Sprintf("\t\ts.%s: \"%s\",\n", fieldName, label))
+		code.WriteString(fmt.Sprintf("\t\ts.%s: %s,\n", fieldName, parser.FormatValue(label, "string", "go")))
 	}
 	code.WriteString("\t}\n\n")
 	code.WriteString("\tif label, exists := labels[value]; exists {\n")
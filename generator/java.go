@@ -2,7 +2,6 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -23,13 +22,29 @@ func NewJavaGenerator(config Config) *JavaGenerator {
 
 // Generate 生成Java代码
 func (g *JavaGenerator) Generate(constants *parser.ConstantsFile) error {
+	if err := checkIntegerRange(constants, g.Config.Language); err != nil {
+		return err
+	}
+
 	var code strings.Builder
 	
 	// 包声明
-	code.WriteString(fmt.Sprintf("package %s;\n\n", g.Config.PackageName))
+	code.WriteString(fmt.Sprintf("package %s;\n\n", g.GetQualifiedPackageName(constants.Namespace)))
 	
 	if g.Config.Mode == "const" {
-		// const模式 - 不需要导入
+		// const模式 - 只有记录组、集合值常量组、各语言的标签表、树形常量组的父子关系表、状态机组的转换表和命名子集需要导入
+		// 正则常量组需要导入Pattern
+		needsUtil := hasRecordGroup(constants) || hasCollectionGroup(constants) || hasLocalizedLabels(constants) || hasTreeGroup(constants) || hasStateMachine(constants) || hasSubsets(constants)
+		if needsUtil {
+			code.WriteString("import java.util.*;\n")
+		}
+		if hasRegexGroup(constants) {
+			code.WriteString("import java.util.regex.Pattern;\n")
+		}
+		if needsUtil || hasRegexGroup(constants) {
+			code.WriteString("\n")
+		}
+
 		// 文件头注释
 		code.WriteString(g.GetFileHeader(constants))
 		code.WriteString("\n")
@@ -56,7 +71,11 @@ func (g *JavaGenerator) Generate(constants *parser.ConstantsFile) error {
 	} else {
 		// class模式
 		// 导入
-		code.WriteString("import java.util.*;\n\n")
+		code.WriteString("import java.util.*;\n")
+		if hasRegexGroup(constants) {
+			code.WriteString("import java.util.regex.Pattern;\n")
+		}
+		code.WriteString("\n")
 		
 		// 文件头注释
 		code.WriteString(g.GetFileHeader(constants))
@@ -84,20 +103,29 @@ func (g *JavaGenerator) Generate(constants *parser.ConstantsFile) error {
 	}
 	
 	// 写入文件
-	outputPath := g.GetOutputFilePath(constants.FileName)
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
+	outputPath := g.GetOutputFilePath(constants)
+	return WriteOutputFile(outputPath, code.String())
 }
 
 // generateConstGroup 生成const模式的常量组
 func (g *JavaGenerator) generateConstGroup(group *parser.ConstantGroup, projectLabel string) string {
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionClass(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexClass(group)
+	}
+
 	var code strings.Builder
 	
 	// 生成注释
-	code.WriteString(fmt.Sprintf("\t// %s %s - %s\n", group.Name, group.Label, projectLabel))
+	code.WriteString(fmt.Sprintf("\t// %s %s - %s\n", group.Name, commentText(group.Label), commentText(projectLabel)))
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToJavaConstantName(constants[i].Name) < parser.ToJavaConstantName(constants[j].Name)
 	})
@@ -106,16 +134,86 @@ func (g *JavaGenerator) generateConstGroup(group *parser.ConstantGroup, projectL
 	for _, constant := range constants {
 		constName := fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
 		valueType := parser.GetJavaType(constant.Type)
-		value := parser.FormatValue(constant.Value, constant.Type, "java")
-		comment := constant.Label
+		value := constantValue(group, constant, "java", "const")
+		if hasDocDetails(constant) {
+			code.WriteString(javaDoc(constant, "\t"))
+			code.WriteString(fmt.Sprintf("\tpublic static final %s %s = %s;\n", valueType, constName, value))
+			continue
+		}
+		comment := commentText(constant.Label)
 		code.WriteString(fmt.Sprintf("\tpublic static final %s %s = %s; // %s\n", valueType, constName, value, comment))
 	}
+
+	// 附加属性的查询方法
+	for _, attribute := range group.Attributes {
+		methodName := "get" + parser.ToJavaName(group.Name) + parser.ToJavaName(attribute.Name)
+		code.WriteString("\n")
+		code.WriteString(javaAttributeMethod(group, attribute, "\t", methodName, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		tableName := toCamelCase(group.Name) + "LocaleLabels"
+		valueType := parser.GetJavaType(group.Constants[0].Type)
+		code.WriteString("\n")
+		code.WriteString(g.javaLocaleTable(group, "\t", tableName, getBoxedType(valueType), canonicalConstants(group),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+		code.WriteString("\n")
+		code.WriteString(g.javaLocalizedLabelMethod(group, "\t", "get"+parser.ToJavaName(group.Name)+"LocalizedLabel", tableName, valueType))
+	}
+
+	// 标签模板的格式化方法
+	code.WriteString(javaTemplateMethods(group, "\t", "format"+parser.ToJavaName(group.Name)))
+
+	// 树形常量组的父子关系查询方法
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n")
+		code.WriteString(javaTreeMethods(group, "\t", toCamelCase(group.Name), parser.ToJavaName(group.Name),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+	}
+
+	// 状态机组的状态转换方法
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n")
+		code.WriteString(javaStateMethods(group, "\t", toCamelCase(group.Name), parser.ToJavaName(group.Name),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+	}
+
+	// 命名子集的集合及判断方法
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n")
+		code.WriteString(javaSubsetMembers(group, "\t", strings.ToUpper(group.Name)+"_", parser.ToJavaName(group.Name),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+	}
 	
 	return code.String()
 }
 
 // generateGroupClass 生成常量组内部类
 func (g *JavaGenerator) generateGroupClass(group *parser.ConstantGroup, _ string) string {
+	if group.Flags {
+		return g.generateFlagsEnum(group)
+	}
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionClass(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexClass(group)
+	}
+
 	var code strings.Builder
 	
 	className := parser.ToJavaName(group.Name)
@@ -124,8 +222,7 @@ func (g *JavaGenerator) generateGroupClass(group *parser.ConstantGroup, _ string
 	code.WriteString(fmt.Sprintf("\tpublic static final class %s {\n", className))
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToJavaConstantName(constants[i].Name) < parser.ToJavaConstantName(constants[j].Name)
 	})
@@ -134,13 +231,17 @@ func (g *JavaGenerator) generateGroupClass(group *parser.ConstantGroup, _ string
 	for _, constant := range constants {
 		constName := parser.ToJavaConstantName(constant.Name)
 		javaType := parser.GetJavaType(constant.Type)
-		value := parser.FormatValue(constant.Value, constant.Type, "java")
-		comment := constant.Label
+		value := constantValue(group, constant, "java", "class")
+		comment := commentText(constant.Label)
 		if comment == "" {
 			comment = constant.Label
 		}
 		
-		code.WriteString(fmt.Sprintf("\t\t/** %s */\n", comment))
+		if hasDocDetails(constant) {
+			code.WriteString(javaDoc(constant, "\t\t"))
+		} else {
+			code.WriteString(fmt.Sprintf("\t\t/** %s */\n", comment))
+		}
 		code.WriteString(fmt.Sprintf("\t\tpublic static final %s %s = %s;\n", javaType, constName, value))
 	}
 	
@@ -163,7 +264,44 @@ func (g *JavaGenerator) generateGroupClass(group *parser.ConstantGroup, _ string
 	code.WriteString(g.generateIsValid(group))
 	code.WriteString("\n")
 	code.WriteString(g.generateFromString(group))
-	
+
+	// 附加属性的查询方法
+	for _, attribute := range group.Attributes {
+		code.WriteString("\n")
+		code.WriteString(javaAttributeMethod(group, attribute, "\t\t", "get"+parser.ToJavaName(attribute.Name),
+			func(constant *parser.Constant) string {
+				return parser.ToJavaConstantName(constant.Name)
+			}))
+	}
+	if hasGroupLocales(group) {
+		valueType := parser.GetJavaType(group.Constants[0].Type)
+		code.WriteString("\n")
+		code.WriteString(g.javaLocaleTable(group, "\t\t", "localeLabels", getBoxedType(valueType), canonicalConstants(group),
+			func(constant *parser.Constant) string {
+				return parser.ToJavaConstantName(constant.Name)
+			}))
+		code.WriteString("\n")
+		code.WriteString(g.javaLocalizedLabelMethod(group, "\t\t", "getLocalizedLabel", "localeLabels", valueType))
+	}
+	code.WriteString(javaTemplateMethods(group, "\t\t", "format"))
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n")
+		code.WriteString(javaTreeMethods(group, "\t\t", "", "", func(constant *parser.Constant) string {
+			return parser.ToJavaConstantName(constant.Name)
+		}))
+	}
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n")
+		code.WriteString(javaStateMethods(group, "\t\t", "", "", func(constant *parser.Constant) string {
+			return parser.ToJavaConstantName(constant.Name)
+		}))
+	}
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n")
+		code.WriteString(javaSubsetMembers(group, "\t\t", "", "", func(constant *parser.Constant) string {
+			return parser.ToJavaConstantName(constant.Name)
+		}))
+	}
 	
 	code.WriteString("\t}\n")
 	
@@ -250,8 +388,7 @@ func (g *JavaGenerator) generateGetKeyValuePairs(group *parser.ConstantGroup) st
 	code.WriteString(fmt.Sprintf("\t\t\tMap<String, %s> pairs = new HashMap<>();\n", boxedType))
 	
 	// 按字母顺序排序
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToJavaConstantName(constants[i].Name) < parser.ToJavaConstantName(constants[j].Name)
 	})
@@ -281,13 +418,13 @@ func (g *JavaGenerator) generateFormat(group *parser.ConstantGroup) string {
 	code.WriteString("\t\t */\n")
 	code.WriteString(fmt.Sprintf("\t\tpublic static String format(%s value) {\n", javaType))
 	code.WriteString(fmt.Sprintf("\t\t\tMap<%s, String> labels = new HashMap<>();\n", boxedType))
-	for _, constant := range group.Constants {
+	for _, constant := range canonicalConstants(group) {
 		constName := parser.ToJavaConstantName(constant.Name)
 		label := constant.Label
 		if label == "" {
 			label = constant.Name
 		}
-		code.WriteString(fmt.Sprintf("\t\t\tlabels.put(%s, \"%s\");\n", constName, label))
+		code.WriteString(fmt.Sprintf("\t\t\tlabels.put(%s, %s);\n", constName, parser.FormatValue(label, "string", "java")))
 	}
 	code.WriteString("\t\t\t\n")
 	code.WriteString("\t\t\tif (labels.containsKey(value)) {\n")
@@ -335,7 +472,690 @@ func (g *JavaGenerator) generateFromString(group *parser.ConstantGroup) string {
 	
 	return code.String()
 }
+// generateFlagsEnum 生成位标志组的枚举，组合值以整数掩码表示，并提供与EnumSet互相转换的静态方法
+func (g *JavaGenerator) generateFlagsEnum(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	enumName := parser.ToJavaName(group.Name)
+	dataType := group.Constants[0].Type
+	javaType := parser.GetJavaType(dataType)
+	constants := flagConstants(group)
+
+	code.WriteString(fmt.Sprintf("\t/** %s（位标志，可按位组合） */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("\tpublic enum %s {\n", enumName))
+	for i, constant := range constants {
+		if hasDocDetails(constant) {
+			code.WriteString(javaDoc(constant, "\t\t"))
+		} else {
+			code.WriteString(fmt.Sprintf("\t\t/** %s */\n", commentText(constant.Label)))
+		}
+		separator := ","
+		if i == len(constants)-1 {
+			separator = ";"
+		}
+		code.WriteString(fmt.Sprintf("\t\t%s(%s, %s)%s\n", parser.ToJavaConstantName(constant.Name),
+			parser.FormatConstantValue(constant, dataType, "java"),
+			parser.FormatValue(constantLabel(constant), "string", "java"), separator))
+	}
+
+	code.WriteString("\n\t\t/** 所有已知标志的组合 */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic static final %s MASK = %s;\n\n", javaType, parser.FormatValue(flagMask(group), dataType, "java")))
+
+	// 别名指向同值的枚举常量
+	for _, constant := range expandAliases(constants) {
+		if constant.AliasOf == "" {
+			continue
+		}
+		code.WriteString(javaDoc(constant, "\t\t"))
+		code.WriteString(fmt.Sprintf("\t\tpublic static final %s %s = %s;\n\n", enumName,
+			parser.ToJavaConstantName(constant.Name), parser.ToJavaConstantName(constant.AliasOf)))
+	}
+	code.WriteString(fmt.Sprintf("\t\tprivate final %s value;\n", javaType))
+	code.WriteString("\t\tprivate final String label;\n\n")
+	code.WriteString(fmt.Sprintf("\t\t%s(%s value, String label) {\n", enumName, javaType))
+	code.WriteString("\t\t\tthis.value = value;\n")
+	code.WriteString("\t\t\tthis.label = label;\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/** 标志的位值 */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic %s getValue() {\n", javaType))
+	code.WriteString("\t\t\treturn value;\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/** 标志的标签 */\n")
+	code.WriteString("\t\tpublic String getLabel() {\n")
+	code.WriteString("\t\t\treturn label;\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/**\n")
+	code.WriteString("\t\t * 判断组合值是否包含指定标志\n")
+	code.WriteString("\t\t * @param mask 组合值\n")
+	code.WriteString("\t\t * @param flag 标志\n")
+	code.WriteString("\t\t * @return 是否包含\n")
+	code.WriteString("\t\t */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic static boolean has(%s mask, %s flag) {\n", javaType, enumName))
+	code.WriteString("\t\t\treturn (mask & flag.value) == flag.value;\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/**\n")
+	code.WriteString("\t\t * 返回添加指定标志后的组合值\n")
+	code.WriteString("\t\t * @param mask 组合值\n")
+	code.WriteString("\t\t * @param flag 标志\n")
+	code.WriteString("\t\t * @return 新的组合值\n")
+	code.WriteString("\t\t */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic static %s add(%s mask, %s flag) {\n", javaType, javaType, enumName))
+	code.WriteString("\t\t\treturn mask | flag.value;\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/**\n")
+	code.WriteString("\t\t * 返回移除指定标志后的组合值\n")
+	code.WriteString("\t\t * @param mask 组合值\n")
+	code.WriteString("\t\t * @param flag 标志\n")
+	code.WriteString("\t\t * @return 新的组合值\n")
+	code.WriteString("\t\t */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic static %s remove(%s mask, %s flag) {\n", javaType, javaType, enumName))
+	code.WriteString("\t\t\treturn mask & ~flag.value;\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/**\n")
+	code.WriteString("\t\t * 将组合值拆分为标志集合\n")
+	code.WriteString("\t\t * @param mask 组合值\n")
+	code.WriteString("\t\t * @return 包含的标志\n")
+	code.WriteString("\t\t */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic static EnumSet<%s> toEnumSet(%s mask) {\n", enumName, javaType))
+	code.WriteString(fmt.Sprintf("\t\t\tEnumSet<%s> flags = EnumSet.noneOf(%s.class);\n", enumName, enumName))
+	code.WriteString(fmt.Sprintf("\t\t\tfor (%s flag : values()) {\n", enumName))
+	code.WriteString("\t\t\t\tif (has(mask, flag)) {\n")
+	code.WriteString("\t\t\t\t\tflags.add(flag);\n")
+	code.WriteString("\t\t\t\t}\n")
+	code.WriteString("\t\t\t}\n")
+	code.WriteString("\t\t\treturn flags;\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/**\n")
+	code.WriteString("\t\t * 将组合值拆分为标志列表，按位从低到高排列\n")
+	code.WriteString("\t\t * @param mask 组合值\n")
+	code.WriteString("\t\t * @return 包含的标志\n")
+	code.WriteString("\t\t */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic static List<%s> toList(%s mask) {\n", enumName, javaType))
+	code.WriteString("\t\t\treturn new ArrayList<>(toEnumSet(mask));\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/**\n")
+	code.WriteString("\t\t * 将标志集合合并为组合值\n")
+	code.WriteString("\t\t * @param flags 标志集合\n")
+	code.WriteString("\t\t * @return 组合值\n")
+	code.WriteString("\t\t */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic static %s toMask(Set<%s> flags) {\n", javaType, enumName))
+	code.WriteString(fmt.Sprintf("\t\t\t%s mask = 0;\n", javaType))
+	code.WriteString(fmt.Sprintf("\t\t\tfor (%s flag : flags) {\n", enumName))
+	code.WriteString("\t\t\t\tmask |= flag.value;\n")
+	code.WriteString("\t\t\t}\n")
+	code.WriteString("\t\t\treturn mask;\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/**\n")
+	code.WriteString("\t\t * 格式化组合值，标签以|分隔，如 读|写\n")
+	code.WriteString("\t\t * @param mask 组合值\n")
+	code.WriteString("\t\t * @return 格式化后的标签\n")
+	code.WriteString("\t\t */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic static String format(%s mask) {\n", javaType))
+	code.WriteString("\t\t\tif (!isValid(mask)) {\n")
+	code.WriteString("\t\t\t\treturn \"Unknown(\" + mask + \")\";\n")
+	code.WriteString("\t\t\t}\n")
+	code.WriteString("\t\t\tStringJoiner joiner = new StringJoiner(\"|\");\n")
+	code.WriteString(fmt.Sprintf("\t\t\tfor (%s flag : toEnumSet(mask)) {\n", enumName))
+	code.WriteString("\t\t\t\tjoiner.add(flag.label);\n")
+	code.WriteString("\t\t\t}\n")
+	code.WriteString("\t\t\treturn joiner.toString();\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t/**\n")
+	code.WriteString("\t\t * 验证组合值是否只包含已知标志\n")
+	code.WriteString("\t\t * @param mask 组合值\n")
+	code.WriteString("\t\t * @return 是否有效\n")
+	code.WriteString("\t\t */\n")
+	code.WriteString(fmt.Sprintf("\t\tpublic static boolean isValid(%s mask) {\n", javaType))
+	code.WriteString("\t\t\treturn (mask & ~MASK) == 0;\n")
+	code.WriteString("\t\t}\n")
+
+	// 附加属性的getter
+	for _, attribute := range group.Attributes {
+		attrType := parser.GetJavaType(attribute.Type)
+		code.WriteString(fmt.Sprintf("\n\t\t/** 标志的%s */\n", commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("\t\tpublic %s get%s() {\n", attrType, parser.ToJavaName(attribute.Name)))
+		code.WriteString("\t\t\tswitch (this) {\n")
+		for _, constant := range constants {
+			code.WriteString(fmt.Sprintf("\t\t\t\tcase %s:\n", parser.ToJavaConstantName(constant.Name)))
+			code.WriteString(fmt.Sprintf("\t\t\t\t\treturn %s;\n",
+				parser.FormatValue(constant.Attributes[attribute.Name], attribute.Type, "java")))
+		}
+		code.WriteString("\t\t\t\tdefault:\n")
+		code.WriteString(fmt.Sprintf("\t\t\t\t\treturn %s;\n", parser.FormatValue(attribute.Default, attribute.Type, "java")))
+		code.WriteString("\t\t\t}\n")
+		code.WriteString("\t\t}\n")
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.javaLocaleTable(group, "\t\t", "localeLabels", enumName, constants, func(constant *parser.Constant) string {
+			return parser.ToJavaConstantName(constant.Name)
+		}))
+
+		code.WriteString("\n\t\t/**\n")
+		code.WriteString("\t\t * 获取标志在指定语言下的标签，语言未知时使用默认语言\n")
+		code.WriteString("\t\t * @param locale 语言代码，如 en、en-US\n")
+		code.WriteString("\t\t * @return 标志的标签\n")
+		code.WriteString("\t\t */\n")
+		code.WriteString("\t\tpublic String getLocalizedLabel(String locale) {\n")
+		code.WriteString(g.javaLocaleLookup("\t\t\t", "localeLabels", enumName))
+		code.WriteString("\t\t\treturn labels.get(this);\n")
+		code.WriteString("\t\t}\n\n")
+
+		code.WriteString("\t\t/**\n")
+		code.WriteString("\t\t * 按指定语言格式化组合值，标签以|分隔\n")
+		code.WriteString("\t\t * @param mask 组合值\n")
+		code.WriteString("\t\t * @param locale 语言代码，如 en、en-US\n")
+		code.WriteString("\t\t * @return 格式化后的标签\n")
+		code.WriteString("\t\t */\n")
+		code.WriteString(fmt.Sprintf("\t\tpublic static String format(%s mask, String locale) {\n", javaType))
+		code.WriteString("\t\t\tif (!isValid(mask)) {\n")
+		code.WriteString("\t\t\t\treturn \"Unknown(\" + mask + \")\";\n")
+		code.WriteString("\t\t\t}\n")
+		code.WriteString("\t\t\tStringJoiner joiner = new StringJoiner(\"|\");\n")
+		code.WriteString(fmt.Sprintf("\t\t\tfor (%s flag : toEnumSet(mask)) {\n", enumName))
+		code.WriteString("\t\t\t\tjoiner.add(flag.getLocalizedLabel(locale));\n")
+		code.WriteString("\t\t\t}\n")
+		code.WriteString("\t\t\treturn joiner.toString();\n")
+		code.WriteString("\t\t}\n")
+	}
+	code.WriteString(javaTemplateMethods(group, "\t\t", "format"))
+
+	code.WriteString("\t}\n")
+
+	return code.String()
+}
+
+// generateRecordClass 生成记录组的不可变类：每个常量是一个静态实例，字段通过getter访问，
+// 并提供按键名和按主值查找的静态方法
+func (g *JavaGenerator) generateRecordClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	fields := recordFields(group)
+	primary := fields[0]
+	primaryType := parser.GetJavaType(primary.Type)
+	byPrimaryName := "by" + parser.ToJavaName(primary.Name)
+	constants := expandAliases(group.Constants)
+
+	code.WriteString(fmt.Sprintf("\t/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("\tpublic static final class %s {\n", className))
+
+	// 记录实例，别名指向同一个实例
+	for _, constant := range constants {
+		value := parser.ToJavaConstantName(constant.AliasOf)
+		if constant.AliasOf == "" {
+			args := []string{
+				parser.FormatValue(constant.Name, "string", "java"),
+				parser.FormatValue(constant.Label, "string", "java"),
+			}
+			for _, field := range fields {
+				args = append(args, recordFieldValue(group, constant, field, field.Type, "java"))
+			}
+			value = fmt.Sprintf("new %s(%s)", className, strings.Join(args, ", "))
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(javaDoc(constant, "\t\t"))
+		} else {
+			code.WriteString(fmt.Sprintf("\t\t/** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("\t\tpublic static final %s %s = %s;\n", className, parser.ToJavaConstantName(constant.Name), value))
+	}
+
+	// 查询表
+	var records []string
+	for _, constant := range group.Constants {
+		records = append(records, parser.ToJavaConstantName(constant.Name))
+	}
+	code.WriteString(fmt.Sprintf("\n\t\tprivate static final List<%s> records = Collections.unmodifiableList(Arrays.asList(%s));\n",
+		className, strings.Join(records, ", ")))
+	code.WriteString(fmt.Sprintf("\t\tprivate static final Map<String, %s> byKey = new HashMap<>();\n", className))
+	code.WriteString(fmt.Sprintf("\t\tprivate static final Map<%s, %s> %s = new HashMap<>();\n\n",
+		getBoxedType(primaryType), className, byPrimaryName))
+	code.WriteString("\t\tstatic {\n")
+	for _, constant := range constants {
+		code.WriteString(fmt.Sprintf("\t\t\tbyKey.put(%s, %s);\n", parser.FormatValue(constant.Name, "string", "java"),
+			parser.ToJavaConstantName(constant.Name)))
+	}
+	for _, constant := range group.Constants {
+		code.WriteString(fmt.Sprintf("\t\t\t%s.put(%s, %s);\n", byPrimaryName,
+			recordFieldValue(group, constant, primary, primary.Type, "java"), parser.ToJavaConstantName(constant.Name)))
+	}
+	code.WriteString("\t\t}\n\n")
 
+	// 字段与构造函数
+	code.WriteString("\t\tprivate final String key;\n")
+	code.WriteString("\t\tprivate final String label;\n")
+	params := []string{"String key", "String label"}
+	for _, field := range fields {
+		fieldType := parser.GetJavaType(field.Type)
+		code.WriteString(fmt.Sprintf("\t\tprivate final %s %s;\n", fieldType, toCamelCase(field.Name)))
+		params = append(params, fieldType+" "+toCamelCase(field.Name))
+	}
+	code.WriteString(fmt.Sprintf("\n\t\tprivate %s(%s) {\n", className, strings.Join(params, ", ")))
+	code.WriteString("\t\t\tthis.key = key;\n")
+	code.WriteString("\t\t\tthis.label = label;\n")
+	for _, field := range fields {
+		code.WriteString(fmt.Sprintf("\t\t\tthis.%s = %s;\n", toCamelCase(field.Name), toCamelCase(field.Name)))
+	}
+	code.WriteString("\t\t}\n")
+
+	// getter
+	getters := []*parser.Attribute{{Name: "key", Type: "string", Label: "键名"}, {Name: "label", Type: "string", Label: "标签"}}
+	for _, field := range append(getters, fields...) {
+		code.WriteString(fmt.Sprintf("\n\t\t/** 获取%s */\n", commentText(attributeLabel(field))))
+		code.WriteString(fmt.Sprintf("\t\tpublic %s get%s() {\n", parser.GetJavaType(field.Type), parser.ToJavaName(field.Name)))
+		code.WriteString(fmt.Sprintf("\t\t\treturn %s;\n", toCamelCase(field.Name)))
+		code.WriteString("\t\t}\n")
+	}
+
+	// 查询方法
+	code.WriteString(fmt.Sprintf("\n\t\t/** 获取所有%s，按源文件顺序排列 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("\t\tpublic static List<%s> allRecords() {\n", className))
+	code.WriteString("\t\t\treturn records;\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString(fmt.Sprintf("\t\t/** 按键名（含别名）查找%s，不存在时返回null */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("\t\tpublic static %s fromKey(String key) {\n", className))
+	code.WriteString("\t\t\treturn byKey.get(key);\n")
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString(fmt.Sprintf("\t\t/** 按%s查找%s，不存在时返回null */\n", commentText(attributeLabel(primary)), commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("\t\tpublic static %s from%s(%s value) {\n", className, parser.ToJavaName(primary.Name), primaryType))
+	code.WriteString(fmt.Sprintf("\t\t\treturn %s.get(value);\n", byPrimaryName))
+	code.WriteString("\t\t}\n\n")
+
+	code.WriteString("\t\t@Override\n")
+	code.WriteString("\t\tpublic String toString() {\n")
+	code.WriteString("\t\t\treturn key;\n")
+	code.WriteString("\t\t}\n")
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.javaLocaleTable(group, "\t\t", "localeLabels", getBoxedType(primaryType), group.Constants,
+			func(constant *parser.Constant) string {
+				return recordFieldValue(group, constant, primary, primary.Type, "java")
+			}))
+		code.WriteString(fmt.Sprintf("\n\t\t/** 获取%s在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言 */\n", commentText(group.Label)))
+		code.WriteString("\t\tpublic String getLocalizedLabel(String locale) {\n")
+		code.WriteString(g.javaLocaleLookup("\t\t\t", "localeLabels", getBoxedType(primaryType)))
+		code.WriteString(fmt.Sprintf("\t\t\treturn labels.get(%s);\n", toCamelCase(primary.Name)))
+		code.WriteString("\t\t}\n")
+	}
+	code.WriteString(javaTemplateMethods(group, "\t\t", "format"))
+
+	code.WriteString("\t}\n")
+
+	return code.String()
+}
+
+// javaAttributeMethod 生成按值查询附加属性的静态方法，constExpr 返回常量在比较中的表达式，未知值返回属性的默认值
+func javaAttributeMethod(group *parser.ConstantGroup, attribute *parser.Attribute, indent, methodName string,
+	constExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	dataType := group.Constants[0].Type
+	attrType := parser.GetJavaType(attribute.Type)
+	code.WriteString(indent + "/**\n")
+	code.WriteString(fmt.Sprintf("%s * 获取值对应的%s\n", indent, commentText(attributeLabel(attribute))))
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(fmt.Sprintf("%s * @return %s，未知值返回默认值\n", indent, commentText(attributeLabel(attribute))))
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%spublic static %s %s(%s value) {\n", indent, attrType, methodName, parser.GetJavaType(dataType)))
+	for _, constant := range canonicalConstants(group) {
+		condition := fmt.Sprintf("value == %s", constExpr(constant))
+		if dataType == "string" {
+			condition = fmt.Sprintf("%s.equals(value)", constExpr(constant))
+		}
+		code.WriteString(fmt.Sprintf("%s\tif (%s) {\n", indent, condition))
+		code.WriteString(fmt.Sprintf("%s\t\treturn %s;\n", indent, parser.FormatValue(constant.Attributes[attribute.Name], attribute.Type, "java")))
+		code.WriteString(indent + "\t}\n")
+	}
+	code.WriteString(fmt.Sprintf("%s\treturn %s;\n", indent, parser.FormatValue(attribute.Default, attribute.Type, "java")))
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// javaLocaleTable 生成各语言的标签表（语言代码 -> 键 -> 标签）及填充它的静态初始化块，keyExpr 返回常量作为键的表达式
+func (g *JavaGenerator) javaLocaleTable(group *parser.ConstantGroup, indent, tableName, keyType string, constants []*parser.Constant,
+	keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("%s/** %s各语言的标签，缺少翻译的常量使用默认语言的标签 */\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%sprivate static final Map<String, Map<%s, String>> %s = new HashMap<>();\n\n", indent, keyType, tableName))
+	code.WriteString(indent + "static {\n")
+	code.WriteString(fmt.Sprintf("%s\tMap<%s, String> labels;\n", indent, keyType))
+	for _, locale := range parser.LabelLocales(group, g.Config.DefaultLocale) {
+		code.WriteString(fmt.Sprintf("%s\tlabels = new HashMap<>();\n", indent))
+		for _, constant := range constants {
+			code.WriteString(fmt.Sprintf("%s\tlabels.put(%s, %s);\n", indent, keyExpr(constant),
+				parser.FormatValue(localizedLabel(constant, locale), "string", "java")))
+		}
+		code.WriteString(fmt.Sprintf("%s\t%s.put(%q, labels);\n", indent, tableName, locale))
+	}
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// javaLocaleLookup 生成按语言选取标签表的语句：先按完整的语言代码查找，再按语言部分（如 en-US 的 en），最后使用默认语言
+func (g *JavaGenerator) javaLocaleLookup(indent, tableName, keyType string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("%sMap<%s, String> labels = %s.get(locale);\n", indent, keyType, tableName))
+	code.WriteString(fmt.Sprintf("%sif (labels == null) {\n", indent))
+	code.WriteString(fmt.Sprintf("%s\tlabels = %s.get(locale.replace('_', '-').split(\"-\")[0]);\n", indent, tableName))
+	code.WriteString(indent + "}\n")
+	code.WriteString(fmt.Sprintf("%sif (labels == null) {\n", indent))
+	code.WriteString(fmt.Sprintf("%s\tlabels = %s.get(%q);\n", indent, tableName, g.Config.DefaultLocale))
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// javaLocalizedLabelMethod 生成按值和语言查询标签的静态方法，未知值返回null
+func (g *JavaGenerator) javaLocalizedLabelMethod(group *parser.ConstantGroup, indent, methodName, tableName, valueType string) string {
+	var code strings.Builder
+
+	code.WriteString(indent + "/**\n")
+	code.WriteString(fmt.Sprintf("%s * 获取值在指定语言下的%s标签，语言未知时使用默认语言\n", indent, commentText(group.Label)))
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @param locale 语言代码，如 en、en-US\n")
+	code.WriteString(indent + " * @return 标签，未知值返回null\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%spublic static String %s(%s value, String locale) {\n", indent, methodName, valueType))
+	code.WriteString(g.javaLocaleLookup(indent+"\t", tableName, getBoxedType(valueType)))
+	code.WriteString(fmt.Sprintf("%s\treturn labels.get(value);\n", indent))
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// javaTemplateMethods 为标签含占位符的常量生成静态格式化方法，方法名为 prefix 加常量名，
+// 方法体按模板片段拼接字符串
+func javaTemplateMethods(group *parser.ConstantGroup, indent, prefix string) string {
+	var code strings.Builder
+
+	for _, constant := range templateConstants(group) {
+		template := constant.Template
+		var parts []string
+		for _, part := range template.Parts {
+			if part.Param == "" {
+				parts = append(parts, parser.FormatValue(part.Text, "string", "java"))
+				continue
+			}
+			parts = append(parts, toCamelCase(part.Param))
+		}
+		// 以参数开头时先拼接空字符串，保证结果是字符串
+		if template.Parts[0].Param != "" {
+			parts = append([]string{`""`}, parts...)
+		}
+
+		code.WriteString(fmt.Sprintf("\n%s/** %s */\n", indent, templateDoc(constant)))
+		code.WriteString(fmt.Sprintf("%spublic static String %s%s(%s) {\n", indent, prefix, parser.ToJavaName(constant.Name),
+			templateParams(template, func(param parser.TemplateParam) string {
+				return parser.GetJavaType(param.Type) + " " + toCamelCase(param.Name)
+			})))
+		code.WriteString(fmt.Sprintf("%s\treturn %s;\n", indent, strings.Join(parts, " + ")))
+		code.WriteString(indent + "}\n")
+	}
+
+	return code.String()
+}
+
+// javaTreeMethods 生成树形常量组的父子关系表及查询父节点、子节点、祖先的静态方法，keyExpr 返回常量作为键的表达式；
+// 表名以 tablePrefix 开头（为空时为 parents、children），方法名中插入 name（如 getRegionParent、isRegionDescendantOf）
+func javaTreeMethods(group *parser.ConstantGroup, indent, tablePrefix, name string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	valueType := parser.GetJavaType(group.Constants[0].Type)
+	boxedType := getBoxedType(valueType)
+	parentsName, childrenName := "parents", "children"
+	if tablePrefix != "" {
+		parentsName, childrenName = tablePrefix+"Parents", tablePrefix+"Children"
+	}
+	code.WriteString(fmt.Sprintf("%s/** %s中各常量的父常量 */\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%sprivate static final Map<%s, %s> %s = new HashMap<>();\n", indent, boxedType, boxedType, parentsName))
+	code.WriteString(fmt.Sprintf("%s/** %s中各常量的直接子常量 */\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%sprivate static final Map<%s, List<%s>> %s = new HashMap<>();\n\n", indent, boxedType, boxedType, childrenName))
+	code.WriteString(indent + "static {\n")
+	for _, constant := range group.Constants {
+		if parent := parentConstant(group, constant); parent != nil {
+			code.WriteString(fmt.Sprintf("%s\t%s.put(%s, %s);\n", indent, parentsName, keyExpr(constant), keyExpr(parent)))
+		}
+	}
+	for _, constant := range group.Constants {
+		var children []string
+		for _, child := range childConstants(group, constant) {
+			children = append(children, keyExpr(child))
+		}
+		if len(children) > 0 {
+			code.WriteString(fmt.Sprintf("%s\t%s.put(%s, Collections.unmodifiableList(Arrays.asList(%s)));\n", indent, childrenName,
+				keyExpr(constant), strings.Join(children, ", ")))
+		}
+	}
+	code.WriteString(indent + "}\n")
+
+	equals := "parent == ancestor"
+	if valueType == "String" {
+		equals = "parent.equals(ancestor)"
+	}
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 获取值的父节点\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @return 父节点的值，根节点和未知值返回null\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%spublic static %s get%sParent(%s value) {\n", indent, boxedType, name, valueType))
+	code.WriteString(fmt.Sprintf("%s\treturn %s.get(value);\n", indent, parentsName))
+	code.WriteString(indent + "}\n")
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 获取值的直接子节点，按定义顺序排列\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @return 子节点的值，没有子节点时返回空列表\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%spublic static List<%s> get%sChildren(%s value) {\n", indent, boxedType, name, valueType))
+	code.WriteString(fmt.Sprintf("%s\treturn %s.getOrDefault(value, Collections.emptyList());\n", indent, childrenName))
+	code.WriteString(indent + "}\n")
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 获取值的全部祖先节点，从父节点到根节点排列\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @return 祖先节点的值，根节点和未知值返回空列表\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%spublic static List<%s> get%sAncestors(%s value) {\n", indent, boxedType, name, valueType))
+	code.WriteString(fmt.Sprintf("%s\tList<%s> ancestors = new ArrayList<>();\n", indent, boxedType))
+	code.WriteString(fmt.Sprintf("%s\tfor (%s parent = %s.get(value); parent != null; parent = %s.get(parent)) {\n", indent, boxedType, parentsName, parentsName))
+	code.WriteString(fmt.Sprintf("%s\t\tancestors.add(parent);\n", indent))
+	code.WriteString(indent + "\t}\n")
+	code.WriteString(fmt.Sprintf("%s\treturn ancestors;\n", indent))
+	code.WriteString(indent + "}\n")
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 判断值是否为指定节点的后代节点（不含自身）\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @param ancestor 祖先节点的值\n")
+	code.WriteString(indent + " * @return 是否为后代节点\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%spublic static boolean is%sDescendantOf(%s value, %s ancestor) {\n", indent, name, valueType, valueType))
+	code.WriteString(fmt.Sprintf("%s\tfor (%s parent = %s.get(value); parent != null; parent = %s.get(parent)) {\n", indent, boxedType, parentsName, parentsName))
+	code.WriteString(fmt.Sprintf("%s\t\tif (%s) {\n", indent, equals))
+	code.WriteString(fmt.Sprintf("%s\t\t\treturn true;\n", indent))
+	code.WriteString(indent + "\t\t}\n")
+	code.WriteString(indent + "\t}\n")
+	code.WriteString(fmt.Sprintf("%s\treturn false;\n", indent))
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// javaStateMethods 生成状态机组的转换表及判断能否转换、查询后继状态和终止状态的静态方法，keyExpr 返回常量作为键的表达式；
+// tablePrefix 非空时表名为 <前缀>Transitions，name 插入方法名中（如 canOrderStatusTransition）
+func javaStateMethods(group *parser.ConstantGroup, indent, tablePrefix, name string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	valueType := parser.GetJavaType(group.Constants[0].Type)
+	boxedType := getBoxedType(valueType)
+	tableName := "transitions"
+	if tablePrefix != "" {
+		tableName = tablePrefix + "Transitions"
+	}
+	code.WriteString(fmt.Sprintf("%s/** %s中各状态允许转换到的目标状态 */\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%sprivate static final Map<%s, List<%s>> %s = new HashMap<>();\n\n", indent, boxedType, boxedType, tableName))
+	code.WriteString(indent + "static {\n")
+	for _, entry := range stateTableEntries(group, keyExpr) {
+		if entry[1] == "" {
+			code.WriteString(fmt.Sprintf("%s\t%s.put(%s, Collections.emptyList());\n", indent, tableName, entry[0]))
+			continue
+		}
+		code.WriteString(fmt.Sprintf("%s\t%s.put(%s, Collections.unmodifiableList(Arrays.asList(%s)));\n", indent, tableName, entry[0], entry[1]))
+	}
+	code.WriteString(indent + "}\n")
+
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 判断能否从状态 from 转换到状态 to\n")
+	code.WriteString(indent + " * @param from 当前状态\n")
+	code.WriteString(indent + " * @param to 目标状态\n")
+	code.WriteString(indent + " * @return 是否允许转换\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%spublic static boolean can%sTransition(%s from, %s to) {\n", indent, name, valueType, valueType))
+	code.WriteString(fmt.Sprintf("%s\treturn %s.getOrDefault(from, Collections.emptyList()).contains(to);\n", indent, tableName))
+	code.WriteString(indent + "}\n")
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 获取状态允许转换到的目标状态\n")
+	code.WriteString(indent + " * @param from 当前状态\n")
+	code.WriteString(indent + " * @return 目标状态，终止状态和未知值返回空列表\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%spublic static List<%s> get%sNextStates(%s from) {\n", indent, boxedType, name, valueType))
+	code.WriteString(fmt.Sprintf("%s\treturn %s.getOrDefault(from, Collections.emptyList());\n", indent, tableName))
+	code.WriteString(indent + "}\n")
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 判断值是否为终止状态（没有可转换到的目标状态）\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @return 是否为终止状态，未知值返回false\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%spublic static boolean is%sTerminal(%s value) {\n", indent, name, valueType))
+	code.WriteString(fmt.Sprintf("%s\tList<%s> next = %s.get(value);\n", indent, boxedType, tableName))
+	code.WriteString(fmt.Sprintf("%s\treturn next != null && next.isEmpty();\n", indent))
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// javaSubsetMembers 生成命名子集的不可变集合及判断是否属于子集的静态方法，集合名为 prefix 加大写的子集名，
+// 方法名为 is + name + 子集名；keyExpr 返回成员的表达式
+func javaSubsetMembers(group *parser.ConstantGroup, indent, prefix, name string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	valueType := parser.GetJavaType(group.Constants[0].Type)
+	boxedType := getBoxedType(valueType)
+	for i, subset := range group.Subsets {
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		setName := prefix + strings.ToUpper(subset.Name)
+		code.WriteString(fmt.Sprintf("%s/** %s的子集：%s */\n", indent, commentText(group.Label), commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("%spublic static final Set<%s> %s = Collections.unmodifiableSet(new LinkedHashSet<>(Arrays.asList(%s)));\n",
+			indent, boxedType, setName, subsetKeys(group, subset, keyExpr)))
+		code.WriteString("\n" + indent + "/**\n")
+		code.WriteString(fmt.Sprintf("%s * 判断值是否属于子集“%s”\n", indent, commentText(subset.Label)))
+		code.WriteString(indent + " * @param value 常量值\n")
+		code.WriteString(indent + " * @return 是否属于该子集\n")
+		code.WriteString(indent + " */\n")
+		code.WriteString(fmt.Sprintf("%spublic static boolean is%s%s(%s value) {\n", indent, name, parser.ToJavaName(subset.Name), valueType))
+		code.WriteString(fmt.Sprintf("%s\treturn %s.contains(value);\n", indent, setName))
+		code.WriteString(indent + "}\n")
+	}
+
+	return code.String()
+}
+
+// generateCollectionClass 生成集合值常量组的工具类：列表使用 List.of，映射使用 Map.of（超过10项时使用 Map.ofEntries），
+// 均为不可修改的集合
+func (g *JavaGenerator) generateCollectionClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	code.WriteString(fmt.Sprintf("\t/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("\tpublic static final class %s {\n", className))
+	code.WriteString(fmt.Sprintf("\t\tprivate %s() {\n", className))
+	code.WriteString("\t\t}\n")
+	for _, constant := range expandAliases(group.Constants) {
+		kind, elementType, _ := parser.CollectionType(constant.Type)
+		elementType = getBoxedType(parser.GetJavaType(elementType))
+		valueType := fmt.Sprintf("List<%s>", elementType)
+		value := fmt.Sprintf("List.of(%s)", strings.Join(collectionItems(constant, "java", ""), ", "))
+		if kind == "map" {
+			valueType = fmt.Sprintf("Map<String, %s>", elementType)
+			items := collectionItems(constant, "java", ", ")
+			value = fmt.Sprintf("Map.of(%s)", strings.Join(items, ", "))
+			if len(items) > 10 {
+				for i, item := range items {
+					items[i] = fmt.Sprintf("Map.entry(%s)", item)
+				}
+				value = fmt.Sprintf("Map.ofEntries(%s)", strings.Join(items, ", "))
+			}
+		}
+
+		code.WriteString("\n")
+		if hasDocDetails(constant) {
+			code.WriteString(javaDoc(constant, "\t\t"))
+		} else {
+			code.WriteString(fmt.Sprintf("\t\t/** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("\t\tpublic static final %s %s = %s;\n", valueType, parser.ToJavaConstantName(constant.Name), value))
+	}
+	code.WriteString("\t}\n")
+
+	return code.String()
+}
+
+// generateRegexClass 生成正则常量组的工具类：每个常量是一个用 Pattern.compile 预编译的静态字段
+func (g *JavaGenerator) generateRegexClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	code.WriteString(fmt.Sprintf("\t/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("\tpublic static final class %s {\n", className))
+	code.WriteString(fmt.Sprintf("\t\tprivate %s() {\n", className))
+	code.WriteString("\t\t}\n")
+	for _, constant := range expandAliases(group.Constants) {
+		code.WriteString("\n")
+		if hasDocDetails(constant) {
+			code.WriteString(javaDoc(constant, "\t\t"))
+		} else {
+			code.WriteString(fmt.Sprintf("\t\t/** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("\t\tpublic static final Pattern %s = Pattern.compile(%s);\n",
+			parser.ToJavaConstantName(constant.Name), regexLiteral(constant.Value.(string), "java")))
+	}
+	code.WriteString("\t}\n")
+
+	return code.String()
+}
+
+// javaDoc 生成常量的Javadoc注释，废弃常量附加 @deprecated 标记和 @Deprecated 注解
+func javaDoc(constant *parser.Constant, indent string) string {
+	lines := docLines(constant)
+	if constant.Deprecated {
+		lines = append(lines, "@deprecated "+commentText(deprecationMessage(constant)))
+	}
+	doc := blockDoc(indent, lines)
+	if constant.Deprecated {
+		doc += indent + "@Deprecated\n"
+	}
+	return doc
+}
 
 // getBoxedType 获取基本类型的装箱类型
 func getBoxedType(primitiveType string) string {
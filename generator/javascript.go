@@ -2,7 +2,6 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -42,8 +41,11 @@ func (g *JavaScriptGenerator) Generate(constants *parser.ConstantsFile) error {
 		code.WriteString("// 导出所有常量\n")
 		code.WriteString("module.exports = {\n")
 		for _, group := range constants.Groups {
-			constants := make([]*parser.Constant, len(group.Constants))
-			copy(constants, group.Constants)
+			if group.Record != nil || parser.IsCollectionGroup(group) || parser.IsRegexGroup(group) {
+				code.WriteString(fmt.Sprintf("  %s,\n", parser.ToJavaName(group.Name)))
+				continue
+			}
+			constants := expandAliases(group.Constants)
 			sort.Slice(constants, func(i, j int) bool {
 				return parser.ToJavaScriptName(constants[i].Name) < parser.ToJavaScriptName(constants[j].Name)
 			})
@@ -51,6 +53,28 @@ func (g *JavaScriptGenerator) Generate(constants *parser.ConstantsFile) error {
 				constName := fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
 				code.WriteString(fmt.Sprintf("  %s,\n", constName))
 			}
+			for _, attribute := range group.Attributes {
+				code.WriteString(fmt.Sprintf("  %s_%s,\n", strings.ToUpper(group.Name), strings.ToUpper(attribute.Name)))
+			}
+			if hasGroupLocales(group) {
+				code.WriteString(fmt.Sprintf("  get%sLocalizedLabel,\n", parser.ToJavaName(group.Name)))
+			}
+			for _, constant := range templateConstants(group) {
+				code.WriteString(fmt.Sprintf("  format%s%s,\n", parser.ToJavaName(group.Name), parser.ToJavaName(constant.Name)))
+			}
+			if parser.IsTreeGroup(group) {
+				className := parser.ToJavaName(group.Name)
+				code.WriteString(fmt.Sprintf("  get%sParent,\n  get%sChildren,\n  get%sAncestors,\n  is%sDescendantOf,\n",
+					className, className, className, className))
+			}
+			if parser.IsStateMachine(group) {
+				className := parser.ToJavaName(group.Name)
+				code.WriteString(fmt.Sprintf("  can%sTransition,\n  get%sNextStates,\n  is%sTerminal,\n", className, className, className))
+			}
+			for _, subset := range group.Subsets {
+				code.WriteString(fmt.Sprintf("  %s_%s,\n  is%s%s,\n", strings.ToUpper(group.Name), strings.ToUpper(subset.Name),
+					parser.ToJavaName(group.Name), parser.ToJavaName(subset.Name)))
+			}
 		}
 		code.WriteString("};\n")
 	} else {
@@ -75,20 +99,29 @@ func (g *JavaScriptGenerator) Generate(constants *parser.ConstantsFile) error {
 	}
 	
 	// 写入文件
-	outputPath := g.GetOutputFilePath(constants.FileName)
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
+	outputPath := g.GetOutputFilePath(constants)
+	return WriteOutputFile(outputPath, code.String())
 }
 
 // generateConstGroup 生成const模式的常量组
 func (g *JavaScriptGenerator) generateConstGroup(group *parser.ConstantGroup, projectLabel string) string {
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionClass(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexClass(group)
+	}
+
 	var code strings.Builder
 	
 	// 生成注释
-	code.WriteString(fmt.Sprintf("// %s %s - %s\n", group.Name, group.Label, projectLabel))
+	code.WriteString(fmt.Sprintf("// %s %s - %s\n", group.Name, commentText(group.Label), commentText(projectLabel)))
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToJavaScriptName(constants[i].Name) < parser.ToJavaScriptName(constants[j].Name)
 	})
@@ -96,16 +129,82 @@ func (g *JavaScriptGenerator) generateConstGroup(group *parser.ConstantGroup, pr
 	// 生成常量定义
 	for _, constant := range constants {
 		constName := fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
-		value := parser.FormatValue(constant.Value, constant.Type, "javascript")
-		comment := constant.Label
+		value := parser.FormatConstantValue(constant, jsDataType(group), "javascript")
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, ""))
+			code.WriteString(fmt.Sprintf("const %s = %s;\n", constName, value))
+			continue
+		}
+		comment := commentText(constant.Label)
 		code.WriteString(fmt.Sprintf("const %s = %s; // %s\n", constName, value, comment))
 	}
+
+	// 附加属性的查询表
+	for _, attribute := range group.Attributes {
+		attrType := jsAttributeType(attribute, group)
+		code.WriteString(fmt.Sprintf("\n/**\n * %s的%s\n", commentText(group.Label), commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf(" * @type {ReadonlyMap<%s, %s>}\n */\n", parser.GetJavaScriptType(jsDataType(group)),
+			parser.GetJavaScriptType(attrType)))
+		code.WriteString(fmt.Sprintf("const %s_%s = new Map([\n", strings.ToUpper(group.Name), strings.ToUpper(attribute.Name)))
+		for _, constant := range canonicalConstants(group) {
+			code.WriteString(fmt.Sprintf("  [%s_%s, %s],\n", strings.ToUpper(group.Name), strings.ToUpper(constant.Name),
+				parser.FormatValue(constant.Attributes[attribute.Name], attrType, "javascript")))
+		}
+		code.WriteString("]);\n")
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		tableName := strings.ToUpper(group.Name) + "_LOCALE_LABELS"
+		code.WriteString(fmt.Sprintf("\n/**\n * %s各语言的标签，缺少翻译的常量使用默认语言的标签\n", commentText(group.Label)))
+		code.WriteString(fmt.Sprintf(" * @type {ReadonlyMap<string, ReadonlyMap<%s, string>>}\n */\n", parser.GetJavaScriptType(jsDataType(group))))
+		code.WriteString(fmt.Sprintf("const %s = %s;\n", tableName, jsLocaleTable(group, "", parser.LabelLocales(group, g.Config.DefaultLocale),
+			canonicalConstants(group), func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}, "javascript", "")))
+		code.WriteString(g.jsLocalizedLabelDoc(group, ""))
+		code.WriteString(fmt.Sprintf("function get%sLocalizedLabel(value, locale) {\n", parser.ToJavaName(group.Name)))
+		code.WriteString(fmt.Sprintf("  const labels = %s;\n", jsLocaleLookup(tableName, g.Config.DefaultLocale, "javascript")))
+		code.WriteString("  return labels.get(value);\n")
+		code.WriteString("}\n")
+	}
+
+	// 标签模板的格式化函数
+	code.WriteString(jsTemplateFuncs(group, "", "function format"+parser.ToJavaName(group.Name)))
+
+	// 树形常量组的父子关系查询函数
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n")
+		code.WriteString(jsTreeFuncs(group, false))
+	}
+
+	// 状态机组的状态转换函数
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n")
+		code.WriteString(jsStateFuncs(group, false))
+	}
+
+	// 命名子集的数组及判断函数
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n")
+		code.WriteString(jsSubsetFuncs(group, false))
+	}
 	
 	return code.String()
 }
 
 // generateGroupClass 生成常量组类
 func (g *JavaScriptGenerator) generateGroupClass(group *parser.ConstantGroup, _ string) string {
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionClass(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexClass(group)
+	}
+
 	var code strings.Builder
 	
 	className := parser.ToJavaName(group.Name)
@@ -117,8 +216,7 @@ func (g *JavaScriptGenerator) generateGroupClass(group *parser.ConstantGroup, _
 	code.WriteString("  static {\n")
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToJavaScriptName(constants[i].Name) < parser.ToJavaScriptName(constants[j].Name)
 	})
@@ -126,13 +224,17 @@ func (g *JavaScriptGenerator) generateGroupClass(group *parser.ConstantGroup, _
 	// 常量定义
 	for _, constant := range constants {
 		constName := parser.ToJavaScriptName(constant.Name)
-		value := parser.FormatValue(constant.Value, constant.Type, "javascript")
-		comment := constant.Label
+		value := parser.FormatConstantValue(constant, jsDataType(group), "javascript")
+		comment := commentText(constant.Label)
 		if comment == "" {
 			comment = constant.Label
 		}
 		
-		code.WriteString(fmt.Sprintf("    /** %s */\n", comment))
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /** %s */\n", comment))
+		}
 		code.WriteString(fmt.Sprintf("    this.%s = %s;\n", constName, value))
 	}
 	
@@ -152,11 +254,36 @@ func (g *JavaScriptGenerator) generateGroupClass(group *parser.ConstantGroup, _
 	code.WriteString("\n")
 	code.WriteString(g.generateGetKeyValuePairs(group))
 	code.WriteString("\n")
-	code.WriteString(g.generateFormatValue(group))
-	code.WriteString("\n")
-	code.WriteString(g.generateIsValid(group))
+	if group.Flags {
+		code.WriteString(g.generateFlagsMethods(group))
+	} else {
+		code.WriteString(g.generateFormatValue(group))
+		code.WriteString("\n")
+		code.WriteString(g.generateIsValid(group))
+	}
 	code.WriteString("\n")
 	code.WriteString(g.generateFromString(group))
+	for _, attribute := range group.Attributes {
+		code.WriteString("\n")
+		code.WriteString(g.generateAttributeGetter(group, attribute))
+	}
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.generateLocalizedLabel(group))
+	}
+	code.WriteString(jsTemplateFuncs(group, "  ", "static format"))
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n")
+		code.WriteString(jsTreeFuncs(group, true))
+	}
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n")
+		code.WriteString(jsStateFuncs(group, true))
+	}
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n")
+		code.WriteString(jsSubsetFuncs(group, true))
+	}
 	
 	
 	code.WriteString("}\n")
@@ -164,12 +291,207 @@ func (g *JavaScriptGenerator) generateGroupClass(group *parser.ConstantGroup, _
 	return code.String()
 }
 
+// generateCollectionClass 生成集合值常量组的类：每个常量是一个静态字段，列表和映射都用 Object.freeze 冻结
+func (g *JavaScriptGenerator) generateCollectionClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("class %s {\n", parser.ToJavaName(group.Name)))
+	for i, constant := range expandAliases(group.Constants) {
+		kind, _, _ := parser.CollectionType(constant.Type)
+		value := "[" + strings.Join(collectionItems(constant, "javascript", ""), ", ") + "]"
+		if kind == "map" {
+			value = "{}"
+			if items := collectionItems(constant, "javascript", ": "); len(items) > 0 {
+				value = "{ " + strings.Join(items, ", ") + " }"
+			}
+		}
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, "  "))
+		} else {
+			code.WriteString(fmt.Sprintf("  /** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("  static %s = Object.freeze(%s);\n", strings.ToUpper(constant.Name), value))
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateRegexClass 生成正则常量组的类：每个常量是一个预编译的 RegExp 静态字段
+func (g *JavaScriptGenerator) generateRegexClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("class %s {\n", parser.ToJavaName(group.Name)))
+	for i, constant := range expandAliases(group.Constants) {
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, "  "))
+		} else {
+			code.WriteString(fmt.Sprintf("  /** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("  static %s = new RegExp(%s);\n", strings.ToUpper(constant.Name),
+			regexLiteral(constant.Value.(string), "javascript")))
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateRecordClass 生成记录组的类：每个常量是一个冻结的实例，别名指向同一个实例，
+// 并提供按键名和按主值查找的静态方法
+func (g *JavaScriptGenerator) generateRecordClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	fields := recordFields(group)
+	primary := fields[0]
+	byPrimaryName := "#by" + parser.ToJavaName(primary.Name)
+
+	code.WriteString(fmt.Sprintf("/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("class %s {\n", className))
+	code.WriteString("  /** @type {ReadonlyMap<string, " + className + ">} */\n")
+	code.WriteString("  static #byKey;\n\n")
+	code.WriteString(fmt.Sprintf("  /** @type {ReadonlyMap<%s, %s>} */\n", parser.GetJavaScriptType(jsRecordFieldType(group, primary)), className))
+	code.WriteString(fmt.Sprintf("  static %s;\n\n", byPrimaryName))
+
+	code.WriteString("  static {\n")
+	for _, constant := range expandAliases(group.Constants) {
+		value := "this." + strings.ToUpper(constant.AliasOf)
+		if constant.AliasOf == "" {
+			args := []string{
+				parser.FormatValue(constant.Name, "string", "javascript"),
+				parser.FormatValue(constant.Label, "string", "javascript"),
+			}
+			for _, field := range fields {
+				args = append(args, recordFieldValue(group, constant, field, jsRecordFieldType(group, field), "javascript"))
+			}
+			value = fmt.Sprintf("new %s(%s)", className, strings.Join(args, ", "))
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("    this.%s = %s;\n", strings.ToUpper(constant.Name), value))
+	}
+
+	var records []string
+	for _, constant := range group.Constants {
+		records = append(records, "this."+strings.ToUpper(constant.Name))
+	}
+	code.WriteString(fmt.Sprintf("\n    /** 所有%s，按源文件顺序排列 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("    this.allRecords = Object.freeze([%s]);\n\n", strings.Join(records, ", ")))
+	code.WriteString("    this.#byKey = new Map([\n")
+	for _, constant := range expandAliases(group.Constants) {
+		code.WriteString(fmt.Sprintf("      [%s, this.%s],\n", parser.FormatValue(constant.Name, "string", "javascript"),
+			strings.ToUpper(constant.Name)))
+	}
+	code.WriteString("    ]);\n")
+	code.WriteString(fmt.Sprintf("    this.%s = new Map(this.allRecords.map((record) => [record.%s, record]));\n", byPrimaryName,
+		toCamelCase(primary.Name)))
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  /**\n")
+	code.WriteString("   * @param {string} key 键名\n")
+	code.WriteString("   * @param {string} label 标签\n")
+	params := []string{"key", "label"}
+	for _, field := range fields {
+		code.WriteString(fmt.Sprintf("   * @param {%s} %s %s\n", parser.GetJavaScriptType(jsRecordFieldType(group, field)),
+			toCamelCase(field.Name), attributeLabel(field)))
+		params = append(params, toCamelCase(field.Name))
+	}
+	code.WriteString("   */\n")
+	code.WriteString(fmt.Sprintf("  constructor(%s) {\n", strings.Join(params, ", ")))
+	for _, param := range params {
+		code.WriteString(fmt.Sprintf("    this.%s = %s;\n", param, param))
+	}
+	code.WriteString("    Object.freeze(this);\n")
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  /**\n")
+	code.WriteString(fmt.Sprintf("   * 按键名（含别名）查找%s\n", commentText(group.Label)))
+	code.WriteString("   * @param {string} key 键名\n")
+	code.WriteString(fmt.Sprintf("   * @returns {%s | undefined} 不存在时返回undefined\n", className))
+	code.WriteString("   */\n")
+	code.WriteString("  static fromKey(key) {\n")
+	code.WriteString(fmt.Sprintf("    return %s.#byKey.get(key);\n", className))
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  /**\n")
+	code.WriteString(fmt.Sprintf("   * 按%s查找%s\n", commentText(attributeLabel(primary)), commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("   * @param {%s} value %s\n", parser.GetJavaScriptType(jsRecordFieldType(group, primary)), commentText(attributeLabel(primary))))
+	code.WriteString(fmt.Sprintf("   * @returns {%s | undefined} 不存在时返回undefined\n", className))
+	code.WriteString("   */\n")
+	code.WriteString(fmt.Sprintf("  static from%s(value) {\n", parser.ToJavaName(primary.Name)))
+	code.WriteString(fmt.Sprintf("    return %s.%s.get(value);\n", className, byPrimaryName))
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  toString() {\n")
+	code.WriteString("    return this.key;\n")
+	code.WriteString("  }\n")
+
+	// 按语言查询标签，标签表按主值索引
+	if hasGroupLocales(group) {
+		code.WriteString(fmt.Sprintf("\n  /**\n   * %s各语言的标签，缺少翻译的常量使用默认语言的标签\n", commentText(group.Label)))
+		code.WriteString(fmt.Sprintf("   * @type {ReadonlyMap<string, ReadonlyMap<%s, string>>}\n   */\n",
+			parser.GetJavaScriptType(jsRecordFieldType(group, primary))))
+		code.WriteString(fmt.Sprintf("  static #localeLabels = %s;\n\n", jsLocaleTable(group, "  ", parser.LabelLocales(group, g.Config.DefaultLocale),
+			group.Constants, func(constant *parser.Constant) string {
+				return recordFieldValue(group, constant, primary, jsRecordFieldType(group, primary), "javascript")
+			}, "javascript", "")))
+		code.WriteString("  /**\n")
+		code.WriteString(fmt.Sprintf("   * 获取%s在指定语言下的标签，语言未知时使用默认语言\n", commentText(group.Label)))
+		code.WriteString("   * @param {string} locale 语言代码，如 en、en-US\n")
+		code.WriteString("   * @returns {string}\n")
+		code.WriteString("   */\n")
+		code.WriteString("  localizedLabel(locale) {\n")
+		code.WriteString(fmt.Sprintf("    const labels = %s;\n", jsLocaleLookup(className+".#localeLabels", g.Config.DefaultLocale, "javascript")))
+		code.WriteString(fmt.Sprintf("    return labels.get(this.%s);\n", toCamelCase(primary.Name)))
+		code.WriteString("  }\n")
+	}
+	code.WriteString(jsTemplateFuncs(group, "  ", "static format"))
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// jsTemplateFuncs 为标签含占位符的常量生成格式化函数，declaration 为函数声明中常量名之前的部分，
+// 参数类型写在JSDoc中
+func jsTemplateFuncs(group *parser.ConstantGroup, indent, declaration string) string {
+	var code strings.Builder
+
+	for _, constant := range templateConstants(group) {
+		code.WriteString(fmt.Sprintf("\n%s/**\n", indent))
+		code.WriteString(fmt.Sprintf("%s * %s\n", indent, templateDoc(constant)))
+		for _, param := range constant.Template.Params {
+			code.WriteString(fmt.Sprintf("%s * @param {%s} %s\n", indent, parser.GetJavaScriptType(param.Type), toCamelCase(param.Name)))
+		}
+		code.WriteString(fmt.Sprintf("%s * @returns {string}\n", indent))
+		code.WriteString(indent + " */\n")
+		code.WriteString(fmt.Sprintf("%s%s%s(%s) {\n", indent, declaration, parser.ToJavaName(constant.Name),
+			templateParams(constant.Template, func(param parser.TemplateParam) string {
+				return toCamelCase(param.Name)
+			})))
+		code.WriteString(fmt.Sprintf("%s  return %s;\n", indent, jsTemplateLiteral(constant.Template)))
+		code.WriteString(indent + "}\n")
+	}
+
+	return code.String()
+}
+
 // generateGetAllValues 生成获取所有值的方法
 func (g *JavaScriptGenerator) generateGetAllValues(group *parser.ConstantGroup) string {
 	var code strings.Builder
 	
 	code.WriteString("  /**\n")
-	code.WriteString(fmt.Sprintf("   * 获取所有%s常量值\n", group.Label))
+	code.WriteString(fmt.Sprintf("   * 获取所有%s常量值\n", commentText(group.Label)))
 	code.WriteString("   * @returns {Array} 所有常量值的数组\n")
 	code.WriteString("   */\n")
 	code.WriteString("  static getAllValues() {\n")
@@ -198,7 +520,7 @@ func (g *JavaScriptGenerator) generateGetAllKeys(group *parser.ConstantGroup) st
 	var code strings.Builder
 	
 	code.WriteString("  /**\n")
-	code.WriteString(fmt.Sprintf("   * 获取所有%s常量键名\n", group.Label))
+	code.WriteString(fmt.Sprintf("   * 获取所有%s常量键名\n", commentText(group.Label)))
 	code.WriteString("   * @returns {Array<string>} 所有常量键名的数组\n")
 	code.WriteString("   */\n")
 	code.WriteString("  static getAllKeys() {\n")
@@ -234,8 +556,7 @@ func (g *JavaScriptGenerator) generateGetKeyValuePairs(group *parser.ConstantGro
 	code.WriteString("    return {\n")
 	
 	// 按字母顺序排序
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToJavaScriptName(constants[i].Name) < parser.ToJavaScriptName(constants[j].Name)
 	})
@@ -255,22 +576,22 @@ func (g *JavaScriptGenerator) generateGetKeyValuePairs(group *parser.ConstantGro
 func (g *JavaScriptGenerator) generateFormatValue(group *parser.ConstantGroup) string {
 	var code strings.Builder
 	
-	jsType := parser.GetJavaScriptType(group.Constants[0].Type)
+	jsType := parser.GetJavaScriptType(jsDataType(group))
 	
 	code.WriteString("  /**\n")
-	code.WriteString(fmt.Sprintf("   * 根据值格式化%s的标签\n", group.Label))
+	code.WriteString(fmt.Sprintf("   * 根据值格式化%s的标签\n", commentText(group.Label)))
 	code.WriteString(fmt.Sprintf("   * @param {%s} value - 常量值\n", jsType))
 	code.WriteString("   * @returns {string} 格式化后的标签，找不到时返回 'Unknown(value)'\n")
 	code.WriteString("   */\n")
 	code.WriteString("  static formatValue(value) {\n")
 	code.WriteString("    const labels = {\n")
-	for _, constant := range group.Constants {
+	for _, constant := range canonicalConstants(group) {
 		constName := parser.ToJavaScriptName(constant.Name)
 		label := constant.Label
 		if label == "" {
 			label = constant.Name
 		}
-		code.WriteString(fmt.Sprintf("      [this.%s]: '%s',\n", constName, label))
+		code.WriteString(fmt.Sprintf("      [this.%s]: %s,\n", constName, parser.FormatValue(label, "string", "javascript")))
 	}
 	code.WriteString("    };\n\n")
 	code.WriteString("    if (labels[value] !== undefined) {\n")
@@ -286,10 +607,10 @@ func (g *JavaScriptGenerator) generateFormatValue(group *parser.ConstantGroup) s
 func (g *JavaScriptGenerator) generateIsValid(group *parser.ConstantGroup) string {
 	var code strings.Builder
 	
-	jsType := parser.GetJavaScriptType(group.Constants[0].Type)
+	jsType := parser.GetJavaScriptType(jsDataType(group))
 	
 	code.WriteString("  /**\n")
-	code.WriteString(fmt.Sprintf("   * 验证值是否为有效的%s常量\n", group.Label))
+	code.WriteString(fmt.Sprintf("   * 验证值是否为有效的%s常量\n", commentText(group.Label)))
 	code.WriteString(fmt.Sprintf("   * @param {%s} value - 要验证的值\n", jsType))
 	code.WriteString("   * @returns {boolean} 是否为有效常量\n")
 	code.WriteString("   */\n")
@@ -300,14 +621,353 @@ func (g *JavaScriptGenerator) generateIsValid(group *parser.ConstantGroup) strin
 	return code.String()
 }
 
+// generateFlagsMethods 生成位标志组的组合操作方法，formatValue以|连接各标志的标签，isValid接受已知标志的任意组合
+func (g *JavaScriptGenerator) generateFlagsMethods(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	dataType := jsDataType(group)
+	jsType := parser.GetJavaScriptType(dataType)
+	zero := parser.FormatValue(int64(0), dataType, "javascript")
+	constants := flagConstants(group)
+
+	var flags []string
+	for _, constant := range constants {
+		flags = append(flags, "this."+parser.ToJavaScriptName(constant.Name))
+	}
+
+	code.WriteString("  /**\n")
+	code.WriteString("   * 判断组合值是否包含指定的全部标志\n")
+	code.WriteString(fmt.Sprintf("   * @param {%s} value - 组合值\n", jsType))
+	code.WriteString(fmt.Sprintf("   * @param {%s} flag - 标志\n", jsType))
+	code.WriteString("   * @returns {boolean} 是否包含\n")
+	code.WriteString("   */\n")
+	code.WriteString("  static has(value, flag) {\n")
+	code.WriteString("    return (value & flag) === flag;\n")
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  /**\n")
+	code.WriteString("   * 返回添加指定标志后的组合值\n")
+	code.WriteString(fmt.Sprintf("   * @param {%s} value - 组合值\n", jsType))
+	code.WriteString(fmt.Sprintf("   * @param {%s} flag - 标志\n", jsType))
+	code.WriteString(fmt.Sprintf("   * @returns {%s} 新的组合值\n", jsType))
+	code.WriteString("   */\n")
+	code.WriteString("  static add(value, flag) {\n")
+	code.WriteString("    return value | flag;\n")
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  /**\n")
+	code.WriteString("   * 返回移除指定标志后的组合值\n")
+	code.WriteString(fmt.Sprintf("   * @param {%s} value - 组合值\n", jsType))
+	code.WriteString(fmt.Sprintf("   * @param {%s} flag - 标志\n", jsType))
+	code.WriteString(fmt.Sprintf("   * @returns {%s} 新的组合值\n", jsType))
+	code.WriteString("   */\n")
+	code.WriteString("  static remove(value, flag) {\n")
+	code.WriteString("    return value & ~flag;\n")
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  /**\n")
+	code.WriteString("   * 将组合值拆分为各个标志，按位从低到高排列\n")
+	code.WriteString(fmt.Sprintf("   * @param {%s} value - 组合值\n", jsType))
+	code.WriteString(fmt.Sprintf("   * @returns {%s[]} 包含的标志\n", jsType))
+	code.WriteString("   */\n")
+	code.WriteString("  static toList(value) {\n")
+	code.WriteString(fmt.Sprintf("    return [%s].filter((flag) => this.has(value, flag));\n", strings.Join(flags, ", ")))
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  /**\n")
+	code.WriteString(fmt.Sprintf("   * 格式化%s的组合值，标签以|分隔，如 读|写\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("   * @param {%s} value - 组合值\n", jsType))
+	code.WriteString("   * @returns {string} 格式化后的标签，包含未知位时返回 'Unknown(value)'\n")
+	code.WriteString("   */\n")
+	code.WriteString("  static formatValue(value) {\n")
+	code.WriteString("    if (!this.isValid(value)) {\n")
+	code.WriteString("      return `Unknown(${value})`;\n")
+	code.WriteString("    }\n\n")
+	code.WriteString("    const labels = [\n")
+	for i, constant := range constants {
+		code.WriteString(fmt.Sprintf("      [%s, %s],\n", flags[i], parser.FormatValue(constantLabel(constant), "string", "javascript")))
+	}
+	code.WriteString("    ];\n")
+	code.WriteString("    return labels.filter(([flag]) => this.has(value, flag)).map(([, label]) => label).join('|');\n")
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  /**\n")
+	code.WriteString(fmt.Sprintf("   * 验证值是否为%s已知标志的任意组合\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("   * @param {%s} value - 要验证的值\n", jsType))
+	code.WriteString("   * @returns {boolean} 是否有效\n")
+	code.WriteString("   */\n")
+	code.WriteString("  static isValid(value) {\n")
+	code.WriteString(fmt.Sprintf("    return typeof value === '%s' && (value & ~%s) === %s;\n",
+		jsType, parser.FormatValue(flagMask(group), dataType, "javascript"), zero))
+	code.WriteString("  }\n")
+
+	return code.String()
+}
+
+// generateLocalizedLabel 生成各语言的标签表和按语言查询标签的静态方法，位标志组的方法按语言格式化组合值
+func (g *JavaScriptGenerator) generateLocalizedLabel(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	tableName := className + ".#localeLabels"
+	constants := canonicalConstants(group)
+	if group.Flags {
+		constants = flagConstants(group)
+	}
+
+	code.WriteString(fmt.Sprintf("  /**\n   * %s各语言的标签，缺少翻译的常量使用默认语言的标签\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("   * @type {ReadonlyMap<string, ReadonlyMap<%s, string>>}\n   */\n", parser.GetJavaScriptType(jsDataType(group))))
+	code.WriteString(fmt.Sprintf("  static #localeLabels = %s;\n\n", jsLocaleTable(group, "  ", parser.LabelLocales(group, g.Config.DefaultLocale),
+		constants, func(constant *parser.Constant) string {
+			return "this." + parser.ToJavaScriptName(constant.Name)
+		}, "javascript", "")))
+
+	if group.Flags {
+		code.WriteString("  /**\n")
+		code.WriteString(fmt.Sprintf("   * 按指定语言格式化%s的组合值，标签以|分隔，语言未知时使用默认语言\n", commentText(group.Label)))
+		code.WriteString(fmt.Sprintf("   * @param {%s} value - 组合值\n", parser.GetJavaScriptType(jsDataType(group))))
+		code.WriteString("   * @param {string} locale - 语言代码，如 en、en-US\n")
+		code.WriteString("   * @returns {string} 格式化后的标签，包含未知位时返回 'Unknown(value)'\n")
+		code.WriteString("   */\n")
+		code.WriteString("  static getLocalizedLabel(value, locale) {\n")
+		code.WriteString("    if (!this.isValid(value)) {\n")
+		code.WriteString("      return `Unknown(${value})`;\n")
+		code.WriteString("    }\n")
+		code.WriteString(fmt.Sprintf("    const labels = %s;\n", jsLocaleLookup(tableName, g.Config.DefaultLocale, "javascript")))
+		code.WriteString("    return this.toList(value).map((flag) => labels.get(flag)).join('|');\n")
+		code.WriteString("  }\n")
+		return code.String()
+	}
+	code.WriteString(g.jsLocalizedLabelDoc(group, "  "))
+	code.WriteString("  static getLocalizedLabel(value, locale) {\n")
+	code.WriteString(fmt.Sprintf("    const labels = %s;\n", jsLocaleLookup(tableName, g.Config.DefaultLocale, "javascript")))
+	code.WriteString("    return labels.get(value);\n")
+	code.WriteString("  }\n")
+
+	return code.String()
+}
+
+// jsTreeFuncs 生成树形常量组的父子关系表及查询父节点、子节点、祖先的函数：method 为 true 时生成为类的私有静态表和
+// 静态方法 getParent、getChildren 等，否则生成模块级的表（如 REGION_PARENTS）和函数 get<组名>Parent 等
+func jsTreeFuncs(group *parser.ConstantGroup, method bool) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	jsType := parser.GetJavaScriptType(jsDataType(group))
+	indent, name := "", className
+	parentsName, childrenName := strings.ToUpper(group.Name)+"_PARENTS", strings.ToUpper(group.Name)+"_CHILDREN"
+	parentsDecl, childrenDecl := "const "+parentsName, "const "+childrenName
+	keyExpr := func(constant *parser.Constant) string {
+		return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+	}
+	declaration, receiver := "function ", ""
+	if method {
+		indent, name = "  ", ""
+		parentsName, childrenName = className+".#parents", className+".#children"
+		parentsDecl, childrenDecl = "static #parents", "static #children"
+		keyExpr = func(constant *parser.Constant) string {
+			return "this." + parser.ToJavaScriptName(constant.Name)
+		}
+		declaration, receiver = "static ", "this."
+	}
+
+	code.WriteString(fmt.Sprintf("%s/**\n%s * %s中各常量的父常量\n", indent, indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s * @type {ReadonlyMap<%s, %s>}\n%s */\n", indent, jsType, jsType, indent))
+	code.WriteString(fmt.Sprintf("%s%s = new Map([\n", indent, parentsDecl))
+	for _, constant := range group.Constants {
+		if parent := parentConstant(group, constant); parent != nil {
+			code.WriteString(fmt.Sprintf("%s  [%s, %s],\n", indent, keyExpr(constant), keyExpr(parent)))
+		}
+	}
+	code.WriteString(indent + "]);\n\n")
+	code.WriteString(fmt.Sprintf("%s/**\n%s * %s中各常量的直接子常量\n", indent, indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s * @type {ReadonlyMap<%s, ReadonlyArray<%s>>}\n%s */\n", indent, jsType, jsType, indent))
+	code.WriteString(fmt.Sprintf("%s%s = new Map([\n", indent, childrenDecl))
+	for _, constant := range group.Constants {
+		var children []string
+		for _, child := range childConstants(group, constant) {
+			children = append(children, keyExpr(child))
+		}
+		if len(children) > 0 {
+			code.WriteString(fmt.Sprintf("%s  [%s, [%s]],\n", indent, keyExpr(constant), strings.Join(children, ", ")))
+		}
+	}
+	code.WriteString(indent + "]);\n\n")
+
+	code.WriteString(fmt.Sprintf("%s/**\n%s * 获取值的父节点\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} value - 常量值\n", indent, jsType))
+	code.WriteString(fmt.Sprintf("%s * @returns {%s|undefined} 父节点的值，根节点和未知值返回 undefined\n%s */\n", indent, jsType, indent))
+	code.WriteString(fmt.Sprintf("%s%sget%sParent(value) {\n", indent, declaration, name))
+	code.WriteString(fmt.Sprintf("%s  return %s.get(value);\n", indent, parentsName))
+	code.WriteString(indent + "}\n\n")
+	code.WriteString(fmt.Sprintf("%s/**\n%s * 获取值的直接子节点，按定义顺序排列\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} value - 常量值\n", indent, jsType))
+	code.WriteString(fmt.Sprintf("%s * @returns {%s[]} 子节点的值，没有子节点时返回空数组\n%s */\n", indent, jsType, indent))
+	code.WriteString(fmt.Sprintf("%s%sget%sChildren(value) {\n", indent, declaration, name))
+	code.WriteString(fmt.Sprintf("%s  return [...(%s.get(value) || [])];\n", indent, childrenName))
+	code.WriteString(indent + "}\n\n")
+	code.WriteString(fmt.Sprintf("%s/**\n%s * 获取值的全部祖先节点，从父节点到根节点排列\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} value - 常量值\n", indent, jsType))
+	code.WriteString(fmt.Sprintf("%s * @returns {%s[]} 祖先节点的值，根节点和未知值返回空数组\n%s */\n", indent, jsType, indent))
+	code.WriteString(fmt.Sprintf("%s%sget%sAncestors(value) {\n", indent, declaration, name))
+	code.WriteString(fmt.Sprintf("%s  const ancestors = [];\n", indent))
+	code.WriteString(fmt.Sprintf("%s  for (let parent = %s.get(value); parent !== undefined; parent = %s.get(parent)) {\n", indent, parentsName, parentsName))
+	code.WriteString(fmt.Sprintf("%s    ancestors.push(parent);\n", indent))
+	code.WriteString(indent + "  }\n")
+	code.WriteString(fmt.Sprintf("%s  return ancestors;\n", indent))
+	code.WriteString(indent + "}\n\n")
+	code.WriteString(fmt.Sprintf("%s/**\n%s * 判断值是否为指定节点的后代节点（不含自身）\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} value - 常量值\n", indent, jsType))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} ancestor - 祖先节点的值\n", indent, jsType))
+	code.WriteString(fmt.Sprintf("%s * @returns {boolean} 是否为后代节点\n%s */\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s%sis%sDescendantOf(value, ancestor) {\n", indent, declaration, name))
+	code.WriteString(fmt.Sprintf("%s  return %sget%sAncestors(value).includes(ancestor);\n", indent, receiver, name))
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// jsStateFuncs 生成状态机组的转换表及判断能否转换、查询后继状态和终止状态的函数：method 为 true 时生成为类的私有静态表和
+// 静态方法 canTransition、getNextStates、isTerminal，否则生成模块级的表（如 ORDER_STATUS_TRANSITIONS）和函数 can<组名>Transition 等
+func jsStateFuncs(group *parser.ConstantGroup, method bool) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	jsType := parser.GetJavaScriptType(jsDataType(group))
+	indent, name := "", className
+	tableName := strings.ToUpper(group.Name) + "_TRANSITIONS"
+	tableDecl := "const " + tableName
+	keyExpr := func(constant *parser.Constant) string {
+		return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+	}
+	declaration := "function "
+	if method {
+		indent, name = "  ", ""
+		tableName, tableDecl = className+".#transitions", "static #transitions"
+		keyExpr = func(constant *parser.Constant) string {
+			return "this." + parser.ToJavaScriptName(constant.Name)
+		}
+		declaration = "static "
+	}
+
+	code.WriteString(fmt.Sprintf("%s/**\n%s * %s中各状态允许转换到的目标状态\n", indent, indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s * @type {ReadonlyMap<%s, ReadonlyArray<%s>>}\n%s */\n", indent, jsType, jsType, indent))
+	code.WriteString(fmt.Sprintf("%s%s = new Map([\n", indent, tableDecl))
+	for _, entry := range stateTableEntries(group, keyExpr) {
+		code.WriteString(fmt.Sprintf("%s  [%s, [%s]],\n", indent, entry[0], entry[1]))
+	}
+	code.WriteString(indent + "]);\n\n")
+
+	code.WriteString(fmt.Sprintf("%s/**\n%s * 判断能否从状态 from 转换到状态 to\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} from - 当前状态\n", indent, jsType))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} to - 目标状态\n", indent, jsType))
+	code.WriteString(fmt.Sprintf("%s * @returns {boolean} 是否允许转换\n%s */\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s%scan%sTransition(from, to) {\n", indent, declaration, name))
+	code.WriteString(fmt.Sprintf("%s  return (%s.get(from) || []).includes(to);\n", indent, tableName))
+	code.WriteString(indent + "}\n\n")
+	code.WriteString(fmt.Sprintf("%s/**\n%s * 获取状态允许转换到的目标状态\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} from - 当前状态\n", indent, jsType))
+	code.WriteString(fmt.Sprintf("%s * @returns {%s[]} 目标状态，终止状态和未知值返回空数组\n%s */\n", indent, jsType, indent))
+	code.WriteString(fmt.Sprintf("%s%sget%sNextStates(from) {\n", indent, declaration, name))
+	code.WriteString(fmt.Sprintf("%s  return [...(%s.get(from) || [])];\n", indent, tableName))
+	code.WriteString(indent + "}\n\n")
+	code.WriteString(fmt.Sprintf("%s/**\n%s * 判断值是否为终止状态（没有可转换到的目标状态）\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} value - 常量值\n", indent, jsType))
+	code.WriteString(fmt.Sprintf("%s * @returns {boolean} 是否为终止状态，未知值返回 false\n%s */\n", indent, indent))
+	code.WriteString(fmt.Sprintf("%s%sis%sTerminal(value) {\n", indent, declaration, name))
+	code.WriteString(fmt.Sprintf("%s  const next = %s.get(value);\n", indent, tableName))
+	code.WriteString(fmt.Sprintf("%s  return next !== undefined && next.length === 0;\n", indent))
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// jsSubsetFuncs 生成命名子集的冻结数组及判断是否属于子集的函数：method 为 true 时生成为类的静态字段（如 ACTIVE）和
+// 静态方法 is<子集名>，否则生成以组名为前缀的常量（如 ORDER_STATUS_ACTIVE）和函数 is<组名><子集名>
+func jsSubsetFuncs(group *parser.ConstantGroup, method bool) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	jsType := parser.GetJavaScriptType(jsDataType(group))
+	for i, subset := range group.Subsets {
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		indent, name := "", className+parser.ToJavaName(subset.Name)
+		arrayName := strings.ToUpper(group.Name) + "_" + strings.ToUpper(subset.Name)
+		arrayDecl, declaration := "const "+arrayName, "function "
+		keyExpr := func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}
+		if method {
+			indent, name = "  ", parser.ToJavaName(subset.Name)
+			arrayName = className + "." + strings.ToUpper(subset.Name)
+			arrayDecl, declaration = "static "+strings.ToUpper(subset.Name), "static "
+			keyExpr = func(constant *parser.Constant) string {
+				return "this." + parser.ToJavaScriptName(constant.Name)
+			}
+		}
+
+		code.WriteString(fmt.Sprintf("%s/**\n%s * %s的子集：%s\n", indent, indent, commentText(group.Label), commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("%s * @type {ReadonlyArray<%s>}\n%s */\n", indent, jsType, indent))
+		code.WriteString(fmt.Sprintf("%s%s = Object.freeze([%s]);\n\n", indent, arrayDecl, subsetKeys(group, subset, keyExpr)))
+		code.WriteString(fmt.Sprintf("%s/**\n%s * 判断值是否属于子集“%s”\n", indent, indent, commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("%s * @param {%s} value - 常量值\n", indent, jsType))
+		code.WriteString(fmt.Sprintf("%s * @returns {boolean} 是否属于该子集\n%s */\n", indent, indent))
+		code.WriteString(fmt.Sprintf("%s%sis%s(value) {\n", indent, declaration, name))
+		code.WriteString(fmt.Sprintf("%s  return %s.includes(value);\n", indent, arrayName))
+		code.WriteString(indent + "}\n")
+	}
+
+	return code.String()
+}
+
+// jsLocalizedLabelDoc 生成按值和语言查询标签的函数的JSDoc注释
+func (g *JavaScriptGenerator) jsLocalizedLabelDoc(group *parser.ConstantGroup, indent string) string {
+	var code strings.Builder
+
+	code.WriteString(indent + "/**\n")
+	code.WriteString(fmt.Sprintf("%s * 获取值在指定语言下的%s标签，语言未知时使用默认语言\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s * @param {%s} value - 常量值\n", indent, parser.GetJavaScriptType(jsDataType(group))))
+	code.WriteString(indent + " * @param {string} locale - 语言代码，如 en、en-US\n")
+	code.WriteString(indent + " * @returns {string|undefined} 标签，未知值返回 undefined\n")
+	code.WriteString(indent + " */\n")
+
+	return code.String()
+}
+
+// generateAttributeGetter 生成按值查询附加属性的静态方法，未知值返回属性的默认值
+func (g *JavaScriptGenerator) generateAttributeGetter(group *parser.ConstantGroup, attribute *parser.Attribute) string {
+	var code strings.Builder
+
+	attrType := jsAttributeType(attribute, group)
+	code.WriteString("  /**\n")
+	code.WriteString(fmt.Sprintf("   * 获取值对应的%s\n", commentText(attributeLabel(attribute))))
+	code.WriteString(fmt.Sprintf("   * @param {%s} value - 常量值\n", parser.GetJavaScriptType(jsDataType(group))))
+	code.WriteString(fmt.Sprintf("   * @returns {%s} %s，未知值返回默认值\n", parser.GetJavaScriptType(attrType), commentText(attributeLabel(attribute))))
+	code.WriteString("   */\n")
+	code.WriteString(fmt.Sprintf("  static get%s(value) {\n", parser.ToJavaName(attribute.Name)))
+	code.WriteString("    switch (value) {\n")
+	for _, constant := range canonicalConstants(group) {
+		code.WriteString(fmt.Sprintf("      case this.%s:\n", parser.ToJavaScriptName(constant.Name)))
+		code.WriteString(fmt.Sprintf("        return %s;\n", parser.FormatValue(constant.Attributes[attribute.Name], attrType, "javascript")))
+	}
+	code.WriteString("      default:\n")
+	code.WriteString(fmt.Sprintf("        return %s;\n", parser.FormatValue(attribute.Default, attrType, "javascript")))
+	code.WriteString("    }\n")
+	code.WriteString("  }\n")
+
+	return code.String()
+}
+
 // generateFromString 生成从字符串获取值的方法
 func (g *JavaScriptGenerator) generateFromString(group *parser.ConstantGroup) string {
 	var code strings.Builder
 	
-	jsType := parser.GetJavaScriptType(group.Constants[0].Type)
+	jsType := parser.GetJavaScriptType(jsDataType(group))
 	
 	code.WriteString("  /**\n")
-	code.WriteString(fmt.Sprintf("   * 从字符串键名获取%s常量值\n", group.Label))
+	code.WriteString(fmt.Sprintf("   * 从字符串键名获取%s常量值\n", commentText(group.Label)))
 	code.WriteString("   * @param {string} key - 常量键名\n")
 	code.WriteString(fmt.Sprintf("   * @returns {%s|undefined} 常量值，找不到时返回 undefined\n", jsType))
 	code.WriteString("   */\n")
@@ -320,8 +980,18 @@ func (g *JavaScriptGenerator) generateFromString(group *parser.ConstantGroup) st
 }
 
 
-// GenerateIndex 生成JavaScript的index.js文件
+// GenerateIndex 生成JavaScript的index.js文件（每个子目录各生成一个）
 func (g *JavaScriptGenerator) GenerateIndex(allConstants []*parser.ConstantsFile) error {
+	for _, dir := range buildIndexDirs(allConstants) {
+		if err := g.generateIndexFile(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateIndexFile 生成单个目录的index.js文件
+func (g *JavaScriptGenerator) generateIndexFile(dir *indexDir) error {
 	var code strings.Builder
 	
 	// 文件头注释
@@ -333,16 +1003,21 @@ func (g *JavaScriptGenerator) GenerateIndex(allConstants []*parser.ConstantsFile
 	code.WriteString(" */\n\n")
 	
 	// 导入所有文件
-	for _, constants := range allConstants {
+	for _, constants := range dir.Files {
 		code.WriteString(fmt.Sprintf("const %s = require('./%s');\n", 
 			constants.FileName, constants.FileName))
 	}
 	
+	// 导入子目录
+	for _, subDir := range dir.SubDirs {
+		code.WriteString(fmt.Sprintf("const %s = require('./%s');\n", subDir, subDir))
+	}
+	
 	code.WriteString("\n// 导出所有常量\n")
 	code.WriteString("module.exports = {\n")
 	
 	// 导出所有常量类
-	for _, constants := range allConstants {
+	for _, constants := range dir.Files {
 		for _, group := range constants.Groups {
 			className := parser.ToJavaName(group.Name)
 			code.WriteString(fmt.Sprintf("  %s: %s.%s,\n", 
@@ -350,9 +1025,14 @@ func (g *JavaScriptGenerator) GenerateIndex(allConstants []*parser.ConstantsFile
 		}
 	}
 	
+	// 子目录按命名空间导出
+	for _, subDir := range dir.SubDirs {
+		code.WriteString(fmt.Sprintf("  %s,\n", subDir))
+	}
+	
 	code.WriteString("};\n")
 	
 	// 写入文件
-	outputPath := filepath.Join(g.Config.OutputDir, "index.js")
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
+	outputPath := filepath.Join(g.GetNamespaceDir(dir.Namespace), "index.js")
+	return WriteOutputFile(outputPath, code.String())
 }
\ No newline at end of file
@@ -2,7 +2,6 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -23,10 +22,14 @@ func NewKotlinGenerator(config Config) *KotlinGenerator {
 
 // Generate 生成Kotlin代码
 func (g *KotlinGenerator) Generate(constants *parser.ConstantsFile) error {
+	if err := checkIntegerRange(constants, g.Config.Language); err != nil {
+		return err
+	}
+
 	var code strings.Builder
 	
 	// 包声明
-	code.WriteString(fmt.Sprintf("package %s\n\n", g.Config.PackageName))
+	code.WriteString(fmt.Sprintf("package %s\n\n", g.GetQualifiedPackageName(constants.Namespace)))
 	
 	// 文件头注释
 	code.WriteString(g.GetFileHeader(constants))
@@ -52,20 +55,29 @@ func (g *KotlinGenerator) Generate(constants *parser.ConstantsFile) error {
 	}
 	
 	// 写入文件
-	outputPath := g.GetOutputFilePath(constants.FileName)
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
+	outputPath := g.GetOutputFilePath(constants)
+	return WriteOutputFile(outputPath, code.String())
 }
 
 // generateConstGroup 生成const模式的常量组
 func (g *KotlinGenerator) generateConstGroup(group *parser.ConstantGroup, projectLabel string) string {
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionObject(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexObject(group)
+	}
+
 	var code strings.Builder
 	
 	// 生成注释
-	code.WriteString(fmt.Sprintf("// %s %s - %s\n", group.Name, group.Label, projectLabel))
+	code.WriteString(fmt.Sprintf("// %s %s - %s\n", group.Name, commentText(group.Label), commentText(projectLabel)))
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToKotlinConstantName(constants[i].Name) < parser.ToKotlinConstantName(constants[j].Name)
 	})
@@ -74,16 +86,85 @@ func (g *KotlinGenerator) generateConstGroup(group *parser.ConstantGroup, projec
 	for _, constant := range constants {
 		constName := fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
 		kotlinType := parser.GetKotlinType(constant.Type)
-		value := parser.FormatValue(constant.Value, constant.Type, "kotlin")
-		comment := constant.Label
+		value := constantValue(group, constant, "kotlin", "const")
+		if hasDocDetails(constant) {
+			code.WriteString(kotlinDoc(constant, ""))
+			code.WriteString(fmt.Sprintf("const val %s: %s = %s\n", constName, kotlinType, value))
+			continue
+		}
+		comment := commentText(constant.Label)
 		code.WriteString(fmt.Sprintf("const val %s: %s = %s // %s\n", constName, kotlinType, value, comment))
 	}
+
+	// 附加属性的查询函数
+	for _, attribute := range group.Attributes {
+		funcName := "get" + parser.ToKotlinName(group.Name) + parser.ToKotlinName(attribute.Name)
+		code.WriteString("\n")
+		code.WriteString(kotlinAttributeFunc(group, attribute, "", funcName, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		tableName := toCamelCase(group.Name) + "LocaleLabels"
+		code.WriteString("\n")
+		code.WriteString(g.kotlinLocaleTable(group, "", tableName, parser.GetKotlinType(group.Constants[0].Type), canonicalConstants(group),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+		code.WriteString("\n")
+		code.WriteString(g.kotlinLocalizedLabelFunc(group, "", "get"+parser.ToKotlinName(group.Name)+"LocalizedLabel", tableName))
+	}
+
+	// 标签模板的格式化函数
+	code.WriteString(kotlinTemplateFuncs(group, "", "format"+parser.ToKotlinName(group.Name)))
+
+	// 树形常量组的父子关系查询函数
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n")
+		code.WriteString(kotlinTreeFuncs(group, "", toCamelCase(group.Name), parser.ToKotlinName(group.Name),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+	}
+
+	// 状态机组的状态转换函数
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n")
+		code.WriteString(kotlinStateFuncs(group, "", toCamelCase(group.Name), parser.ToKotlinName(group.Name),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+	}
+
+	// 命名子集的集合及判断函数
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n")
+		code.WriteString(kotlinSubsetFuncs(group, "", strings.ToUpper(group.Name)+"_", parser.ToKotlinName(group.Name),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+	}
 	
 	return code.String()
 }
 
 // generateObject 生成常量组对象
 func (g *KotlinGenerator) generateObject(group *parser.ConstantGroup, _ string) string {
+	if group.Flags {
+		return g.generateFlagsEnum(group)
+	}
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionObject(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexObject(group)
+	}
+
 	var code strings.Builder
 	
 	objectName := parser.ToKotlinName(group.Name)
@@ -92,8 +173,7 @@ func (g *KotlinGenerator) generateObject(group *parser.ConstantGroup, _ string)
 	code.WriteString(fmt.Sprintf("object %s {\n", objectName))
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToKotlinConstantName(constants[i].Name) < parser.ToKotlinConstantName(constants[j].Name)
 	})
@@ -102,13 +182,17 @@ func (g *KotlinGenerator) generateObject(group *parser.ConstantGroup, _ string)
 	for _, constant := range constants {
 		constName := parser.ToKotlinConstantName(constant.Name)
 		kotlinType := parser.GetKotlinType(constant.Type)
-		value := parser.FormatValue(constant.Value, constant.Type, "kotlin")
-		comment := constant.Label
+		value := constantValue(group, constant, "kotlin", "class")
+		comment := commentText(constant.Label)
 		if comment == "" {
 			comment = constant.Label
 		}
 		
-		code.WriteString(fmt.Sprintf("    /** %s */\n", comment))
+		if hasDocDetails(constant) {
+			code.WriteString(kotlinDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /** %s */\n", comment))
+		}
 		code.WriteString(fmt.Sprintf("    const val %s: %s = %s\n", constName, kotlinType, value))
 	}
 	
@@ -125,6 +209,43 @@ func (g *KotlinGenerator) generateObject(group *parser.ConstantGroup, _ string)
 	code.WriteString(g.generateIsValid(group))
 	code.WriteString("\n")
 	code.WriteString(g.generateFromString(group))
+
+	// 附加属性的查询函数
+	for _, attribute := range group.Attributes {
+		code.WriteString("\n")
+		code.WriteString(kotlinAttributeFunc(group, attribute, "    ", "get"+parser.ToKotlinName(attribute.Name),
+			func(constant *parser.Constant) string {
+				return parser.ToKotlinConstantName(constant.Name)
+			}))
+	}
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.kotlinLocaleTable(group, "    ", "localeLabels", parser.GetKotlinType(group.Constants[0].Type), canonicalConstants(group),
+			func(constant *parser.Constant) string {
+				return parser.ToKotlinConstantName(constant.Name)
+			}))
+		code.WriteString("\n")
+		code.WriteString(g.kotlinLocalizedLabelFunc(group, "    ", "getLocalizedLabel", "localeLabels"))
+	}
+	code.WriteString(kotlinTemplateFuncs(group, "    ", "format"))
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n")
+		code.WriteString(kotlinTreeFuncs(group, "    ", "", "", func(constant *parser.Constant) string {
+			return parser.ToKotlinConstantName(constant.Name)
+		}))
+	}
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n")
+		code.WriteString(kotlinStateFuncs(group, "    ", "", "", func(constant *parser.Constant) string {
+			return parser.ToKotlinConstantName(constant.Name)
+		}))
+	}
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n")
+		code.WriteString(kotlinSubsetFuncs(group, "    ", "", "", func(constant *parser.Constant) string {
+			return parser.ToKotlinConstantName(constant.Name)
+		}))
+	}
 	
 	
 	code.WriteString("}\n")
@@ -201,8 +322,7 @@ func (g *KotlinGenerator) generateGetKeyValuePairs(group *parser.ConstantGroup)
 	code.WriteString("        return mapOf(\n")
 	
 	// 按字母顺序排序
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToKotlinConstantName(constants[i].Name) < parser.ToKotlinConstantName(constants[j].Name)
 	})
@@ -233,7 +353,7 @@ func (g *KotlinGenerator) generateFormat(group *parser.ConstantGroup) string {
 	code.WriteString(fmt.Sprintf(`    fun format(value: %s): String {`, kotlinType))
 	code.WriteString("\n")
 	code.WriteString("        val labels = mapOf(\n")
-	for i, constant := range group.Constants {
+	for i, constant := range canonicalConstants(group) {
 		if i > 0 {
 			code.WriteString(",\n")
 		}
@@ -242,7 +362,7 @@ func (g *KotlinGenerator) generateFormat(group *parser.ConstantGroup) string {
 		if label == "" {
 			label = constant.Name
 		}
-		code.WriteString(fmt.Sprintf(`            %s to "%s"`, constName, label))
+		code.WriteString(fmt.Sprintf(`            %s to %s`, constName, parser.FormatValue(label, "string", "kotlin")))
 	}
 	code.WriteString(",\n")
 	code.WriteString("        )\n\n")
@@ -289,7 +409,514 @@ func (g *KotlinGenerator) generateFromString(group *parser.ConstantGroup) string
 	
 	return code.String()
 }
+// generateFlagsEnum 生成位标志组的枚举类，组合值以整数掩码表示，伴生对象提供组合操作方法
+func (g *KotlinGenerator) generateFlagsEnum(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	enumName := parser.ToKotlinName(group.Name)
+	dataType := group.Constants[0].Type
+	kotlinType := parser.GetKotlinType(dataType)
+	zero := parser.FormatValue(int64(0), dataType, "kotlin")
+	constants := flagConstants(group)
+
+	code.WriteString(fmt.Sprintf("/** %s（位标志，可按位组合） */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("enum class %s(val value: %s, val label: String) {\n", enumName, kotlinType))
+	for i, constant := range constants {
+		if hasDocDetails(constant) {
+			code.WriteString(kotlinDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /** %s */\n", commentText(constant.Label)))
+		}
+		separator := ","
+		if i == len(constants)-1 {
+			separator = ";"
+		}
+		code.WriteString(fmt.Sprintf("    %s(%s, %s)%s\n", parser.ToKotlinConstantName(constant.Name),
+			parser.FormatConstantValue(constant, dataType, "kotlin"),
+			parser.FormatValue(constantLabel(constant), "string", "kotlin"), separator))
+	}
+
+	// 附加属性
+	for _, attribute := range group.Attributes {
+		code.WriteString(fmt.Sprintf("\n    /** 标志的%s */\n", commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("    val %s: %s\n", toCamelCase(attribute.Name), parser.GetKotlinType(attribute.Type)))
+		code.WriteString("        get() = when (this) {\n")
+		for _, constant := range constants {
+			code.WriteString(fmt.Sprintf("            %s -> %s\n", parser.ToKotlinConstantName(constant.Name),
+				parser.FormatValue(constant.Attributes[attribute.Name], attribute.Type, "kotlin")))
+		}
+		code.WriteString("        }\n")
+	}
+
+	// 按语言查询标签，标签表定义在伴生对象中
+	if hasGroupLocales(group) {
+		code.WriteString("\n    /** 获取标志在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言 */\n")
+		code.WriteString("    fun getLocalizedLabel(locale: String): String {\n")
+		code.WriteString(g.kotlinLocaleLookup("        ", "localeLabels"))
+		code.WriteString("        return labels.getValue(this)\n")
+		code.WriteString("    }\n")
+	}
+
+	code.WriteString("\n    companion object {\n")
+	code.WriteString("        /** 所有已知标志的组合 */\n")
+	code.WriteString(fmt.Sprintf("        const val MASK: %s = %s\n\n", kotlinType, parser.FormatValue(flagMask(group), dataType, "kotlin")))
+
+	// 别名指向同值的枚举常量
+	for _, constant := range expandAliases(constants) {
+		if constant.AliasOf == "" {
+			continue
+		}
+		code.WriteString(kotlinDoc(constant, "        "))
+		code.WriteString(fmt.Sprintf("        val %s: %s = %s\n\n", parser.ToKotlinConstantName(constant.Name),
+			enumName, parser.ToKotlinConstantName(constant.AliasOf)))
+	}
+
+	code.WriteString("        /** 判断组合值是否包含指定标志 */\n")
+	code.WriteString(fmt.Sprintf("        fun has(mask: %s, flag: %s): Boolean {\n", kotlinType, enumName))
+	code.WriteString("            return (mask and flag.value) == flag.value\n")
+	code.WriteString("        }\n\n")
+
+	code.WriteString("        /** 返回添加指定标志后的组合值 */\n")
+	code.WriteString(fmt.Sprintf("        fun add(mask: %s, flag: %s): %s {\n", kotlinType, enumName, kotlinType))
+	code.WriteString("            return mask or flag.value\n")
+	code.WriteString("        }\n\n")
+
+	code.WriteString("        /** 返回移除指定标志后的组合值 */\n")
+	code.WriteString(fmt.Sprintf("        fun remove(mask: %s, flag: %s): %s {\n", kotlinType, enumName, kotlinType))
+	code.WriteString("            return mask and flag.value.inv()\n")
+	code.WriteString("        }\n\n")
+
+	code.WriteString("        /** 将组合值拆分为标志列表，按位从低到高排列 */\n")
+	code.WriteString(fmt.Sprintf("        fun toList(mask: %s): List<%s> {\n", kotlinType, enumName))
+	code.WriteString("            return values().filter { has(mask, it) }\n")
+	code.WriteString("        }\n\n")
+
+	code.WriteString("        /** 将标志集合合并为组合值 */\n")
+	code.WriteString(fmt.Sprintf("        fun toMask(flags: Collection<%s>): %s {\n", enumName, kotlinType))
+	code.WriteString(fmt.Sprintf("            return flags.fold(%s) { mask, flag -> mask or flag.value }\n", zero))
+	code.WriteString("        }\n\n")
+
+	code.WriteString("        /** 格式化组合值，标签以|分隔，如 读|写 */\n")
+	code.WriteString(fmt.Sprintf("        fun format(mask: %s): String {\n", kotlinType))
+	code.WriteString(`            if (!isValid(mask)) return "Unknown($mask)"`)
+	code.WriteString("\n")
+	code.WriteString(`            return toList(mask).joinToString("|") { it.label }`)
+	code.WriteString("\n        }\n\n")
+
+	code.WriteString("        /** 验证组合值是否只包含已知标志 */\n")
+	code.WriteString(fmt.Sprintf("        fun isValid(mask: %s): Boolean {\n", kotlinType))
+	code.WriteString(fmt.Sprintf("            return (mask and MASK.inv()) == %s\n", zero))
+	code.WriteString("        }\n")
+
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.kotlinLocaleTable(group, "        ", "localeLabels", enumName, constants, func(constant *parser.Constant) string {
+			return parser.ToKotlinConstantName(constant.Name)
+		}))
+
+		code.WriteString("\n        /** 按指定语言（如 en、en-US）格式化组合值，标签以|分隔，语言未知时使用默认语言 */\n")
+		code.WriteString(fmt.Sprintf("        fun format(mask: %s, locale: String): String {\n", kotlinType))
+		code.WriteString(`            if (!isValid(mask)) return "Unknown($mask)"`)
+		code.WriteString("\n")
+		code.WriteString(`            return toList(mask).joinToString("|") { it.getLocalizedLabel(locale) }`)
+		code.WriteString("\n        }\n")
+	}
+	code.WriteString(kotlinTemplateFuncs(group, "        ", "format"))
+	code.WriteString("    }\n")
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateRecordClass 生成记录组的密封类：每个常量是一个data object，别名和查询表定义在伴生对象中；
+// 伴生对象引用子对象的属性均延迟初始化，以免先访问子对象时读到尚未初始化的实例
+func (g *KotlinGenerator) generateRecordClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToKotlinName(group.Name)
+	fields := recordFields(group)
+	primary := fields[0]
+	primaryType := parser.GetKotlinType(primary.Type)
+	byPrimaryName := "by" + parser.ToKotlinName(primary.Name)
+
+	code.WriteString(fmt.Sprintf("/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("sealed class %s(\n", className))
+	code.WriteString("    /** 键名 */\n")
+	code.WriteString("    val key: String,\n")
+	code.WriteString("    /** 标签 */\n")
+	code.WriteString("    val label: String,\n")
+	for _, field := range fields {
+		code.WriteString(fmt.Sprintf("    /** %s */\n", commentText(attributeLabel(field))))
+		code.WriteString(fmt.Sprintf("    val %s: %s,\n", toCamelCase(field.Name), parser.GetKotlinType(field.Type)))
+	}
+	code.WriteString(") {\n")
+
+	for _, constant := range group.Constants {
+		args := []string{
+			parser.FormatValue(constant.Name, "string", "kotlin"),
+			parser.FormatValue(constant.Label, "string", "kotlin"),
+		}
+		for _, field := range fields {
+			args = append(args, recordFieldValue(group, constant, field, field.Type, "kotlin"))
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(kotlinDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("    data object %s : %s(%s)\n", parser.ToKotlinName(constant.Name), className, strings.Join(args, ", ")))
+	}
+
+	// 按语言查询标签，标签表按主值索引，定义在伴生对象中
+	if hasGroupLocales(group) {
+		code.WriteString(fmt.Sprintf("\n    /** 获取%s在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言 */\n", commentText(group.Label)))
+		code.WriteString("    fun getLocalizedLabel(locale: String): String {\n")
+		code.WriteString(g.kotlinLocaleLookup("        ", "localeLabels"))
+		code.WriteString(fmt.Sprintf("        return labels.getValue(%s)\n", toCamelCase(primary.Name)))
+		code.WriteString("    }\n")
+	}
+
+	code.WriteString("\n    companion object {\n")
+
+	// 别名指向同一个子对象
+	for _, constant := range expandAliases(group.Constants) {
+		if constant.AliasOf == "" {
+			continue
+		}
+		code.WriteString(kotlinDoc(constant, "        "))
+		code.WriteString(fmt.Sprintf("        val %s: %s get() = %s\n\n", parser.ToKotlinName(constant.Name),
+			className, parser.ToKotlinName(constant.AliasOf)))
+	}
+
+	var records []string
+	for _, constant := range group.Constants {
+		records = append(records, parser.ToKotlinName(constant.Name))
+	}
+	code.WriteString(fmt.Sprintf("        /** 所有%s，按源文件顺序排列 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("        val allRecords: List<%s> by lazy { listOf(%s) }\n\n", className, strings.Join(records, ", ")))
+
+	code.WriteString(fmt.Sprintf("        private val byKey: Map<String, %s> by lazy {\n", className))
+	code.WriteString("            mapOf(\n")
+	for _, constant := range expandAliases(group.Constants) {
+		code.WriteString(fmt.Sprintf("                %s to %s,\n", parser.FormatValue(constant.Name, "string", "kotlin"),
+			parser.ToKotlinName(constant.Name)))
+	}
+	code.WriteString("            )\n")
+	code.WriteString("        }\n\n")
+
+	code.WriteString(fmt.Sprintf("        private val %s: Map<%s, %s> by lazy { allRecords.associateBy { it.%s } }\n\n",
+		byPrimaryName, primaryType, className, toCamelCase(primary.Name)))
+
+	code.WriteString(fmt.Sprintf("        /** 按键名（含别名）查找%s，不存在时返回null */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("        fun fromKey(key: String): %s? = byKey[key]\n\n", className))
 
+	code.WriteString(fmt.Sprintf("        /** 按%s查找%s，不存在时返回null */\n", commentText(attributeLabel(primary)), commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("        fun from%s(value: %s): %s? = %s[value]\n", parser.ToKotlinName(primary.Name),
+		primaryType, className, byPrimaryName))
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.kotlinLocaleTable(group, "        ", "localeLabels", primaryType, group.Constants, func(constant *parser.Constant) string {
+			return recordFieldValue(group, constant, primary, primary.Type, "kotlin")
+		}))
+	}
+	code.WriteString(kotlinTemplateFuncs(group, "        ", "format"))
+	code.WriteString("    }\n")
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateCollectionObject 生成集合值常量组的对象：列表使用 listOf，映射使用 mapOf，均为只读集合，映射保持源文件中的顺序
+func (g *KotlinGenerator) generateCollectionObject(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("object %s {\n", parser.ToKotlinName(group.Name)))
+	for i, constant := range expandAliases(group.Constants) {
+		kind, elementType, _ := parser.CollectionType(constant.Type)
+		valueType := fmt.Sprintf("List<%s>", parser.GetKotlinType(elementType))
+		value := fmt.Sprintf("listOf(%s)", strings.Join(collectionItems(constant, "kotlin", ""), ", "))
+		if kind == "map" {
+			valueType = fmt.Sprintf("Map<String, %s>", parser.GetKotlinType(elementType))
+			value = fmt.Sprintf("mapOf(%s)", strings.Join(collectionItems(constant, "kotlin", " to "), ", "))
+		}
+
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(kotlinDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("    val %s: %s = %s\n", parser.ToKotlinConstantName(constant.Name), valueType, value))
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateRegexObject 生成正则常量组的对象：每个常量是一个预编译的 Regex
+func (g *KotlinGenerator) generateRegexObject(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("object %s {\n", parser.ToKotlinName(group.Name)))
+	for i, constant := range expandAliases(group.Constants) {
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(kotlinDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("    val %s: Regex = Regex(%s)\n",
+			parser.ToKotlinConstantName(constant.Name), regexLiteral(constant.Value.(string), "kotlin")))
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// kotlinAttributeFunc 生成按值查询附加属性的函数，constExpr 返回常量在when分支中的表达式，未知值返回属性的默认值
+func kotlinAttributeFunc(group *parser.ConstantGroup, attribute *parser.Attribute, indent, funcName string,
+	constExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	code.WriteString(indent + "/**\n")
+	code.WriteString(fmt.Sprintf("%s * 获取值对应的%s\n", indent, commentText(attributeLabel(attribute))))
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(fmt.Sprintf("%s * @return %s，未知值返回默认值\n", indent, commentText(attributeLabel(attribute))))
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%sfun %s(value: %s): %s {\n", indent, funcName,
+		parser.GetKotlinType(group.Constants[0].Type), parser.GetKotlinType(attribute.Type)))
+	code.WriteString(indent + "    return when (value) {\n")
+	for _, constant := range canonicalConstants(group) {
+		code.WriteString(fmt.Sprintf("%s        %s -> %s\n", indent, constExpr(constant),
+			parser.FormatValue(constant.Attributes[attribute.Name], attribute.Type, "kotlin")))
+	}
+	code.WriteString(fmt.Sprintf("%s        else -> %s\n", indent, parser.FormatValue(attribute.Default, attribute.Type, "kotlin")))
+	code.WriteString(indent + "    }\n")
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// kotlinLocaleTable 生成各语言的标签表（语言代码 -> 键 -> 标签），keyExpr 返回常量作为键的表达式
+func (g *KotlinGenerator) kotlinLocaleTable(group *parser.ConstantGroup, indent, tableName, keyType string, constants []*parser.Constant,
+	keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("%s/** %s各语言的标签，缺少翻译的常量使用默认语言的标签 */\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%sprivate val %s: Map<String, Map<%s, String>> = mapOf(\n", indent, tableName, keyType))
+	for _, locale := range parser.LabelLocales(group, g.Config.DefaultLocale) {
+		code.WriteString(fmt.Sprintf("%s    %q to mapOf(\n", indent, locale))
+		for _, constant := range constants {
+			code.WriteString(fmt.Sprintf("%s        %s to %s,\n", indent, keyExpr(constant),
+				parser.FormatValue(localizedLabel(constant, locale), "string", "kotlin")))
+		}
+		code.WriteString(indent + "    ),\n")
+	}
+	code.WriteString(indent + ")\n")
+
+	return code.String()
+}
+
+// kotlinLocaleLookup 生成按语言选取标签表的语句：先按完整的语言代码查找，再按语言部分（如 en-US 的 en），最后使用默认语言
+func (g *KotlinGenerator) kotlinLocaleLookup(indent, tableName string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("%sval labels = %s[locale]\n", indent, tableName))
+	code.WriteString(fmt.Sprintf("%s    ?: %s[locale.replace('_', '-').substringBefore('-')]\n", indent, tableName))
+	code.WriteString(fmt.Sprintf("%s    ?: %s.getValue(%q)\n", indent, tableName, g.Config.DefaultLocale))
+
+	return code.String()
+}
+
+// kotlinLocalizedLabelFunc 生成按值和语言查询标签的函数，未知值返回null
+func (g *KotlinGenerator) kotlinLocalizedLabelFunc(group *parser.ConstantGroup, indent, funcName, tableName string) string {
+	var code strings.Builder
+
+	code.WriteString(indent + "/**\n")
+	code.WriteString(fmt.Sprintf("%s * 获取值在指定语言下的%s标签，语言未知时使用默认语言\n", indent, commentText(group.Label)))
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @param locale 语言代码，如 en、en-US\n")
+	code.WriteString(indent + " * @return 标签，未知值返回null\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%sfun %s(value: %s, locale: String): String? {\n", indent, funcName,
+		parser.GetKotlinType(group.Constants[0].Type)))
+	code.WriteString(g.kotlinLocaleLookup(indent+"    ", tableName))
+	code.WriteString(indent + "    return labels[value]\n")
+	code.WriteString(indent + "}\n")
+
+	return code.String()
+}
+
+// kotlinTemplateFuncs 为标签含占位符的常量生成格式化函数，函数名为 prefix 加常量名，函数体为字符串模板
+func kotlinTemplateFuncs(group *parser.ConstantGroup, indent, prefix string) string {
+	var code strings.Builder
+
+	for _, constant := range templateConstants(group) {
+		template := constant.Template
+		var text strings.Builder
+		for _, part := range template.Parts {
+			if part.Param == "" {
+				text.WriteString(stringContent(part.Text, "kotlin"))
+				continue
+			}
+			text.WriteString("${" + toCamelCase(part.Param) + "}")
+		}
+
+		code.WriteString(fmt.Sprintf("\n%s/** %s */\n", indent, templateDoc(constant)))
+		code.WriteString(fmt.Sprintf("%sfun %s%s(%s): String = \"%s\"\n", indent, prefix, parser.ToKotlinName(constant.Name),
+			templateParams(template, func(param parser.TemplateParam) string {
+				return toCamelCase(param.Name) + ": " + parser.GetKotlinType(param.Type)
+			}), text.String()))
+	}
+
+	return code.String()
+}
+
+// kotlinTreeFuncs 生成树形常量组的父子关系表及查询父节点、子节点、祖先的函数，keyExpr 返回常量作为键的表达式；
+// 表名以 tablePrefix 开头（为空时为 parents、children），函数名中插入 name（如 getRegionParent、isRegionDescendantOf）
+func kotlinTreeFuncs(group *parser.ConstantGroup, indent, tablePrefix, name string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	valueType := parser.GetKotlinType(group.Constants[0].Type)
+	parentsName, childrenName := "parents", "children"
+	if tablePrefix != "" {
+		parentsName, childrenName = tablePrefix+"Parents", tablePrefix+"Children"
+	}
+	code.WriteString(fmt.Sprintf("%s/** %s中各常量的父常量 */\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%sprivate val %s: Map<%s, %s> = mapOf(\n", indent, parentsName, valueType, valueType))
+	for _, constant := range group.Constants {
+		if parent := parentConstant(group, constant); parent != nil {
+			code.WriteString(fmt.Sprintf("%s    %s to %s,\n", indent, keyExpr(constant), keyExpr(parent)))
+		}
+	}
+	code.WriteString(indent + ")\n\n")
+	code.WriteString(fmt.Sprintf("%s/** %s中各常量的直接子常量 */\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%sprivate val %s: Map<%s, List<%s>> = mapOf(\n", indent, childrenName, valueType, valueType))
+	for _, constant := range group.Constants {
+		var children []string
+		for _, child := range childConstants(group, constant) {
+			children = append(children, keyExpr(child))
+		}
+		if len(children) > 0 {
+			code.WriteString(fmt.Sprintf("%s    %s to listOf(%s),\n", indent, keyExpr(constant), strings.Join(children, ", ")))
+		}
+	}
+	code.WriteString(indent + ")\n")
+
+	ancestors := fmt.Sprintf("generateSequence(%s[value]) { %s[it] }", parentsName, parentsName)
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 获取值的父节点\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @return 父节点的值，根节点和未知值返回null\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%sfun get%sParent(value: %s): %s? = %s[value]\n", indent, name, valueType, valueType, parentsName))
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 获取值的直接子节点，按定义顺序排列\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @return 子节点的值，没有子节点时返回空列表\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%sfun get%sChildren(value: %s): List<%s> = %s[value] ?: emptyList()\n", indent, name, valueType, valueType, childrenName))
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 获取值的全部祖先节点，从父节点到根节点排列\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @return 祖先节点的值，根节点和未知值返回空列表\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%sfun get%sAncestors(value: %s): List<%s> = %s.toList()\n", indent, name, valueType, valueType, ancestors))
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 判断值是否为指定节点的后代节点（不含自身）\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @param ancestor 祖先节点的值\n")
+	code.WriteString(indent + " * @return 是否为后代节点\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%sfun is%sDescendantOf(value: %s, ancestor: %s): Boolean = %s.any { it == ancestor }\n",
+		indent, name, valueType, valueType, ancestors))
+
+	return code.String()
+}
+
+// kotlinStateFuncs 生成状态机组的转换表及判断能否转换、查询后继状态和终止状态的函数，keyExpr 返回常量作为键的表达式；
+// tablePrefix 非空时表名为 <前缀>Transitions，name 插入函数名中（如 canOrderStatusTransition）
+func kotlinStateFuncs(group *parser.ConstantGroup, indent, tablePrefix, name string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	valueType := parser.GetKotlinType(group.Constants[0].Type)
+	tableName := "transitions"
+	if tablePrefix != "" {
+		tableName = tablePrefix + "Transitions"
+	}
+	code.WriteString(fmt.Sprintf("%s/** %s中各状态允许转换到的目标状态 */\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%sprivate val %s: Map<%s, List<%s>> = mapOf(\n", indent, tableName, valueType, valueType))
+	for _, entry := range stateTableEntries(group, keyExpr) {
+		if entry[1] == "" {
+			code.WriteString(fmt.Sprintf("%s    %s to emptyList<%s>(),\n", indent, entry[0], valueType))
+			continue
+		}
+		code.WriteString(fmt.Sprintf("%s    %s to listOf(%s),\n", indent, entry[0], entry[1]))
+	}
+	code.WriteString(indent + ")\n")
+
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 判断能否从状态 from 转换到状态 to\n")
+	code.WriteString(indent + " * @param from 当前状态\n")
+	code.WriteString(indent + " * @param to 目标状态\n")
+	code.WriteString(indent + " * @return 是否允许转换\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%sfun can%sTransition(from: %s, to: %s): Boolean = %s[from]?.contains(to) == true\n",
+		indent, name, valueType, valueType, tableName))
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 获取状态允许转换到的目标状态\n")
+	code.WriteString(indent + " * @param from 当前状态\n")
+	code.WriteString(indent + " * @return 目标状态，终止状态和未知值返回空列表\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%sfun get%sNextStates(from: %s): List<%s> = %s[from] ?: emptyList()\n", indent, name, valueType, valueType, tableName))
+	code.WriteString("\n" + indent + "/**\n")
+	code.WriteString(indent + " * 判断值是否为终止状态（没有可转换到的目标状态）\n")
+	code.WriteString(indent + " * @param value 常量值\n")
+	code.WriteString(indent + " * @return 是否为终止状态，未知值返回false\n")
+	code.WriteString(indent + " */\n")
+	code.WriteString(fmt.Sprintf("%sfun is%sTerminal(value: %s): Boolean = %s[value]?.isEmpty() == true\n", indent, name, valueType, tableName))
+
+	return code.String()
+}
+
+// kotlinSubsetFuncs 生成命名子集的只读集合及判断是否属于子集的函数，集合名为 prefix 加大写的子集名，
+// 函数名为 is + name + 子集名；keyExpr 返回成员的表达式
+func kotlinSubsetFuncs(group *parser.ConstantGroup, indent, prefix, name string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	valueType := parser.GetKotlinType(group.Constants[0].Type)
+	for i, subset := range group.Subsets {
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		setName := prefix + strings.ToUpper(subset.Name)
+		code.WriteString(fmt.Sprintf("%s/** %s的子集：%s */\n", indent, commentText(group.Label), commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("%sval %s: Set<%s> = setOf(%s)\n", indent, setName, valueType, subsetKeys(group, subset, keyExpr)))
+		code.WriteString("\n" + indent + "/**\n")
+		code.WriteString(fmt.Sprintf("%s * 判断值是否属于子集“%s”\n", indent, commentText(subset.Label)))
+		code.WriteString(indent + " * @param value 常量值\n")
+		code.WriteString(indent + " * @return 是否属于该子集\n")
+		code.WriteString(indent + " */\n")
+		code.WriteString(fmt.Sprintf("%sfun is%s%s(value: %s): Boolean = value in %s\n", indent, name, parser.ToKotlinName(subset.Name), valueType, setName))
+	}
+
+	return code.String()
+}
+
+// kotlinDoc 生成常量的KDoc注释，废弃常量附加 @Deprecated 注解
+func kotlinDoc(constant *parser.Constant, indent string) string {
+	doc := blockDoc(indent, docLines(constant))
+	if constant.Deprecated {
+		doc += fmt.Sprintf("%s@Deprecated(%s)\n", indent, parser.FormatValue(deprecationMessage(constant), "string", "kotlin"))
+	}
+	return doc
+}
 
 // GenerateIndex Kotlin不需要生成索引文件
 func (g *KotlinGenerator) GenerateIndex(allConstants []*parser.ConstantsFile) error {
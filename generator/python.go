@@ -2,7 +2,6 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -32,7 +31,29 @@ func (g *PythonGenerator) Generate(constants *parser.ConstantsFile) error {
 	code.WriteString("\n")
 
 	if g.Config.Mode == "const" {
-		// const模式 - 生成简单常量
+		// const模式 - 生成简单常量；记录组、集合值常量组和正则常量组与class模式相同，记录组需要导入dataclass，
+		// 映射值需要导入MappingProxyType，正则常量需要导入re
+		if hasRegexGroup(constants) {
+			code.WriteString("import re\n")
+		}
+		imported := true
+		if hasRecordGroup(constants) {
+			code.WriteString("from dataclasses import dataclass\n")
+			code.WriteString("from typing import List, Dict, Optional, ClassVar\n")
+		} else if hasTreeGroup(constants) || hasStateMachine(constants) {
+			code.WriteString("from typing import List, Optional\n")
+		} else if hasLocalizedLabels(constants) {
+			code.WriteString("from typing import Optional\n")
+		} else {
+			imported = false
+		}
+		if hasMapConstants(constants) {
+			code.WriteString("from types import MappingProxyType\n")
+			imported = true
+		}
+		if imported || hasRegexGroup(constants) {
+			code.WriteString("\n\n")
+		}
 		for _, group := range constants.Groups {
 			code.WriteString(g.generateConstGroup(group, constants.Label))
 			code.WriteString("\n")
@@ -40,31 +61,94 @@ func (g *PythonGenerator) Generate(constants *parser.ConstantsFile) error {
 	} else {
 		// class模式 - 生成类
 		// 导入
-		code.WriteString("from typing import List, Dict, Optional, Any\n")
+		if hasRegexGroup(constants) {
+			code.WriteString("import re\n")
+		}
+		if hasRecordGroup(constants) {
+			code.WriteString("from dataclasses import dataclass\n")
+			code.WriteString("from typing import List, Dict, Optional, Any, ClassVar\n")
+		} else {
+			code.WriteString("from typing import List, Dict, Optional, Any\n")
+		}
+		if hasFlagsGroup(constants) {
+			code.WriteString("from enum import IntFlag\n")
+		}
+		if hasMapConstants(constants) {
+			code.WriteString("from types import MappingProxyType\n")
+		}
 		code.WriteString("\n\n")
 
 		// 生成每个常量组的类
 		for _, group := range constants.Groups {
 			code.WriteString(g.generateGroupClass(group, constants.Label))
 			code.WriteString("\n\n")
+			if group.Record != nil {
+				// 记录组的字段即附加属性，不另生成查询表
+				continue
+			}
+			for _, attribute := range group.Attributes {
+				className := parser.ToGoName(group.Name)
+				keyType := parser.GetPythonType(group.Constants[0].Type)
+				if group.Flags {
+					keyType = className
+				}
+				code.WriteString(pythonAttributeMap(group, attribute, keyType, func(constant *parser.Constant) string {
+					return className + "." + parser.ToPythonName(constant.Name)
+				}))
+				code.WriteString("\n\n")
+			}
+			if hasGroupLocales(group) {
+				className := parser.ToGoName(group.Name)
+				keyType, keys := parser.GetPythonType(group.Constants[0].Type), canonicalConstants(group)
+				if group.Flags {
+					keyType, keys = className, flagConstants(group)
+				}
+				code.WriteString(g.pythonLocaleTable(group, keyType, keys, func(constant *parser.Constant) string {
+					return className + "." + parser.ToPythonName(constant.Name)
+				}))
+				code.WriteString("\n\n")
+			}
+			if parser.IsTreeGroup(group) {
+				className := parser.ToGoName(group.Name)
+				code.WriteString(pythonTreeTables(group, parser.GetPythonType(group.Constants[0].Type), func(constant *parser.Constant) string {
+					return className + "." + parser.ToPythonName(constant.Name)
+				}))
+				code.WriteString("\n\n")
+			}
+			if parser.IsStateMachine(group) {
+				className := parser.ToGoName(group.Name)
+				code.WriteString(pythonStateTable(group, parser.GetPythonType(group.Constants[0].Type), func(constant *parser.Constant) string {
+					return className + "." + parser.ToPythonName(constant.Name)
+				}))
+				code.WriteString("\n\n")
+			}
 		}
 	}
 
 	// 写入文件
-	outputPath := g.GetOutputFilePath(constants.FileName)
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
+	outputPath := g.GetOutputFilePath(constants)
+	return WriteOutputFile(outputPath, code.String())
 }
 
 // generateConstGroup 生成const模式的常量组
 func (g *PythonGenerator) generateConstGroup(group *parser.ConstantGroup, projectLabel string) string {
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionClass(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexClass(group)
+	}
+
 	var code strings.Builder
 
 	// 生成注释
-	code.WriteString(fmt.Sprintf("# %s %s - %s\n", group.Name, group.Label, projectLabel))
+	code.WriteString(fmt.Sprintf("# %s %s - %s\n", group.Name, commentText(group.Label), commentText(projectLabel)))
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToPythonName(constants[i].Name) < parser.ToPythonName(constants[j].Name)
 	})
@@ -72,9 +156,62 @@ func (g *PythonGenerator) generateConstGroup(group *parser.ConstantGroup, projec
 	// 生成常量定义
 	for _, constant := range constants {
 		constName := fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
-		value := parser.FormatValue(constant.Value, constant.Type, "python")
-		comment := constant.Label
+		value := parser.FormatConstantValue(constant, constant.Type, "python")
+		comment := commentText(constant.Label)
 		code.WriteString(fmt.Sprintf("%s = %s  # %s\n", constName, value, comment))
+		code.WriteString(pythonDoc(constant, ""))
+	}
+
+	// 附加属性的查询表
+	for _, attribute := range group.Attributes {
+		code.WriteString("\n")
+		code.WriteString(pythonAttributeMap(group, attribute, "", func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.pythonLocaleTable(group, "", canonicalConstants(group), func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+		code.WriteString(fmt.Sprintf("\n\ndef %s_localized_label(value: %s, locale: str) -> Optional[str]:\n",
+			strings.ToLower(group.Name), parser.GetPythonType(group.Constants[0].Type)))
+		code.WriteString(`    """返回值在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言，未知值返回None"""`)
+		code.WriteString("\n")
+		code.WriteString(g.pythonLocaleLookup(group, "    "))
+		code.WriteString("    return labels.get(value)\n")
+	}
+
+	// 标签模板的格式化函数
+	code.WriteString(pythonTemplateFuncs(group, false))
+
+	// 树形常量组的父子关系查询函数
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n")
+		code.WriteString(pythonTreeTables(group, "", func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+		code.WriteString(pythonTreeFuncs(group, false))
+	}
+
+	// 状态机组的状态转换函数
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n")
+		code.WriteString(pythonStateTable(group, "", func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+		code.WriteString(pythonStateFuncs(group, false))
+	}
+
+	// 命名子集的集合及判断函数
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n")
+		code.WriteString(pythonSubsetSets(group, "", strings.ToUpper(group.Name)+"_", func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+		code.WriteString(pythonSubsetFuncs(group, false))
 	}
 	
 	return code.String()
@@ -82,18 +219,30 @@ func (g *PythonGenerator) generateConstGroup(group *parser.ConstantGroup, projec
 
 // generateGroupClass 生成常量组类
 func (g *PythonGenerator) generateGroupClass(group *parser.ConstantGroup, _ string) string {
+	if group.Flags {
+		return g.generateFlagsClass(group)
+	}
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionClass(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexClass(group)
+	}
+
 	var code strings.Builder
 
 	className := parser.ToGoName(group.Name)
 
 	// 类定义
 	code.WriteString(fmt.Sprintf("class %s:\n", className))
-	code.WriteString(fmt.Sprintf(`    """%s"""`, group.Label))
+	code.WriteString(fmt.Sprintf(`    """%s"""`, pythonDocText(group.Label)))
 	code.WriteString("\n\n")
 
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToPythonName(constants[i].Name) < parser.ToPythonName(constants[j].Name)
 	})
@@ -102,8 +251,8 @@ func (g *PythonGenerator) generateGroupClass(group *parser.ConstantGroup, _ stri
 	code.WriteString("    # 常量定义 (按字母顺序排列)\n")
 	for _, constant := range constants {
 		constName := parser.ToPythonName(constant.Name)
-		value := parser.FormatValue(constant.Value, constant.Type, "python")
-		comment := constant.Label
+		value := parser.FormatConstantValue(constant, constant.Type, "python")
+		comment := commentText(constant.Label)
 		if comment == "" {
 			comment = constant.Label
 		}
@@ -116,6 +265,13 @@ func (g *PythonGenerator) generateGroupClass(group *parser.ConstantGroup, _ stri
 
 		code.WriteString(fmt.Sprintf("    %s = %s%s# %s\n",
 			constName, value, strings.Repeat(" ", spaces), comment))
+		code.WriteString(pythonDoc(constant, "    "))
+	}
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n    # 命名子集\n")
+		code.WriteString(pythonSubsetSets(group, "    ", "", func(constant *parser.Constant) string {
+			return parser.ToPythonName(constant.Name)
+		}))
 	}
 
 	// 生成方法
@@ -131,7 +287,610 @@ func (g *PythonGenerator) generateGroupClass(group *parser.ConstantGroup, _ stri
 	code.WriteString(g.generateIsValid(group))
 	code.WriteString("\n")
 	code.WriteString(g.generateFromString(group))
+	code.WriteString(g.generateAttributes(group))
+	if hasGroupLocales(group) {
+		code.WriteString("\n    @classmethod\n")
+		code.WriteString(fmt.Sprintf("    def localized_label(cls, value: %s, locale: str) -> Optional[str]:\n",
+			parser.GetPythonType(group.Constants[0].Type)))
+		code.WriteString(`        """返回值在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言，未知值返回None"""`)
+		code.WriteString("\n")
+		code.WriteString(g.pythonLocaleLookup(group, "        "))
+		code.WriteString("        return labels.get(value)\n")
+	}
+	code.WriteString(pythonTemplateFuncs(group, true))
+	if parser.IsTreeGroup(group) {
+		code.WriteString(pythonTreeFuncs(group, true))
+	}
+	if parser.IsStateMachine(group) {
+		code.WriteString(pythonStateFuncs(group, true))
+	}
+	if len(group.Subsets) > 0 {
+		code.WriteString(pythonSubsetFuncs(group, true))
+	}
+
+	return code.String()
+}
+
+// generateFlagsClass 生成位标志组的IntFlag类及组合操作方法，标签表定义在类外以免成为枚举成员
+func (g *PythonGenerator) generateFlagsClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToGoName(group.Name)
+	labelsName := "_" + parser.ToPythonName(group.Name) + "_LABELS"
+	constants := flagConstants(group)
+
+	code.WriteString(fmt.Sprintf("class %s(IntFlag):\n", className))
+	code.WriteString(fmt.Sprintf(`    """%s（位标志，可按位组合）"""`, pythonDocText(group.Label)))
+	code.WriteString("\n\n")
+
+	// 标志定义（按位从低到高排列），别名成为IntFlag的别名成员
+	for _, constant := range expandAliases(constants) {
+		constName := parser.ToPythonName(constant.Name)
+		value := parser.FormatConstantValue(constant, constant.Type, "python")
+		spaces := 20 - len(constName)
+		if spaces < 1 {
+			spaces = 1
+		}
+		code.WriteString(fmt.Sprintf("    %s = %s%s# %s\n", constName, value, strings.Repeat(" ", spaces), commentText(constant.Label)))
+		code.WriteString(pythonDoc(constant, "    "))
+	}
+
+	code.WriteString("\n")
+	code.WriteString("    def has(self, flag: int) -> bool:\n")
+	code.WriteString(`        """判断是否包含指定的全部标志"""`)
+	code.WriteString("\n        return self & flag == flag\n\n")
+
+	code.WriteString(fmt.Sprintf("    def add(self, flag: int) -> \"%s\":\n", className))
+	code.WriteString(`        """返回添加指定标志后的值"""`)
+	code.WriteString(fmt.Sprintf("\n        return %s(self | flag)\n\n", className))
+
+	code.WriteString(fmt.Sprintf("    def remove(self, flag: int) -> \"%s\":\n", className))
+	code.WriteString(`        """返回移除指定标志后的值"""`)
+	code.WriteString(fmt.Sprintf("\n        return %s(self & ~flag)\n\n", className))
+
+	code.WriteString(fmt.Sprintf("    def to_list(self) -> List[\"%s\"]:\n", className))
+	code.WriteString(`        """拆分为包含的各个标志，按位从低到高排列"""`)
+	code.WriteString(fmt.Sprintf("\n        return [flag for flag in %s if self.has(flag)]\n\n", labelsName))
 
+	code.WriteString("    @classmethod\n")
+	code.WriteString(fmt.Sprintf("    def get_all_values(cls) -> List[\"%s\"]:\n", className))
+	code.WriteString(fmt.Sprintf(`        """获取所有%s标志"""`, pythonDocText(group.Label)))
+	code.WriteString(fmt.Sprintf("\n        return list(%s)\n\n", labelsName))
+
+	code.WriteString("    @classmethod\n")
+	code.WriteString("    def format_value(cls, value: int) -> str:\n")
+	code.WriteString(`        """格式化为以|分隔的标签，如 '读|写'；包含未知位时返回 'Unknown(value)'"""`)
+	code.WriteString("\n        if not cls.is_valid(value):\n")
+	code.WriteString("            return f'Unknown({value})'\n")
+	code.WriteString(fmt.Sprintf("        return '|'.join(label for flag, label in %s.items() if value & flag == flag)\n\n", labelsName))
+
+	code.WriteString("    @classmethod\n")
+	code.WriteString("    def is_valid(cls, value: int) -> bool:\n")
+	code.WriteString(`        """验证值是否为已知标志的任意组合"""`)
+	code.WriteString(fmt.Sprintf("\n        return value & ~%d == 0\n\n", flagMask(group)))
+
+	code.WriteString("    @classmethod\n")
+	code.WriteString(fmt.Sprintf("    def from_string(cls, key: str) -> Optional[\"%s\"]:\n", className))
+	code.WriteString(`        """从字符串键名获取标志，找不到时返回 None"""`)
+	code.WriteString("\n        return cls.__members__.get(key)\n")
+	code.WriteString(g.generateAttributes(group))
+	if hasGroupLocales(group) {
+		code.WriteString("\n    @classmethod\n")
+		code.WriteString("    def localized_label(cls, value: int, locale: str) -> str:\n")
+		code.WriteString(`        """格式化为指定语言（如 en、en-US）下以|分隔的标签，语言未知时使用默认语言；包含未知位时返回 'Unknown(value)'"""`)
+		code.WriteString("\n        if not cls.is_valid(value):\n")
+		code.WriteString("            return f'Unknown({value})'\n")
+		code.WriteString(g.pythonLocaleLookup(group, "        "))
+		code.WriteString(fmt.Sprintf("        return '|'.join(labels[flag] for flag in %s if value & flag == flag)\n", labelsName))
+	}
+	code.WriteString(pythonTemplateFuncs(group, true))
+	code.WriteString("\n\n")
+
+	code.WriteString(fmt.Sprintf("%s: Dict[%s, str] = {\n", labelsName, className))
+	for _, constant := range constants {
+		code.WriteString(fmt.Sprintf("    %s.%s: %s,\n", className, parser.ToPythonName(constant.Name),
+			parser.FormatValue(constantLabel(constant), "string", "python")))
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateRecordClass 生成记录组的不可变数据类：记录以类属性访问，在类定义之后创建，
+// 并生成按键名和按主值查找的查询表
+func (g *PythonGenerator) generateRecordClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToGoName(group.Name)
+	fields := recordFields(group)
+	primary := fields[0]
+	prefix := "_" + strings.ToUpper(group.Name)
+	recordsName := prefix + "_RECORDS"
+	byKeyName := prefix + "_BY_KEY"
+	byPrimaryName := prefix + "_BY_" + strings.ToUpper(primary.Name)
+	constants := expandAliases(group.Constants)
+
+	code.WriteString("@dataclass(frozen=True)\n")
+	code.WriteString(fmt.Sprintf("class %s:\n", className))
+	code.WriteString(fmt.Sprintf(`    """%s"""`, pythonDocText(group.Label)))
+	code.WriteString("\n\n")
+
+	code.WriteString("    # 记录定义 (按源文件顺序排列)，实例在类定义之后创建\n")
+	for _, constant := range constants {
+		code.WriteString(fmt.Sprintf("    %s: ClassVar[\"%s\"]  # %s\n", parser.ToPythonName(constant.Name), className, commentText(constant.Label)))
+		code.WriteString(pythonDoc(constant, "    "))
+	}
+
+	code.WriteString("\n")
+	code.WriteString("    key: str  # 键名\n")
+	code.WriteString("    label: str  # 标签\n")
+	for _, field := range fields {
+		code.WriteString(fmt.Sprintf("    %s: %s  # %s\n", field.Name, parser.GetPythonType(field.Type), commentText(attributeLabel(field))))
+	}
+
+	code.WriteString("\n    @classmethod\n")
+	code.WriteString(fmt.Sprintf("    def all_records(cls) -> List[\"%s\"]:\n", className))
+	code.WriteString(fmt.Sprintf(`        """获取所有%s，按源文件顺序排列"""`, pythonDocText(group.Label)))
+	code.WriteString(fmt.Sprintf("\n        return list(%s)\n", recordsName))
+
+	code.WriteString("\n    @classmethod\n")
+	code.WriteString(fmt.Sprintf("    def from_key(cls, key: str) -> Optional[\"%s\"]:\n", className))
+	code.WriteString(fmt.Sprintf(`        """按键名（含别名）查找%s，不存在时返回None"""`, pythonDocText(group.Label)))
+	code.WriteString(fmt.Sprintf("\n        return %s.get(key)\n", byKeyName))
+
+	code.WriteString("\n    @classmethod\n")
+	code.WriteString(fmt.Sprintf("    def from_%s(cls, value: %s) -> Optional[\"%s\"]:\n", primary.Name, parser.GetPythonType(primary.Type), className))
+	code.WriteString(fmt.Sprintf(`        """按%s查找%s，不存在时返回None"""`, pythonDocText(attributeLabel(primary)), pythonDocText(group.Label)))
+	code.WriteString(fmt.Sprintf("\n        return %s.get(value)\n", byPrimaryName))
+	if hasGroupLocales(group) {
+		code.WriteString("\n    def localized_label(self, locale: str) -> str:\n")
+		code.WriteString(fmt.Sprintf(`        """返回%s在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言"""`, pythonDocText(group.Label)))
+		code.WriteString("\n")
+		code.WriteString(g.pythonLocaleLookup(group, "        "))
+		code.WriteString(fmt.Sprintf("        return labels[self.%s]\n", primary.Name))
+	}
+	code.WriteString(pythonTemplateFuncs(group, true))
+	code.WriteString("\n\n")
+
+	for _, constant := range constants {
+		constName := className + "." + parser.ToPythonName(constant.Name)
+		if constant.AliasOf != "" {
+			code.WriteString(fmt.Sprintf("%s = %s.%s\n", constName, className, parser.ToPythonName(constant.AliasOf)))
+			continue
+		}
+		items := []string{
+			"key=" + parser.FormatValue(constant.Name, "string", "python"),
+			"label=" + parser.FormatValue(constant.Label, "string", "python"),
+		}
+		for _, field := range fields {
+			items = append(items, field.Name+"="+recordFieldValue(group, constant, field, field.Type, "python"))
+		}
+		code.WriteString(fmt.Sprintf("%s = %s(%s)\n", constName, className, strings.Join(items, ", ")))
+	}
+
+	code.WriteString(fmt.Sprintf("\n# 所有%s\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s: List[%s] = [\n", recordsName, className))
+	for _, constant := range group.Constants {
+		code.WriteString(fmt.Sprintf("    %s.%s,\n", className, parser.ToPythonName(constant.Name)))
+	}
+	code.WriteString("]\n")
+
+	code.WriteString(fmt.Sprintf("\n# 按键名（含别名）索引的%s\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s: Dict[str, %s] = {\n", byKeyName, className))
+	for _, constant := range constants {
+		code.WriteString(fmt.Sprintf("    %s: %s.%s,\n", parser.FormatValue(constant.Name, "string", "python"),
+			className, parser.ToPythonName(constant.Name)))
+	}
+	code.WriteString("}\n")
+
+	code.WriteString(fmt.Sprintf("\n# 按%s索引的%s\n", commentText(attributeLabel(primary)), commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s: Dict[%s, %s] = {\n", byPrimaryName, parser.GetPythonType(primary.Type), className))
+	for _, constant := range group.Constants {
+		code.WriteString(fmt.Sprintf("    %s: %s.%s,\n", recordFieldValue(group, constant, primary, primary.Type, "python"),
+			className, parser.ToPythonName(constant.Name)))
+	}
+	code.WriteString("}\n")
+
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.pythonLocaleTable(group, parser.GetPythonType(primary.Type), group.Constants, func(constant *parser.Constant) string {
+			return recordFieldValue(group, constant, primary, primary.Type, "python")
+		}))
+	}
+
+	return code.String()
+}
+
+// generateAttributes 生成附加属性的查询方法，查询表定义在类之后（位标志组的类属性会成为枚举成员）
+func (g *PythonGenerator) generateAttributes(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	valueType := parser.GetPythonType(group.Constants[0].Type)
+	for _, attribute := range group.Attributes {
+		attrType := parser.GetPythonType(attribute.Type)
+		code.WriteString("\n    @classmethod\n")
+		code.WriteString(fmt.Sprintf("    def %s(cls, value: %s) -> %s:\n", attribute.Name, valueType, attrType))
+		code.WriteString(fmt.Sprintf(`        """获取值对应的%s，未知值返回默认值"""`, pythonDocText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("\n        return %s.get(value, %s)\n", pythonAttributeMapName(group, attribute),
+			parser.FormatValue(attribute.Default, attribute.Type, "python")))
+	}
+	return code.String()
+}
+
+// pythonAttributeMapName 返回附加属性查询表的名称，如 ORDER_STATUS_COLOR
+func pythonAttributeMapName(group *parser.ConstantGroup, attribute *parser.Attribute) string {
+	return strings.ToUpper(group.Name) + "_" + strings.ToUpper(attribute.Name)
+}
+
+// pythonAttributeMap 生成值到附加属性的查询表，keyExpr 返回常量作为键的表达式，keyType 为空时不加类型注解
+func pythonAttributeMap(group *parser.ConstantGroup, attribute *parser.Attribute, keyType string,
+	keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	mapName := pythonAttributeMapName(group, attribute)
+	code.WriteString(fmt.Sprintf("# %s的%s\n", commentText(group.Label), commentText(attributeLabel(attribute))))
+	if keyType != "" {
+		code.WriteString(fmt.Sprintf("%s: Dict[%s, %s] = {\n", mapName, keyType, parser.GetPythonType(attribute.Type)))
+	} else {
+		code.WriteString(fmt.Sprintf("%s = {\n", mapName))
+	}
+	for _, constant := range canonicalConstants(group) {
+		code.WriteString(fmt.Sprintf("    %s: %s,\n", keyExpr(constant),
+			parser.FormatValue(constant.Attributes[attribute.Name], attribute.Type, "python")))
+	}
+	code.WriteString("}\n")
+	return code.String()
+}
+
+// generateCollectionClass 生成集合值常量组的类：列表生成为元组，映射生成为只读的 MappingProxyType，
+// 单元素的元组带有结尾的逗号
+func (g *PythonGenerator) generateCollectionClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("class %s:\n", parser.ToGoName(group.Name)))
+	code.WriteString(fmt.Sprintf(`    """%s"""`, pythonDocText(group.Label)))
+	code.WriteString("\n\n")
+	code.WriteString("    # 常量定义 (按源文件顺序排列)\n")
+	for _, constant := range expandAliases(group.Constants) {
+		kind, _, _ := parser.CollectionType(constant.Type)
+		value := ""
+		if kind == "map" {
+			value = fmt.Sprintf("MappingProxyType({%s})", strings.Join(collectionItems(constant, "python", ": "), ", "))
+		} else {
+			items := collectionItems(constant, "python", "")
+			value = "(" + strings.Join(items, ", ") + ")"
+			if len(items) == 1 {
+				value = "(" + items[0] + ",)"
+			}
+		}
+		code.WriteString(fmt.Sprintf("    %s = %s  # %s\n", parser.ToPythonName(constant.Name), value, commentText(constant.Label)))
+		code.WriteString(pythonDoc(constant, "    "))
+	}
+
+	return code.String()
+}
+
+// generateRegexClass 生成正则常量组的类：每个常量是一个用 re.compile 预编译的类属性
+func (g *PythonGenerator) generateRegexClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("class %s:\n", parser.ToGoName(group.Name)))
+	code.WriteString(fmt.Sprintf(`    """%s"""`, pythonDocText(group.Label)))
+	code.WriteString("\n\n")
+	code.WriteString("    # 常量定义 (按源文件顺序排列)\n")
+	for _, constant := range expandAliases(group.Constants) {
+		code.WriteString(fmt.Sprintf("    %s = re.compile(%s)  # %s\n", parser.ToPythonName(constant.Name),
+			regexLiteral(constant.Value.(string), "python"), constant.Label))
+		code.WriteString(pythonDoc(constant, "    "))
+	}
+
+	return code.String()
+}
+
+// pythonLocaleTableName 返回各语言标签表的名称，如 ORDER_STATUS_LOCALE_LABELS；记录组的表与其他查询表一样以下划线开头
+func pythonLocaleTableName(group *parser.ConstantGroup) string {
+	if group.Record != nil {
+		return "_" + strings.ToUpper(group.Name) + "_LOCALE_LABELS"
+	}
+	return strings.ToUpper(group.Name) + "_LOCALE_LABELS"
+}
+
+// pythonLocaleTable 生成各语言的标签表（语言代码 -> 键 -> 标签），keyExpr 返回常量作为键的表达式，keyType 为空时不加类型注解
+func (g *PythonGenerator) pythonLocaleTable(group *parser.ConstantGroup, keyType string, constants []*parser.Constant,
+	keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	tableName := pythonLocaleTableName(group)
+	code.WriteString(fmt.Sprintf("# %s各语言的标签，缺少翻译的常量使用默认语言的标签\n", commentText(group.Label)))
+	if keyType != "" {
+		code.WriteString(fmt.Sprintf("%s: Dict[str, Dict[%s, str]] = {\n", tableName, keyType))
+	} else {
+		code.WriteString(fmt.Sprintf("%s = {\n", tableName))
+	}
+	for _, locale := range parser.LabelLocales(group, g.Config.DefaultLocale) {
+		code.WriteString(fmt.Sprintf("    '%s': {\n", locale))
+		for _, constant := range constants {
+			code.WriteString(fmt.Sprintf("        %s: %s,\n", keyExpr(constant),
+				parser.FormatValue(localizedLabel(constant, locale), "string", "python")))
+		}
+		code.WriteString("    },\n")
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// pythonLocaleLookup 生成按语言选取标签表的语句：先按完整的语言代码查找，再按语言部分（如 en-US 的 en），最后使用默认语言
+func (g *PythonGenerator) pythonLocaleLookup(group *parser.ConstantGroup, indent string) string {
+	tableName := pythonLocaleTableName(group)
+	return fmt.Sprintf("%slabels = %s.get(locale) or %s.get(locale.replace('_', '-').split('-')[0]) or %s['%s']\n",
+		indent, tableName, tableName, tableName, g.Config.DefaultLocale)
+}
+
+// pythonTemplateFuncs 为标签含占位符的常量生成格式化函数：method 为 true 时生成为类方法 format_xxx，
+// 否则生成模块级函数 format_<组名>_<常量名>
+func pythonTemplateFuncs(group *parser.ConstantGroup, method bool) string {
+	var code strings.Builder
+
+	for _, constant := range templateConstants(group) {
+		template := constant.Template
+		var format strings.Builder
+		for _, part := range template.Parts {
+			if part.Param == "" {
+				format.WriteString(strings.NewReplacer("{", "{{", "}", "}}").Replace(part.Text))
+				continue
+			}
+			format.WriteString("{" + part.Param + "}")
+		}
+		params := templateParams(template, func(param parser.TemplateParam) string {
+			return param.Name + ": " + parser.GetPythonType(param.Type)
+		})
+		args := templateParams(template, func(param parser.TemplateParam) string {
+			// 布尔值按其他语言的写法输出为 true/false
+			if param.Type == "bool" {
+				return param.Name + "=str(" + param.Name + ").lower()"
+			}
+			return param.Name + "=" + param.Name
+		})
+		doc := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(templateDoc(constant))
+		body := fmt.Sprintf("return %s.format(%s)", parser.FormatValue(format.String(), "string", "python"), args)
+
+		if method {
+			code.WriteString("\n    @classmethod\n")
+			code.WriteString(fmt.Sprintf("    def format_%s(cls, %s) -> str:\n", strings.ToLower(constant.Name), params))
+			code.WriteString(fmt.Sprintf("        \"\"\"%s\"\"\"\n", doc))
+			code.WriteString(fmt.Sprintf("        %s\n", body))
+			continue
+		}
+		code.WriteString(fmt.Sprintf("\n\ndef format_%s_%s(%s) -> str:\n", strings.ToLower(group.Name), strings.ToLower(constant.Name), params))
+		code.WriteString(fmt.Sprintf("    \"\"\"%s\"\"\"\n", doc))
+		code.WriteString(fmt.Sprintf("    %s\n", body))
+	}
+
+	return code.String()
+}
+
+// pythonTreeTables 生成树形常量组的父子关系表（如 REGION_PARENTS、REGION_CHILDREN），keyExpr 返回常量作为键的表达式，
+// keyType 为空时不加类型注解
+func pythonTreeTables(group *parser.ConstantGroup, keyType string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	parentsName, childrenName := strings.ToUpper(group.Name)+"_PARENTS", strings.ToUpper(group.Name)+"_CHILDREN"
+	code.WriteString(fmt.Sprintf("# %s中各常量的父常量\n", commentText(group.Label)))
+	if keyType != "" {
+		code.WriteString(fmt.Sprintf("%s: Dict[%s, %s] = {\n", parentsName, keyType, keyType))
+	} else {
+		code.WriteString(fmt.Sprintf("%s = {\n", parentsName))
+	}
+	for _, constant := range group.Constants {
+		if parent := parentConstant(group, constant); parent != nil {
+			code.WriteString(fmt.Sprintf("    %s: %s,\n", keyExpr(constant), keyExpr(parent)))
+		}
+	}
+	code.WriteString("}\n\n")
+	code.WriteString(fmt.Sprintf("# %s中各常量的直接子常量\n", commentText(group.Label)))
+	if keyType != "" {
+		code.WriteString(fmt.Sprintf("%s: Dict[%s, List[%s]] = {\n", childrenName, keyType, keyType))
+	} else {
+		code.WriteString(fmt.Sprintf("%s = {\n", childrenName))
+	}
+	for _, constant := range group.Constants {
+		var children []string
+		for _, child := range childConstants(group, constant) {
+			children = append(children, keyExpr(child))
+		}
+		if len(children) > 0 {
+			code.WriteString(fmt.Sprintf("    %s: [%s],\n", keyExpr(constant), strings.Join(children, ", ")))
+		}
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// pythonTreeFuncs 生成查询父节点、子节点和祖先的函数：method 为 true 时生成为类方法 parent、children 等，
+// 否则生成模块级函数 <组名>_parent 等
+func pythonTreeFuncs(group *parser.ConstantGroup, method bool) string {
+	var code strings.Builder
+
+	valueType := parser.GetPythonType(group.Constants[0].Type)
+	parentsName, childrenName := strings.ToUpper(group.Name)+"_PARENTS", strings.ToUpper(group.Name)+"_CHILDREN"
+	funcs := []struct {
+		name, params, result, doc string
+		body                      []string
+	}{
+		{"parent", "value: " + valueType, "Optional[" + valueType + "]", "返回值的父节点，根节点和未知值返回None",
+			[]string{fmt.Sprintf("return %s.get(value)", parentsName)}},
+		{"children", "value: " + valueType, "List[" + valueType + "]", "返回值的直接子节点，按定义顺序排列",
+			[]string{fmt.Sprintf("return list(%s.get(value, []))", childrenName)}},
+		{"ancestors", "value: " + valueType, "List[" + valueType + "]", "返回值的全部祖先节点，从父节点到根节点排列",
+			[]string{
+				"ancestors = []",
+				fmt.Sprintf("while value in %s:", parentsName),
+				fmt.Sprintf("    value = %s[value]", parentsName),
+				"    ancestors.append(value)",
+				"return ancestors",
+			}},
+		{"is_descendant_of", fmt.Sprintf("value: %s, ancestor: %s", valueType, valueType), "bool", "判断值是否为 ancestor 的后代节点（不含自身）",
+			[]string{
+				fmt.Sprintf("while value in %s:", parentsName),
+				fmt.Sprintf("    value = %s[value]", parentsName),
+				"    if value == ancestor:",
+				"        return True",
+				"return False",
+			}},
+	}
+
+	for _, f := range funcs {
+		indent := "    "
+		if method {
+			code.WriteString("\n    @classmethod\n")
+			code.WriteString(fmt.Sprintf("    def %s(cls, %s) -> %s:\n", f.name, f.params, f.result))
+			indent = "        "
+		} else {
+			code.WriteString(fmt.Sprintf("\n\ndef %s_%s(%s) -> %s:\n", strings.ToLower(group.Name), f.name, f.params, f.result))
+		}
+		code.WriteString(fmt.Sprintf("%s\"\"\"%s\"\"\"\n", indent, f.doc))
+		for _, line := range f.body {
+			code.WriteString(indent + line + "\n")
+		}
+	}
+
+	return code.String()
+}
+
+// pythonStateTable 生成状态机组的转换表（如 ORDER_STATUS_TRANSITIONS），终止状态对应空列表；
+// keyExpr 返回常量作为键的表达式，keyType 非空时标注表的类型
+func pythonStateTable(group *parser.ConstantGroup, keyType string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	tableName := strings.ToUpper(group.Name) + "_TRANSITIONS"
+	code.WriteString(fmt.Sprintf("# %s中各状态允许转换到的目标状态\n", commentText(group.Label)))
+	if keyType != "" {
+		code.WriteString(fmt.Sprintf("%s: Dict[%s, List[%s]] = {\n", tableName, keyType, keyType))
+	} else {
+		code.WriteString(fmt.Sprintf("%s = {\n", tableName))
+	}
+	for _, entry := range stateTableEntries(group, keyExpr) {
+		code.WriteString(fmt.Sprintf("    %s: [%s],\n", entry[0], entry[1]))
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// pythonStateFuncs 生成判断能否转换、查询后继状态和终止状态的函数：method 为 true 时生成为类方法 can_transition、
+// next_states、is_terminal，否则生成以组名为前缀的模块函数
+func pythonStateFuncs(group *parser.ConstantGroup, method bool) string {
+	var code strings.Builder
+
+	valueType := parser.GetPythonType(group.Constants[0].Type)
+	tableName := strings.ToUpper(group.Name) + "_TRANSITIONS"
+	funcs := []struct {
+		name, params, result, doc string
+		body                      []string
+	}{
+		{"can_transition", fmt.Sprintf("from_state: %s, to_state: %s", valueType, valueType), "bool", "判断能否从状态 from_state 转换到状态 to_state",
+			[]string{fmt.Sprintf("return to_state in %s.get(from_state, [])", tableName)}},
+		{"next_states", "from_state: " + valueType, "List[" + valueType + "]", "返回状态 from_state 允许转换到的目标状态，终止状态和未知值返回空列表",
+			[]string{fmt.Sprintf("return list(%s.get(from_state, []))", tableName)}},
+		{"is_terminal", "value: " + valueType, "bool", "判断值是否为终止状态（没有可转换到的目标状态），未知值返回False",
+			[]string{fmt.Sprintf("return value in %s and not %s[value]", tableName, tableName)}},
+	}
+
+	for _, f := range funcs {
+		indent := "    "
+		if method {
+			code.WriteString("\n    @classmethod\n")
+			code.WriteString(fmt.Sprintf("    def %s(cls, %s) -> %s:\n", f.name, f.params, f.result))
+			indent = "        "
+		} else {
+			code.WriteString(fmt.Sprintf("\n\ndef %s_%s(%s) -> %s:\n", strings.ToLower(group.Name), f.name, f.params, f.result))
+		}
+		code.WriteString(fmt.Sprintf("%s\"\"\"%s\"\"\"\n", indent, f.doc))
+		for _, line := range f.body {
+			code.WriteString(indent + line + "\n")
+		}
+	}
+
+	return code.String()
+}
+
+// pythonSubsetSets 生成命名子集的frozenset（如 ORDER_STATUS_ACTIVE），prefix 为集合名的前缀，keyExpr 返回成员的表达式
+func pythonSubsetSets(group *parser.ConstantGroup, indent, prefix string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	for _, subset := range group.Subsets {
+		code.WriteString(fmt.Sprintf("%s%s%s = frozenset({%s})  # %s\n", indent, prefix, strings.ToUpper(subset.Name),
+			subsetKeys(group, subset, keyExpr), subset.Label))
+	}
+
+	return code.String()
+}
+
+// pythonSubsetFuncs 生成判断值是否属于命名子集的函数：method 为 true 时生成为类方法 is_<子集名>，
+// 否则生成以组名为前缀的模块函数
+func pythonSubsetFuncs(group *parser.ConstantGroup, method bool) string {
+	var code strings.Builder
+
+	valueType := parser.GetPythonType(group.Constants[0].Type)
+	for _, subset := range group.Subsets {
+		name := strings.ToLower(subset.Name)
+		indent, setName := "    ", strings.ToUpper(group.Name)+"_"+strings.ToUpper(subset.Name)
+		if method {
+			code.WriteString("\n    @classmethod\n")
+			code.WriteString(fmt.Sprintf("    def is_%s(cls, value: %s) -> bool:\n", name, valueType))
+			indent, setName = "        ", "cls."+strings.ToUpper(subset.Name)
+		} else {
+			code.WriteString(fmt.Sprintf("\n\ndef %s_is_%s(value: %s) -> bool:\n", strings.ToLower(group.Name), name, valueType))
+		}
+		code.WriteString(fmt.Sprintf("%s\"\"\"判断值是否属于子集“%s”\"\"\"\n", indent, commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("%sreturn value in %s\n", indent, setName))
+	}
+
+	return code.String()
+}
+
+// pythonDocText 返回放入文档字符串的一行文本：换行替换为空格，并转义反斜杠和双引号，
+// 以免 \N、\u 等被当作转义序列，或文本中的 """ 提前结束文档字符串
+func pythonDocText(text string) string {
+	return pythonDocEscaper.Replace(commentText(text))
+}
+
+// pythonDocEscaper 转义文档字符串中的反斜杠和双引号
+var pythonDocEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// pythonDoc 生成常量的属性文档字符串（描述、附加标记、废弃说明），无详细信息时返回空
+func pythonDoc(constant *parser.Constant, indent string) string {
+	if !hasDocDetails(constant) {
+		return ""
+	}
+
+	lines := docDetails(constant)
+	if constant.Deprecated {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "Deprecated: "+deprecationMessage(constant))
+	}
+
+	for i, line := range lines {
+		lines[i] = pythonDocEscaper.Replace(line)
+	}
+	if len(lines) == 1 {
+		return fmt.Sprintf("%s\"\"\"%s\"\"\"\n", indent, lines[0])
+	}
+
+	var code strings.Builder
+	code.WriteString(indent + `"""` + "\n")
+	for _, line := range lines {
+		if line == "" {
+			code.WriteString("\n")
+		} else {
+			code.WriteString(indent + line + "\n")
+		}
+	}
+	code.WriteString(indent + `"""` + "\n")
 	return code.String()
 }
 
@@ -144,7 +903,7 @@ func (g *PythonGenerator) generateGetAllValues(group *parser.ConstantGroup) stri
 	code.WriteString("    @classmethod\n")
 	code.WriteString(fmt.Sprintf("    def get_all_values(cls) -> List[%s]:\n",
 		parser.GetPythonType(group.Constants[0].Type)))
-	code.WriteString(fmt.Sprintf(`        """获取所有%s常量值"""`, group.Label))
+	code.WriteString(fmt.Sprintf(`        """获取所有%s常量值"""`, pythonDocText(group.Label)))
 	code.WriteString("\n        return [")
 
 	// 按字母顺序排序
@@ -172,7 +931,7 @@ func (g *PythonGenerator) generateGetAllKeys(group *parser.ConstantGroup) string
 
 	code.WriteString("    @classmethod\n")
 	code.WriteString("    def get_all_keys(cls) -> List[str]:\n")
-	code.WriteString(fmt.Sprintf(`        """获取所有%s常量键名"""`, group.Label))
+	code.WriteString(fmt.Sprintf(`        """获取所有%s常量键名"""`, pythonDocText(group.Label)))
 	code.WriteString("\n        return [")
 
 	// 按字母顺序排序
@@ -205,8 +964,7 @@ func (g *PythonGenerator) generateGetKeyValuePairs(group *parser.ConstantGroup)
 	code.WriteString("\n        return {\n")
 
 	// 按字母顺序排序
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToPythonName(constants[i].Name) < parser.ToPythonName(constants[j].Name)
 	})
@@ -230,7 +988,7 @@ func (g *PythonGenerator) generateFormatValue(group *parser.ConstantGroup) strin
 
 	code.WriteString("    @classmethod\n")
 	code.WriteString(fmt.Sprintf("    def format_value(cls, value: %s) -> str:\n", valueType))
-	code.WriteString(fmt.Sprintf(`        """根据值格式化%s的标签`, group.Label))
+	code.WriteString(fmt.Sprintf(`        """根据值格式化%s的标签`, pythonDocText(group.Label)))
 	code.WriteString("\n        \n")
 	code.WriteString("        Args:\n")
 	code.WriteString("            value: 常量值\n")
@@ -239,13 +997,13 @@ func (g *PythonGenerator) generateFormatValue(group *parser.ConstantGroup) strin
 	code.WriteString("            格式化后的标签，找不到时返回 'Unknown(value)'\n")
 	code.WriteString(`        """`)
 	code.WriteString("\n        labels = {\n")
-	for _, constant := range group.Constants {
+	for _, constant := range canonicalConstants(group) {
 		constName := parser.ToPythonName(constant.Name)
 		label := constant.Label
 		if label == "" {
 			label = constant.Name
 		}
-		code.WriteString(fmt.Sprintf("            cls.%s: '%s',\n", constName, label))
+		code.WriteString(fmt.Sprintf("            cls.%s: %s,\n", constName, parser.FormatValue(label, "string", "python")))
 	}
 	code.WriteString("        }\n\n")
 	code.WriteString("        if value in labels:\n")
@@ -263,7 +1021,7 @@ func (g *PythonGenerator) generateIsValid(group *parser.ConstantGroup) string {
 
 	code.WriteString("    @classmethod\n")
 	code.WriteString(fmt.Sprintf("    def is_valid(cls, value: %s) -> bool:\n", valueType))
-	code.WriteString(fmt.Sprintf(`        """验证值是否为有效的%s常量"""`, group.Label))
+	code.WriteString(fmt.Sprintf(`        """验证值是否为有效的%s常量"""`, pythonDocText(group.Label)))
 	code.WriteString("\n        return value in cls.get_all_values()\n")
 
 	return code.String()
@@ -277,7 +1035,7 @@ func (g *PythonGenerator) generateFromString(group *parser.ConstantGroup) string
 
 	code.WriteString("    @classmethod\n")
 	code.WriteString(fmt.Sprintf("    def from_string(cls, key: str) -> Optional[%s]:\n", valueType))
-	code.WriteString(fmt.Sprintf(`        """从字符串键名获取%s常量值`, group.Label))
+	code.WriteString(fmt.Sprintf(`        """从字符串键名获取%s常量值`, pythonDocText(group.Label)))
 	code.WriteString("\n        \n")
 	code.WriteString("        Args:\n")
 	code.WriteString("            key: 常量键名\n")
@@ -292,8 +1050,18 @@ func (g *PythonGenerator) generateFromString(group *parser.ConstantGroup) string
 }
 
 
-// GenerateIndex 生成Python的__init__.py文件
+// GenerateIndex 生成Python的__init__.py文件（每个子包各生成一个）
 func (g *PythonGenerator) GenerateIndex(allConstants []*parser.ConstantsFile) error {
+	for _, dir := range buildIndexDirs(allConstants) {
+		if err := g.generateIndexFile(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateIndexFile 生成单个包目录的__init__.py文件
+func (g *PythonGenerator) generateIndexFile(dir *indexDir) error {
 	var code strings.Builder
 
 	// 文件头注释
@@ -310,7 +1078,7 @@ func (g *PythonGenerator) GenerateIndex(allConstants []*parser.ConstantsFile) er
 
 	// 导入所有常量类
 	code.WriteString("# 导入所有常量类\n")
-	for _, constants := range allConstants {
+	for _, constants := range dir.Files {
 		var classes []string
 		for _, group := range constants.Groups {
 			classes = append(classes, parser.ToGoName(group.Name))
@@ -321,10 +1089,16 @@ func (g *PythonGenerator) GenerateIndex(allConstants []*parser.ConstantsFile) er
 		}
 	}
 
+	// 导入子包
+	if len(dir.SubDirs) > 0 {
+		code.WriteString("\n# 导入子包\n")
+		code.WriteString(fmt.Sprintf("from . import %s\n", strings.Join(dir.SubDirs, ", ")))
+	}
+
 	code.WriteString("\n# 导出所有常量类\n")
 	code.WriteString("__all__ = [\n")
 
-	for _, constants := range allConstants {
+	for _, constants := range dir.Files {
 		if len(constants.Groups) > 0 {
 			code.WriteString(fmt.Sprintf("    # %s.py 中的常量\n", constants.FileName))
 			for _, group := range constants.Groups {
@@ -334,6 +1108,14 @@ func (g *PythonGenerator) GenerateIndex(allConstants []*parser.ConstantsFile) er
 		}
 	}
 
+	if len(dir.SubDirs) > 0 {
+		code.WriteString("    # 子包\n")
+		for _, subDir := range dir.SubDirs {
+			code.WriteString(fmt.Sprintf("    '%s',\n", subDir))
+		}
+		code.WriteString("    \n")
+	}
+
 	code.WriteString("]\n\n")
 
 	// 版本信息
@@ -342,6 +1124,6 @@ func (g *PythonGenerator) GenerateIndex(allConstants []*parser.ConstantsFile) er
 	code.WriteString("__generator__ = 'cons-coder'\n")
 
 	// 写入文件
-	outputPath := filepath.Join(g.Config.OutputDir, "__init__.py")
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
+	outputPath := filepath.Join(g.GetNamespaceDir(dir.Namespace), "__init__.py")
+	return WriteOutputFile(outputPath, code.String())
 }
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"cons-coder/parser"
+)
+
+// 标签、描述和废弃说明中含反斜杠、引号和 """ 时，生成的文档字符串仍能编译
+const pythonDocSource = `# 路径 C:\New folder
+paths: # 路径 "C:\Users"
+  home: {value: 1, label: 'C:\New folder', description: 'C:\New folder\u0041'}
+  quoted: {value: 2, label: 结尾的引号", description: '含有 """ 的描述', deprecated: '旧写法 \N """'}
+  ending: {value: 3, label: 反斜杠结尾\, description: 描述以引号结尾"}
+`
+
+func TestPythonDocCompiles(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 不可用")
+	}
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "paths.yaml")
+	if err := os.WriteFile(source, []byte(pythonDocSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files, diags, err := parser.ParseFile(source)
+	if err != nil || diags.HasErrors() {
+		t.Fatalf("parse: %v %v", err, diags)
+	}
+
+	for _, mode := range []string{"class", "const"} {
+		output := filepath.Join(dir, mode)
+		gen := NewPythonGenerator(Config{Language: "python", Mode: mode, OutputDir: output, Version: "test"})
+		for _, constants := range files {
+			if err := gen.Generate(constants); err != nil {
+				t.Fatalf("%s: Generate() error: %v", mode, err)
+			}
+		}
+		// -W error：无效的转义序列也视为失败
+		cmd := exec.Command(python, "-W", "error", "-m", "py_compile", filepath.Join(output, "paths.py"))
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("%s: generated module does not compile: %v\n%s", mode, err, out)
+		}
+	}
+}
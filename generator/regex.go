@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"cons-coder/parser"
+)
+
+// regexFeatureNames 正则表达式扩展语法的说明，用于报告目标语言不支持的语法
+var regexFeatureNames = map[string]string{
+	parser.RegexLookahead:        "先行断言 (?=...)",
+	parser.RegexLookbehind:       "后行断言 (?<=...)",
+	parser.RegexBackreference:    "反向引用 \\1",
+	parser.RegexAtomicGroup:      "原子组 (?>...)",
+	parser.RegexPossessive:       "占有量词 *+",
+	parser.RegexNamedGroup:       "命名捕获组 (?<name>...)",
+	parser.RegexPythonNamedGroup: "命名捕获组 (?P<name>...)",
+	parser.RegexUnicodeClass:     "Unicode属性 \\p{...}",
+	parser.RegexInlineFlags:      "行内标志 (?i)",
+	parser.RegexStartAnchor:      "\\A",
+	parser.RegexEndAnchor:        "\\z",
+	parser.RegexEndAnchorNewline: "\\Z",
+	parser.RegexPosixClass:       "POSIX字符类 [[:alpha:]]",
+	parser.RegexQuote:            "字面引用 \\Q...\\E",
+	parser.RegexUnicodeEscape:    "Unicode转义 \\uXXXX",
+	parser.RegexEngineEscape:     "引擎特有的转义（如 \\h、\\R）",
+}
+
+// regexUnsupported 各语言的正则引擎不支持的扩展语法：Go为RE2语法，Java、Kotlin为java.util.regex，
+// Swift的NSRegularExpression为ICU语法，TypeScript/JavaScript的RegExp不带u标志；
+// RegExp把不认识的转义当作字面字符（\Q 即 Q、\h 即 h），不会报错，因此同样列为不支持
+var regexUnsupported = map[string][]string{
+	"go": {parser.RegexLookahead, parser.RegexLookbehind, parser.RegexBackreference, parser.RegexAtomicGroup,
+		parser.RegexPossessive, parser.RegexEndAnchorNewline, parser.RegexUnicodeEscape, parser.RegexEngineEscape},
+	"python": {parser.RegexNamedGroup, parser.RegexUnicodeClass, parser.RegexEndAnchor, parser.RegexPosixClass,
+		parser.RegexQuote, parser.RegexEngineEscape},
+	"java":   {parser.RegexPythonNamedGroup, parser.RegexPosixClass},
+	"kotlin": {parser.RegexPythonNamedGroup, parser.RegexPosixClass},
+	"swift":  {parser.RegexPythonNamedGroup},
+	"typescript": {parser.RegexPythonNamedGroup, parser.RegexAtomicGroup, parser.RegexPossessive, parser.RegexUnicodeClass,
+		parser.RegexInlineFlags, parser.RegexStartAnchor, parser.RegexEndAnchor, parser.RegexEndAnchorNewline, parser.RegexPosixClass,
+		parser.RegexQuote, parser.RegexEngineEscape},
+	"javascript": {parser.RegexPythonNamedGroup, parser.RegexAtomicGroup, parser.RegexPossessive, parser.RegexUnicodeClass,
+		parser.RegexInlineFlags, parser.RegexStartAnchor, parser.RegexEndAnchor, parser.RegexEndAnchorNewline, parser.RegexPosixClass,
+		parser.RegexQuote, parser.RegexEngineEscape},
+}
+
+// CheckRegexSupport 检查正则常量是否用到了目标语言的正则引擎不支持的语法，返回指向这些常量的警告；
+// 这些表达式仍会生成，但在目标语言中可能无法编译或匹配结果不同
+func CheckRegexSupport(allConstants []*parser.ConstantsFile, language string) parser.Diagnostics {
+	unsupported := make(map[string]bool)
+	for _, feature := range regexUnsupported[language] {
+		unsupported[feature] = true
+	}
+
+	var diags parser.Diagnostics
+	for _, constants := range allConstants {
+		for _, group := range constants.Groups {
+			for _, constant := range group.Constants {
+				pattern, ok := constant.Value.(string)
+				if constant.Type != "regex" || !ok {
+					continue
+				}
+				var names []string
+				for _, feature := range parser.RegexFeatures(pattern) {
+					if unsupported[feature] {
+						names = append(names, regexFeatureNames[feature])
+					}
+				}
+				if len(names) > 0 {
+					diags = append(diags, &parser.Diagnostic{
+						File:     constants.FilePath,
+						Pos:      constant.Pos,
+						Severity: parser.SeverityWarning,
+						Message: fmt.Sprintf("常量 '%s.%s' 的正则表达式用到了%s，%s的正则引擎不支持",
+							group.Name, constant.Name, strings.Join(names, "、"), language),
+					})
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// hasRegexGroup 判断文件中是否有正则常量组
+func hasRegexGroup(constants *parser.ConstantsFile) bool {
+	for _, group := range constants.Groups {
+		if parser.IsRegexGroup(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// regexLiteral 返回正则表达式在目标语言中的字符串字面值：能用原始字符串时使用原始字符串
+// （Go的 `...`、Python的 r"..."、Swift的 #"..."#），免去反斜杠的转义，否则使用普通的字符串字面值
+func regexLiteral(pattern string, lang string) string {
+	singleLine := !strings.ContainsAny(pattern, "\r\n")
+	switch {
+	case lang == "go" && singleLine && !strings.Contains(pattern, "`"):
+		return "`" + pattern + "`"
+	case lang == "python" && singleLine && !strings.Contains(pattern, `"`) && !strings.HasSuffix(pattern, `\`):
+		return `r"` + pattern + `"`
+	case lang == "swift" && singleLine && !strings.Contains(pattern, `"#`) && !strings.Contains(pattern, `\#`):
+		return `#"` + pattern + `"#`
+	}
+	return parser.FormatValue(pattern, "string", lang)
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"testing"
+
+	"cons-coder/parser"
+)
+
+func TestCheckRegexSupport(t *testing.T) {
+	patterns := map[string]string{
+		"lookahead": `^(?=.*\d).+$`,
+		"escape":    `^\h+$`,
+		"quote":     `\Qa.b\E`,
+		"unicode":   `\u0041`,
+	}
+	tests := []struct {
+		language string
+		warned   []string
+	}{
+		{"go", []string{"lookahead", "escape", "unicode"}},
+		{"python", []string{"escape", "quote"}},
+		{"typescript", []string{"escape", "quote"}},
+		{"javascript", []string{"escape", "quote"}},
+		{"java", nil},
+		{"swift", nil},
+	}
+	for _, tt := range tests {
+		for name, pattern := range patterns {
+			group := &parser.ConstantGroup{Name: "rules", Constants: []*parser.Constant{{Name: name, Value: pattern, Type: "regex"}}}
+			files := []*parser.ConstantsFile{{FilePath: "rules.yaml", Groups: []*parser.ConstantGroup{group}}}
+			want := false
+			for _, warned := range tt.warned {
+				want = want || warned == name
+			}
+			if got := len(CheckRegexSupport(files, tt.language)) > 0; got != want {
+				t.Errorf("CheckRegexSupport(%q, %s) warned = %v, want %v", pattern, tt.language, got, want)
+			}
+		}
+	}
+}
+
+func TestRegexLiteral(t *testing.T) {
+	tests := []struct {
+		pattern string
+		lang    string
+		want    string
+	}{
+		{`^\d+$`, "go", "`^\\d+$`"},
+		{"a`b", "go", "\"a`b\""},
+		{`^\d+$`, "python", `r"^\d+$"`},
+		{`a\`, "python", `"a\\"`},
+		{`^\d+$`, "swift", `#"^\d+$"#`},
+		{`^\d+$`, "java", `"^\\d+$"`},
+		{`it's`, "javascript", `'it\'s'`},
+	}
+	for _, tt := range tests {
+		if got := regexLiteral(tt.pattern, tt.lang); got != tt.want {
+			t.Errorf("regexLiteral(%q, %s) = %s, want %s", tt.pattern, tt.lang, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -47,8 +46,143 @@ func escapeSwiftKeyword(name string) string {
 	return name
 }
 
+// swiftDoc 生成常量的文档注释，废弃常量附加 @available 声明
+func swiftDoc(constant *parser.Constant, indent string) string {
+	doc := lineDoc(indent, "///", docLines(constant))
+	if constant.Deprecated {
+		doc += fmt.Sprintf("%s@available(*, deprecated, message: %s)\n", indent, parser.FormatValue(deprecationMessage(constant), "string", "swift"))
+	}
+	return doc
+}
+
+// swiftTemplateFuncs 为标签含占位符的常量生成格式化函数，declaration 为函数声明中常量名之前的部分，
+// 函数体为字符串插值
+func swiftTemplateFuncs(group *parser.ConstantGroup, indent, declaration string) string {
+	var code strings.Builder
+
+	for _, constant := range templateConstants(group) {
+		template := constant.Template
+		var text strings.Builder
+		for _, part := range template.Parts {
+			if part.Param == "" {
+				text.WriteString(stringContent(part.Text, "swift"))
+				continue
+			}
+			text.WriteString(`\(` + escapeSwiftKeyword(toCamelCase(part.Param)) + ")")
+		}
+
+		code.WriteString(fmt.Sprintf("\n%s/// %s\n", indent, templateDoc(constant)))
+		code.WriteString(fmt.Sprintf("%s%s%s(%s) -> String {\n", indent, declaration, parser.ToJavaName(constant.Name),
+			templateParams(template, func(param parser.TemplateParam) string {
+				return escapeSwiftKeyword(toCamelCase(param.Name)) + ": " + parser.GetSwiftType(param.Type)
+			})))
+		code.WriteString(fmt.Sprintf("%s    return \"%s\"\n", indent, text.String()))
+		code.WriteString(indent + "}\n")
+	}
+
+	return code.String()
+}
+
+// swiftLocaleTable 生成各语言的标签表（语言代码 -> 键 -> 标签），declaration 为表的声明（如 private static let localeLabels），
+// keyExpr 返回常量作为键的表达式
+func (g *SwiftGenerator) swiftLocaleTable(group *parser.ConstantGroup, indent, declaration, keyType string, constants []*parser.Constant,
+	keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("%s/// %s各语言的标签，缺少翻译的常量使用默认语言的标签\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s%s: [String: [%s: String]] = [\n", indent, declaration, keyType))
+	for _, locale := range parser.LabelLocales(group, g.Config.DefaultLocale) {
+		code.WriteString(fmt.Sprintf("%s    %q: [\n", indent, locale))
+		for _, constant := range constants {
+			code.WriteString(fmt.Sprintf("%s        %s: %s,\n", indent, keyExpr(constant),
+				parser.FormatValue(localizedLabel(constant, locale), "string", "swift")))
+		}
+		code.WriteString(indent + "    ],\n")
+	}
+	code.WriteString(indent + "]\n")
+
+	return code.String()
+}
+
+// swiftTreeTables 生成树形常量组的父子关系表，declaration 为声明关键字（如 private static let），
+// 表名为 parentTable、childTable 加上前缀 prefix（如 regionParentTable）；keyExpr 返回常量作为键的表达式
+func swiftTreeTables(group *parser.ConstantGroup, indent, declaration, prefix, keyType string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	parentTable, childTable := "parentTable", "childTable"
+	if prefix != "" {
+		parentTable, childTable = prefix+"ParentTable", prefix+"ChildTable"
+	}
+	code.WriteString(fmt.Sprintf("%s/// %s中各常量的父常量\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s%s %s: [%s: %s] = [\n", indent, declaration, parentTable, keyType, keyType))
+	for _, constant := range group.Constants {
+		if parent := parentConstant(group, constant); parent != nil {
+			code.WriteString(fmt.Sprintf("%s    %s: %s,\n", indent, keyExpr(constant), keyExpr(parent)))
+		}
+	}
+	code.WriteString(indent + "]\n\n")
+	code.WriteString(fmt.Sprintf("%s/// %s中各常量的直接子常量\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s%s %s: [%s: [%s]] = [\n", indent, declaration, childTable, keyType, keyType))
+	for _, constant := range group.Constants {
+		var children []string
+		for _, child := range childConstants(group, constant) {
+			children = append(children, keyExpr(child))
+		}
+		if len(children) > 0 {
+			code.WriteString(fmt.Sprintf("%s    %s: [%s],\n", indent, keyExpr(constant), strings.Join(children, ", ")))
+		}
+	}
+	code.WriteString(indent + "]\n")
+
+	return code.String()
+}
+
+// swiftStateTable 生成状态机组的转换表，declaration 为声明关键字和表名（如 private static let transitionTable），
+// 终止状态对应空数组；keyExpr 返回常量作为键的表达式
+func swiftStateTable(group *parser.ConstantGroup, indent, declaration, keyType string, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("%s/// %s中各状态允许转换到的目标状态\n", indent, commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("%s%s: [%s: [%s]] = [\n", indent, declaration, keyType, keyType))
+	for _, entry := range stateTableEntries(group, keyExpr) {
+		code.WriteString(fmt.Sprintf("%s    %s: [%s],\n", indent, entry[0], entry[1]))
+	}
+	code.WriteString(indent + "]\n")
+
+	return code.String()
+}
+
+// swiftLocaleLookup 生成按语言选取标签表的语句：先按完整的语言代码查找，再按语言部分（如 en-US 的 en），最后使用默认语言
+func (g *SwiftGenerator) swiftLocaleLookup(indent, tableName string) string {
+	return fmt.Sprintf("%slet labels = %s[locale] ?? %s[String(locale.prefix { $0 != \"-\" && $0 != \"_\" })] ?? %s[%q]!\n",
+		indent, tableName, tableName, tableName, g.Config.DefaultLocale)
+}
+
+// swiftAttributeSwitch 生成按值返回附加属性的switch语句，caseExpr 返回常量在case中的表达式；
+// 穷举枚举的所有case时不需要default分支
+func swiftAttributeSwitch(constants []*parser.Constant, attribute *parser.Attribute, indent, subject string, withDefault bool,
+	caseExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("%s    switch %s {\n", indent, subject))
+	for _, constant := range constants {
+		code.WriteString(fmt.Sprintf("%s    case %s: return %s\n", indent, caseExpr(constant),
+			parser.FormatValue(constant.Attributes[attribute.Name], attribute.Type, "swift")))
+	}
+	if withDefault {
+		code.WriteString(fmt.Sprintf("%s    default: return %s\n", indent, parser.FormatValue(attribute.Default, attribute.Type, "swift")))
+	}
+	code.WriteString(indent + "    }\n")
+
+	return code.String()
+}
+
 // Generate 生成Swift代码
 func (g *SwiftGenerator) Generate(constants *parser.ConstantsFile) error {
+	if err := checkIntegerRange(constants, g.Config.Language); err != nil {
+		return err
+	}
+
 	var code strings.Builder
 
 	// 导入
@@ -78,20 +212,29 @@ func (g *SwiftGenerator) Generate(constants *parser.ConstantsFile) error {
 	}
 
 	// 写入文件
-	outputPath := g.GetOutputFilePath(constants.FileName)
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
+	outputPath := g.GetOutputFilePath(constants)
+	return WriteOutputFile(outputPath, code.String())
 }
 
 // generateConstGroup 生成const模式的常量组
 func (g *SwiftGenerator) generateConstGroup(group *parser.ConstantGroup, projectLabel string) string {
+	if group.Record != nil {
+		return g.generateRecordStruct(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionEnum(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexEnum(group)
+	}
+
 	var code strings.Builder
 	
 	// 生成注释
-	code.WriteString(fmt.Sprintf("// %s %s - %s\n", group.Name, group.Label, projectLabel))
+	code.WriteString(fmt.Sprintf("// %s %s - %s\n", group.Name, commentText(group.Label), commentText(projectLabel)))
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToSwiftName(constants[i].Name) < parser.ToSwiftName(constants[j].Name)
 	})
@@ -100,37 +243,301 @@ func (g *SwiftGenerator) generateConstGroup(group *parser.ConstantGroup, project
 	for _, constant := range constants {
 		constName := fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
 		valueType := parser.GetSwiftType(constant.Type)
-		value := parser.FormatValue(constant.Value, constant.Type, "swift")
-		comment := constant.Label
+		value := constantValue(group, constant, "swift", "const")
+		if hasDocDetails(constant) {
+			code.WriteString(swiftDoc(constant, ""))
+			code.WriteString(fmt.Sprintf("public let %s: %s = %s\n", constName, valueType, value))
+			continue
+		}
+		comment := commentText(constant.Label)
 		code.WriteString(fmt.Sprintf("public let %s: %s = %s // %s\n", constName, valueType, value, comment))
 	}
+
+	// 附加属性的查询函数
+	for _, attribute := range group.Attributes {
+		funcName := toCamelCase(group.Name) + parser.ToSwiftName(attribute.Name)
+		code.WriteString(fmt.Sprintf("\n/// 获取值对应的%s，未知值返回默认值\n", commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("public func %s(_ value: %s) -> %s {\n", funcName,
+			parser.GetSwiftType(group.Constants[0].Type), parser.GetSwiftType(attribute.Type)))
+		code.WriteString(swiftAttributeSwitch(canonicalConstants(group), attribute, "", "value", true, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+		code.WriteString("}\n")
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		tableName := toCamelCase(group.Name) + "LocaleLabels"
+		valueType := parser.GetSwiftType(group.Constants[0].Type)
+		code.WriteString("\n")
+		code.WriteString(g.swiftLocaleTable(group, "", "private let "+tableName, valueType, canonicalConstants(group),
+			func(constant *parser.Constant) string {
+				return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+			}))
+		code.WriteString("\n/// 获取值在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言，未知值返回nil\n")
+		code.WriteString(fmt.Sprintf("public func %sLocalizedLabel(_ value: %s, locale: String) -> String? {\n", toCamelCase(group.Name), valueType))
+		code.WriteString(g.swiftLocaleLookup("    ", tableName))
+		code.WriteString("    return labels[value]\n")
+		code.WriteString("}\n")
+	}
+
+	// 标签模板的格式化函数
+	code.WriteString(swiftTemplateFuncs(group, "", "public func format"+parser.ToJavaName(group.Name)))
+
+	// 树形常量组的父子关系查询函数
+	if parser.IsTreeGroup(group) {
+		prefix := toCamelCase(group.Name)
+		valueType := parser.GetSwiftType(group.Constants[0].Type)
+		code.WriteString("\n")
+		code.WriteString(swiftTreeTables(group, "", "private let", prefix, valueType, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+		code.WriteString("\n/// 获取值的父节点，根节点和未知值返回nil\n")
+		code.WriteString(fmt.Sprintf("public func %sParent(_ value: %s) -> %s? {\n", prefix, valueType, valueType))
+		code.WriteString(fmt.Sprintf("    return %sParentTable[value]\n", prefix))
+		code.WriteString("}\n")
+		code.WriteString("\n/// 获取值的直接子节点，按定义顺序排列\n")
+		code.WriteString(fmt.Sprintf("public func %sChildren(_ value: %s) -> [%s] {\n", prefix, valueType, valueType))
+		code.WriteString(fmt.Sprintf("    return %sChildTable[value] ?? []\n", prefix))
+		code.WriteString("}\n")
+		code.WriteString("\n/// 获取值的全部祖先节点，从父节点到根节点排列\n")
+		code.WriteString(fmt.Sprintf("public func %sAncestors(_ value: %s) -> [%s] {\n", prefix, valueType, valueType))
+		code.WriteString(fmt.Sprintf("    return Array(sequence(first: value) { %sParentTable[$0] }.dropFirst())\n", prefix))
+		code.WriteString("}\n")
+		code.WriteString("\n/// 判断值是否为指定节点的后代节点（不含自身）\n")
+		code.WriteString(fmt.Sprintf("public func %sIsDescendant(_ value: %s, of ancestor: %s) -> Bool {\n", prefix, valueType, valueType))
+		code.WriteString(fmt.Sprintf("    return %sAncestors(value).contains(ancestor)\n", prefix))
+		code.WriteString("}\n")
+	}
+
+	// 状态机组的状态转换函数
+	if parser.IsStateMachine(group) {
+		prefix := toCamelCase(group.Name)
+		valueType := parser.GetSwiftType(group.Constants[0].Type)
+		code.WriteString("\n")
+		code.WriteString(swiftStateTable(group, "", "private let "+prefix+"TransitionTable", valueType, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+		code.WriteString("\n/// 判断能否从状态 from 转换到状态 to\n")
+		code.WriteString(fmt.Sprintf("public func %sCanTransition(_ from: %s, to: %s) -> Bool {\n", prefix, valueType, valueType))
+		code.WriteString(fmt.Sprintf("    return %sTransitionTable[from]?.contains(to) ?? false\n", prefix))
+		code.WriteString("}\n")
+		code.WriteString("\n/// 获取状态允许转换到的目标状态，终止状态和未知值返回空数组\n")
+		code.WriteString(fmt.Sprintf("public func %sNextStates(_ from: %s) -> [%s] {\n", prefix, valueType, valueType))
+		code.WriteString(fmt.Sprintf("    return %sTransitionTable[from] ?? []\n", prefix))
+		code.WriteString("}\n")
+		code.WriteString("\n/// 判断值是否为终止状态（没有可转换到的目标状态），未知值返回false\n")
+		code.WriteString(fmt.Sprintf("public func %sIsTerminal(_ value: %s) -> Bool {\n", prefix, valueType))
+		code.WriteString(fmt.Sprintf("    return %sTransitionTable[value]?.isEmpty ?? false\n", prefix))
+		code.WriteString("}\n")
+	}
+
+	// 命名子集的集合及判断函数
+	if len(group.Subsets) > 0 {
+		prefix := toCamelCase(group.Name)
+		valueType := parser.GetSwiftType(group.Constants[0].Type)
+		for _, subset := range group.Subsets {
+			name := parser.ToJavaName(subset.Name)
+			code.WriteString(fmt.Sprintf("\n/// %s的子集：%s\n", commentText(group.Label), commentText(subset.Label)))
+			code.WriteString(fmt.Sprintf("public let %s%s: Set<%s> = [%s]\n", prefix, name, valueType,
+				subsetKeys(group, subset, func(constant *parser.Constant) string {
+					return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+				})))
+			code.WriteString(fmt.Sprintf("\n/// 判断值是否属于子集“%s”\n", commentText(subset.Label)))
+			code.WriteString(fmt.Sprintf("public func %sIs%s(_ value: %s) -> Bool {\n", prefix, name, valueType))
+			code.WriteString(fmt.Sprintf("    return %s%s.contains(value)\n", prefix, name))
+			code.WriteString("}\n")
+		}
+	}
 	
 	return code.String()
 }
 
 // generateGroup 生成常量组
 func (g *SwiftGenerator) generateGroup(group *parser.ConstantGroup, projectLabel string) string {
-	// 所有类型都使用enum形式
-	if len(group.Constants) > 0 {
+	// 位标志组使用OptionSet形式，支持按位组合
+	if group.Flags {
+		return g.generateOptionSetGroup(group)
+	}
+
+	// 记录组使用struct形式，每个常量是一个静态实例
+	if group.Record != nil {
+		return g.generateRecordStruct(group)
+	}
+
+	// 集合值常量组使用没有case的enum作为命名空间
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionEnum(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexEnum(group)
+	}
+
+	// 整数、浮点和字符串类型使用enum形式
+	if len(group.Constants) > 0 && group.Constants[0].Type != "bool" {
 		return g.generateEnumGroup(group, projectLabel)
 	}
 
-	// 字符串类型仍使用struct形式（作为备份，但实际不会使用）
+	// 布尔类型不能作为enum的原始值，使用struct形式
 	return g.generateStructGroup(group, projectLabel)
 }
 
-// generateEnumGroup 生成enum形式的常量组（适用于整数和字符串类型）
+// generateRecordStruct 生成记录组的结构体：每个常量是一个静态实例，别名指向同一个实例，
+// 并提供按键名和按主值查找的静态方法
+func (g *SwiftGenerator) generateRecordStruct(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	structName := parser.ToJavaName(group.Name)
+	fields := recordFields(group)
+	primary := fields[0]
+	primaryType := parser.GetSwiftType(primary.Type)
+	byPrimaryName := "by" + parser.ToSwiftName(primary.Name)
+
+	code.WriteString(fmt.Sprintf("/// %s\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("public struct %s: Hashable {\n", structName))
+	code.WriteString("    /// 键名\n")
+	code.WriteString("    public let key: String\n")
+	code.WriteString("    /// 标签\n")
+	code.WriteString("    public let label: String\n")
+	for _, field := range fields {
+		code.WriteString(fmt.Sprintf("    /// %s\n", commentText(attributeLabel(field))))
+		code.WriteString(fmt.Sprintf("    public let %s: %s\n", escapeSwiftKeyword(toCamelCase(field.Name)), parser.GetSwiftType(field.Type)))
+	}
+	code.WriteString("\n")
+
+	for _, constant := range expandAliases(group.Constants) {
+		value := escapeSwiftKeyword(parser.ToSwiftName(constant.AliasOf))
+		if constant.AliasOf == "" {
+			args := []string{
+				"key: " + parser.FormatValue(constant.Name, "string", "swift"),
+				"label: " + parser.FormatValue(constant.Label, "string", "swift"),
+			}
+			for _, field := range fields {
+				args = append(args, escapeSwiftKeyword(toCamelCase(field.Name))+": "+recordFieldValue(group, constant, field, field.Type, "swift"))
+			}
+			value = fmt.Sprintf("%s(%s)", structName, strings.Join(args, ", "))
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(swiftDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /// %s\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("    public static let %s = %s\n", escapeSwiftKeyword(parser.ToSwiftName(constant.Name)), value))
+	}
+
+	var records []string
+	for _, constant := range group.Constants {
+		records = append(records, escapeSwiftKeyword(parser.ToSwiftName(constant.Name)))
+	}
+	code.WriteString(fmt.Sprintf("\n    /// 所有%s，按源文件顺序排列\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("    public static let allRecords: [%s] = [%s]\n\n", structName, strings.Join(records, ", ")))
+
+	code.WriteString(fmt.Sprintf("    private static let byKey: [String: %s] = [\n", structName))
+	for _, constant := range expandAliases(group.Constants) {
+		code.WriteString(fmt.Sprintf("        %s: %s,\n", parser.FormatValue(constant.Name, "string", "swift"),
+			escapeSwiftKeyword(parser.ToSwiftName(constant.Name))))
+	}
+	code.WriteString("    ]\n\n")
+
+	code.WriteString(fmt.Sprintf("    private static let %s: [%s: %s] = Dictionary(uniqueKeysWithValues: allRecords.map { ($0.%s, $0) })\n\n",
+		byPrimaryName, primaryType, structName, escapeSwiftKeyword(toCamelCase(primary.Name))))
+
+	code.WriteString(fmt.Sprintf("    /// 按键名（含别名）查找%s，不存在时返回nil\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("    public static func fromKey(_ key: String) -> %s? {\n", structName))
+	code.WriteString("        return byKey[key]\n")
+	code.WriteString("    }\n\n")
+
+	code.WriteString(fmt.Sprintf("    /// 按%s查找%s，不存在时返回nil\n", commentText(attributeLabel(primary)), commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("    public static func from%s(_ value: %s) -> %s? {\n", parser.ToSwiftName(primary.Name), primaryType, structName))
+	code.WriteString(fmt.Sprintf("        return %s[value]\n", byPrimaryName))
+	code.WriteString("    }\n")
+
+	// 按语言查询标签，标签表按主值索引
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.swiftLocaleTable(group, "    ", "private static let localeLabels", primaryType, group.Constants,
+			func(constant *parser.Constant) string {
+				return recordFieldValue(group, constant, primary, primary.Type, "swift")
+			}))
+		code.WriteString(fmt.Sprintf("\n    /// 获取%s在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言\n", commentText(group.Label)))
+		code.WriteString("    public func localizedLabel(_ locale: String) -> String {\n")
+		code.WriteString(g.swiftLocaleLookup("        ", "Self.localeLabels"))
+		code.WriteString(fmt.Sprintf("        return labels[%s] ?? label\n", escapeSwiftKeyword(toCamelCase(primary.Name))))
+		code.WriteString("    }\n")
+	}
+	code.WriteString(swiftTemplateFuncs(group, "    ", "public static func format"))
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateCollectionEnum 生成集合值常量组：没有case的enum作为命名空间，每个常量是一个静态的数组或字典，
+// Swift的集合是值类型，let常量不可修改
+func (g *SwiftGenerator) generateCollectionEnum(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("/// %s\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("public enum %s {\n", parser.ToJavaName(group.Name)))
+	for i, constant := range expandAliases(group.Constants) {
+		kind, elementType, _ := parser.CollectionType(constant.Type)
+		valueType := fmt.Sprintf("[%s]", parser.GetSwiftType(elementType))
+		items := collectionItems(constant, "swift", "")
+		if kind == "map" {
+			valueType = fmt.Sprintf("[String: %s]", parser.GetSwiftType(elementType))
+			items = collectionItems(constant, "swift", ": ")
+		}
+		value := "[" + strings.Join(items, ", ") + "]"
+		if kind == "map" && len(items) == 0 {
+			value = "[:]"
+		}
+
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(swiftDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /// %s\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("    public static let %s: %s = %s\n", escapeSwiftKeyword(parser.ToSwiftName(constant.Name)), valueType, value))
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateRegexEnum 生成正则常量组：没有case的enum作为命名空间，每个常量是一个预编译的 NSRegularExpression；
+// 表达式已在生成时校验过语法，因此用 try! 创建
+func (g *SwiftGenerator) generateRegexEnum(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("/// %s\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("public enum %s {\n", parser.ToJavaName(group.Name)))
+	for i, constant := range expandAliases(group.Constants) {
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(swiftDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /// %s\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("    public static let %s: NSRegularExpression = try! NSRegularExpression(pattern: %s)\n",
+			escapeSwiftKeyword(parser.ToSwiftName(constant.Name)), regexLiteral(constant.Value.(string), "swift")))
+	}
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateEnumGroup 生成enum形式的常量组（适用于整数、浮点和字符串类型）
 func (g *SwiftGenerator) generateEnumGroup(group *parser.ConstantGroup, _ string) string {
 	var code strings.Builder
 
 	enumName := parser.ToJavaName(group.Name)
 
-	// 判断类型
-	isIntType := len(group.Constants) > 0 && group.Constants[0].Type == "int"
-	rawType := "String"
-	if isIntType {
-		rawType = "Int"
-	}
+	// 原始值类型
+	rawType := parser.GetSwiftType(group.Constants[0].Type)
 
 	// 枚举定义
 	code.WriteString(fmt.Sprintf("public enum %s: %s, CaseIterable, Codable, Identifiable, CustomStringConvertible {\n", enumName, rawType))
@@ -142,32 +549,39 @@ func (g *SwiftGenerator) generateEnumGroup(group *parser.ConstantGroup, _ string
 		return strings.ToUpper(constants[i].Name) < strings.ToUpper(constants[j].Name)
 	})
 
-	// 枚举case定义
-	for _, constant := range constants {
-		caseName := parser.ToSwiftName(constant.Name)
+	// 原始值不能重复：同值的常量只有一个作为case（优先未废弃的常量），其余常量和别名以静态属性指向该case
+	caseOf := make(map[string]*parser.Constant)
+	for _, constant := range canonicalConstants(group) {
+		caseOf[fmt.Sprint(constant.Value)] = constant
+	}
+	caseName := func(constant *parser.Constant) string {
 		// 处理 Swift 关键字
-		caseName = escapeSwiftKeyword(caseName)
-		value := constant.Value
-		comment := constant.Label
+		return escapeSwiftKeyword(parser.ToSwiftName(caseOf[fmt.Sprint(constant.Value)].Name))
+	}
+
+	// 枚举case定义
+	for _, constant := range expandAliases(constants) {
+		name := escapeSwiftKeyword(parser.ToSwiftName(constant.Name))
+		value := parser.FormatConstantValue(constant, constant.Type, "swift")
+		comment := commentText(constant.Label)
 		if comment == "" {
 			comment = constant.Label
 		}
 
-		code.WriteString(fmt.Sprintf("    /// %s\n", comment))
-		if isIntType {
-			code.WriteString(fmt.Sprintf("    case %s = %s\n", caseName, value))
+		if hasDocDetails(constant) {
+			code.WriteString(swiftDoc(constant, "    "))
 		} else {
-			// 字符串类型需要加引号
-			code.WriteString(fmt.Sprintf("    case %s = \"%s\"\n", caseName, value))
+			code.WriteString(fmt.Sprintf("    /// %s\n", comment))
 		}
+		if caseOf[fmt.Sprint(constant.Value)] != constant {
+			code.WriteString(fmt.Sprintf("    public static let %s: %s = .%s\n", name, enumName, caseName(constant)))
+			continue
+		}
+		code.WriteString(fmt.Sprintf("    case %s = %s\n", name, value))
 	}
 
 	// 添加Identifiable协议的实现
-	if isIntType {
-		code.WriteString("\n    public var id: Int { rawValue }\n")
-	} else {
-		code.WriteString("\n    public var id: String { rawValue }\n")
-	}
+	code.WriteString(fmt.Sprintf("\n    public var id: %s { rawValue }\n", rawType))
 
 	// 添加CustomStringConvertible协议的实现
 	code.WriteString("    \n")
@@ -181,17 +595,28 @@ func (g *SwiftGenerator) generateEnumGroup(group *parser.ConstantGroup, _ string
 	code.WriteString("    public var label: String {\n")
 	code.WriteString("        switch self {\n")
 	for _, constant := range constants {
-		caseName := parser.ToSwiftName(constant.Name)
-		caseName = escapeSwiftKeyword(caseName)
+		if caseOf[fmt.Sprint(constant.Value)] != constant {
+			continue
+		}
 		label := constant.Label
 		if label == "" {
 			label = constant.Name
 		}
-		code.WriteString(fmt.Sprintf("        case .%s: return \"%s\"\n", caseName, label))
+		code.WriteString(fmt.Sprintf("        case .%s: return %s\n", caseName(constant), parser.FormatValue(label, "string", "swift")))
 	}
 	code.WriteString("        }\n")
 	code.WriteString("    }\n")
 
+	// 附加属性
+	for _, attribute := range group.Attributes {
+		code.WriteString(fmt.Sprintf("\n    /// %s\n", commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("    public var %s: %s {\n", escapeSwiftKeyword(toCamelCase(attribute.Name)), parser.GetSwiftType(attribute.Type)))
+		code.WriteString(swiftAttributeSwitch(canonicalConstants(group), attribute, "    ", "self", false, func(constant *parser.Constant) string {
+			return "." + caseName(constant)
+		}))
+		code.WriteString("    }\n")
+	}
+
 
 
 	// 生成从字符串创建的静态方法
@@ -201,17 +626,200 @@ func (g *SwiftGenerator) generateEnumGroup(group *parser.ConstantGroup, _ string
 	code.WriteString("    /// - Returns: 枚举值，找不到时返回nil\n")
 	code.WriteString("    public static func fromString(_ key: String) -> Self? {\n")
 	code.WriteString("        switch key {\n")
-	for _, constant := range constants {
-		caseName := parser.ToSwiftName(constant.Name)
-		caseName = escapeSwiftKeyword(caseName)
-		// 对于fromString，仍使用原始的键名（可能是snake_case等）
+	for _, constant := range expandAliases(constants) {
+		// 对于fromString，仍使用原始的键名（可能是snake_case等）；别名和同值常量返回对应的case
 		originalKey := constant.Name
-		code.WriteString(fmt.Sprintf("        case \"%s\": return .%s\n", originalKey, caseName))
+		code.WriteString(fmt.Sprintf("        case \"%s\": return .%s\n", originalKey, caseName(constant)))
 	}
 	code.WriteString("        default: return nil\n")
 	code.WriteString("        }\n")
 	code.WriteString("    }\n")
 
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.swiftLocaleTable(group, "    ", "private static let localeLabels", enumName, canonicalConstants(group),
+			func(constant *parser.Constant) string {
+				return "." + caseName(constant)
+			}))
+		code.WriteString("\n    /// 获取指定语言（如 en、en-US）下的标签，语言未知时使用默认语言\n")
+		code.WriteString("    public func localizedLabel(_ locale: String) -> String {\n")
+		code.WriteString(g.swiftLocaleLookup("        ", "Self.localeLabels"))
+		code.WriteString("        return labels[self] ?? label\n")
+		code.WriteString("    }\n")
+	}
+
+	// 树形常量组的父子关系
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n")
+		code.WriteString(swiftTreeTables(group, "    ", "private static let", "", enumName, func(constant *parser.Constant) string {
+			return "." + caseName(constant)
+		}))
+		code.WriteString("\n    /// 父节点，根节点为nil\n")
+		code.WriteString(fmt.Sprintf("    public var parent: %s? {\n", enumName))
+		code.WriteString("        return Self.parentTable[self]\n")
+		code.WriteString("    }\n")
+		code.WriteString("\n    /// 直接子节点，按定义顺序排列\n")
+		code.WriteString(fmt.Sprintf("    public var children: [%s] {\n", enumName))
+		code.WriteString("        return Self.childTable[self] ?? []\n")
+		code.WriteString("    }\n")
+		code.WriteString("\n    /// 全部祖先节点，从父节点到根节点排列\n")
+		code.WriteString(fmt.Sprintf("    public var ancestors: [%s] {\n", enumName))
+		code.WriteString("        return Array(sequence(first: self) { $0.parent }.dropFirst())\n")
+		code.WriteString("    }\n")
+		code.WriteString("\n    /// 判断是否为指定节点的后代节点（不含自身）\n")
+		code.WriteString(fmt.Sprintf("    public func isDescendant(of ancestor: %s) -> Bool {\n", enumName))
+		code.WriteString("        return ancestors.contains(ancestor)\n")
+		code.WriteString("    }\n")
+	}
+
+	// 状态机组的状态转换
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n")
+		code.WriteString(swiftStateTable(group, "    ", "private static let transitionTable", enumName, func(constant *parser.Constant) string {
+			return "." + caseName(constant)
+		}))
+		code.WriteString("\n    /// 允许转换到的目标状态，终止状态为空数组\n")
+		code.WriteString(fmt.Sprintf("    public var nextStates: [%s] {\n", enumName))
+		code.WriteString("        return Self.transitionTable[self] ?? []\n")
+		code.WriteString("    }\n")
+		code.WriteString("\n    /// 是否为终止状态（没有可转换到的目标状态）\n")
+		code.WriteString("    public var isTerminal: Bool {\n")
+		code.WriteString("        return nextStates.isEmpty\n")
+		code.WriteString("    }\n")
+		code.WriteString("\n    /// 判断能否转换到指定状态\n")
+		code.WriteString(fmt.Sprintf("    public func canTransition(to next: %s) -> Bool {\n", enumName))
+		code.WriteString("        return nextStates.contains(next)\n")
+		code.WriteString("    }\n")
+	}
+
+	// 命名子集
+	for _, subset := range group.Subsets {
+		name := parser.ToSwiftName(subset.Name)
+		code.WriteString(fmt.Sprintf("\n    /// 子集：%s\n", commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("    public static let %s: Set<%s> = [%s]\n", escapeSwiftKeyword(name), enumName,
+			subsetKeys(group, subset, func(constant *parser.Constant) string {
+				return "." + caseName(constant)
+			})))
+		code.WriteString(fmt.Sprintf("\n    /// 是否属于子集“%s”\n", commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("    public var is%s: Bool {\n", parser.ToJavaName(subset.Name)))
+		code.WriteString(fmt.Sprintf("        return Self.%s.contains(self)\n", escapeSwiftKeyword(name)))
+		code.WriteString("    }\n")
+	}
+	code.WriteString(swiftTemplateFuncs(group, "    ", "public static func format"))
+
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// generateOptionSetGroup 生成OptionSet形式的位标志组
+func (g *SwiftGenerator) generateOptionSetGroup(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	structName := parser.ToJavaName(group.Name)
+	rawType := parser.GetSwiftType(group.Constants[0].Type)
+	constants := flagConstants(group)
+
+	code.WriteString(fmt.Sprintf("/// %s（位标志，可按位组合）\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("public struct %s: OptionSet, Hashable, CustomStringConvertible {\n", structName))
+	code.WriteString(fmt.Sprintf("    public let rawValue: %s\n\n", rawType))
+	code.WriteString(fmt.Sprintf("    public init(rawValue: %s) {\n", rawType))
+	code.WriteString("        self.rawValue = rawValue\n")
+	code.WriteString("    }\n\n")
+
+	var names []string
+	for _, constant := range constants {
+		names = append(names, escapeSwiftKeyword(parser.ToSwiftName(constant.Name)))
+	}
+
+	// 标志定义（按位从低到高排列），别名与对应标志同值
+	for _, constant := range expandAliases(constants) {
+		name := escapeSwiftKeyword(parser.ToSwiftName(constant.Name))
+		if hasDocDetails(constant) {
+			code.WriteString(swiftDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /// %s\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("    public static let %s = %s(rawValue: %s)\n", name, structName,
+			parser.FormatConstantValue(constant, constant.Type, "swift")))
+	}
+
+	code.WriteString("\n    /// 所有已知标志的组合\n")
+	code.WriteString(fmt.Sprintf("    public static let all: %s = [.%s]\n\n", structName, strings.Join(names, ", .")))
+
+	code.WriteString("    /// 各标志及其标签，按位从低到高排列\n")
+	code.WriteString(fmt.Sprintf("    private static let labels: [(flag: %s, label: String)] = [\n", structName))
+	for i, constant := range constants {
+		code.WriteString(fmt.Sprintf("        (.%s, %s),\n", names[i], parser.FormatValue(constantLabel(constant), "string", "swift")))
+	}
+	code.WriteString("    ]\n\n")
+
+	code.WriteString("    /// 判断是否包含指定的全部标志\n")
+	code.WriteString(fmt.Sprintf("    public func has(_ flag: %s) -> Bool {\n", structName))
+	code.WriteString("        contains(flag)\n")
+	code.WriteString("    }\n\n")
+
+	code.WriteString("    /// 返回添加指定标志后的值\n")
+	code.WriteString(fmt.Sprintf("    public func adding(_ flag: %s) -> %s {\n", structName, structName))
+	code.WriteString("        union(flag)\n")
+	code.WriteString("    }\n\n")
+
+	code.WriteString("    /// 返回移除指定标志后的值\n")
+	code.WriteString(fmt.Sprintf("    public func removing(_ flag: %s) -> %s {\n", structName, structName))
+	code.WriteString("        subtracting(flag)\n")
+	code.WriteString("    }\n\n")
+
+	code.WriteString("    /// 拆分为包含的各个标志，按位从低到高排列\n")
+	code.WriteString(fmt.Sprintf("    public func toList() -> [%s] {\n", structName))
+	code.WriteString("        Self.labels.filter { contains($0.flag) }.map { $0.flag }\n")
+	code.WriteString("    }\n\n")
+
+	code.WriteString("    /// 格式化为以|分隔的标签，如 读|写；包含未知位时返回 Unknown(值)\n")
+	code.WriteString("    public func format() -> String {\n")
+	code.WriteString("        guard Self.isValid(rawValue) else {\n")
+	code.WriteString(`            return "Unknown(\(rawValue))"`)
+	code.WriteString("\n        }\n")
+	code.WriteString(`        return Self.labels.filter { contains($0.flag) }.map { $0.label }.joined(separator: "|")`)
+	code.WriteString("\n    }\n\n")
+
+	code.WriteString("    public var description: String {\n")
+	code.WriteString("        format()\n")
+	code.WriteString("    }\n\n")
+
+	code.WriteString("    /// 验证值是否为已知标志的任意组合\n")
+	code.WriteString(fmt.Sprintf("    public static func isValid(_ value: %s) -> Bool {\n", rawType))
+	code.WriteString("        value & ~all.rawValue == 0\n")
+	code.WriteString("    }\n")
+
+	// 附加属性
+	for _, attribute := range group.Attributes {
+		code.WriteString(fmt.Sprintf("\n    /// 单个标志的%s，组合值和未知值返回默认值\n", commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("    public var %s: %s {\n", escapeSwiftKeyword(toCamelCase(attribute.Name)), parser.GetSwiftType(attribute.Type)))
+		code.WriteString(swiftAttributeSwitch(constants, attribute, "    ", "self", true, func(constant *parser.Constant) string {
+			return "." + escapeSwiftKeyword(parser.ToSwiftName(constant.Name))
+		}))
+		code.WriteString("    }\n")
+	}
+
+	// 按语言格式化
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.swiftLocaleTable(group, "    ", "private static let localeLabels", structName, constants,
+			func(constant *parser.Constant) string {
+				return "." + escapeSwiftKeyword(parser.ToSwiftName(constant.Name))
+			}))
+		code.WriteString("\n    /// 按指定语言（如 en、en-US）格式化为以|分隔的标签，语言未知时使用默认语言；包含未知位时返回 Unknown(值)\n")
+		code.WriteString("    public func format(locale: String) -> String {\n")
+		code.WriteString("        guard Self.isValid(rawValue) else {\n")
+		code.WriteString(`            return "Unknown(\(rawValue))"`)
+		code.WriteString("\n        }\n")
+		code.WriteString(g.swiftLocaleLookup("        ", "Self.localeLabels"))
+		code.WriteString(`        return Self.labels.filter { contains($0.flag) }.map { labels[$0.flag] ?? $0.label }.joined(separator: "|")`)
+		code.WriteString("\n    }\n")
+	}
+	code.WriteString(swiftTemplateFuncs(group, "    ", "public static func format"))
+
 	code.WriteString("}\n")
 
 	return code.String()
@@ -227,8 +835,7 @@ func (g *SwiftGenerator) generateStructGroup(group *parser.ConstantGroup, _ stri
 	code.WriteString(fmt.Sprintf("public struct %s {\n", structName))
 
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToSwiftName(constants[i].Name) < parser.ToSwiftName(constants[j].Name)
 	})
@@ -237,13 +844,17 @@ func (g *SwiftGenerator) generateStructGroup(group *parser.ConstantGroup, _ stri
 	for _, constant := range constants {
 		constName := parser.ToSwiftName(constant.Name)
 		swiftType := parser.GetSwiftType(constant.Type)
-		value := parser.FormatValue(constant.Value, constant.Type, "swift")
-		comment := constant.Label
+		value := constantValue(group, constant, "swift", "class")
+		comment := commentText(constant.Label)
 		if comment == "" {
 			comment = constant.Label
 		}
 
-		code.WriteString(fmt.Sprintf("    /// %s\n", comment))
+		if hasDocDetails(constant) {
+			code.WriteString(swiftDoc(constant, "    "))
+		} else {
+			code.WriteString(fmt.Sprintf("    /// %s\n", comment))
+		}
 		code.WriteString(fmt.Sprintf("    public static let %s: %s = %s\n", constName, swiftType, value))
 	}
 
@@ -265,6 +876,33 @@ func (g *SwiftGenerator) generateStructGroup(group *parser.ConstantGroup, _ stri
 	code.WriteString(g.generateIsValid(group))
 	code.WriteString("\n")
 	code.WriteString(g.generateFromString(group))
+
+	// 附加属性的查询方法
+	for _, attribute := range group.Attributes {
+		code.WriteString(fmt.Sprintf("\n    /// 获取值对应的%s，未知值返回默认值\n", commentText(attributeLabel(attribute))))
+		code.WriteString(fmt.Sprintf("    public static func %s(_ value: %s) -> %s {\n", escapeSwiftKeyword(toCamelCase(attribute.Name)),
+			parser.GetSwiftType(group.Constants[0].Type), parser.GetSwiftType(attribute.Type)))
+		code.WriteString(swiftAttributeSwitch(canonicalConstants(group), attribute, "    ", "value", true, func(constant *parser.Constant) string {
+			return parser.ToSwiftName(constant.Name)
+		}))
+		code.WriteString("    }\n")
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		valueType := parser.GetSwiftType(group.Constants[0].Type)
+		code.WriteString("\n")
+		code.WriteString(g.swiftLocaleTable(group, "    ", "private static let localeLabels", valueType, canonicalConstants(group),
+			func(constant *parser.Constant) string {
+				return parser.ToSwiftName(constant.Name)
+			}))
+		code.WriteString("\n    /// 获取值在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言，未知值返回nil\n")
+		code.WriteString(fmt.Sprintf("    public static func localizedLabel(_ value: %s, locale: String) -> String? {\n", valueType))
+		code.WriteString(g.swiftLocaleLookup("        ", "localeLabels"))
+		code.WriteString("        return labels[value]\n")
+		code.WriteString("    }\n")
+	}
+	code.WriteString(swiftTemplateFuncs(group, "    ", "public static func format"))
 	
 
 	code.WriteString("}\n")
@@ -341,8 +979,7 @@ func (g *SwiftGenerator) generateGetKeyValuePairs(group *parser.ConstantGroup) s
 	code.WriteString("        return [\n")
 
 	// 按字母顺序排序
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToSwiftName(constants[i].Name) < parser.ToSwiftName(constants[j].Name)
 	})
@@ -371,13 +1008,13 @@ func (g *SwiftGenerator) generateFormat(group *parser.ConstantGroup) string {
 	code.WriteString(fmt.Sprintf(`    public static func format(_ value: %s) -> String {`, swiftType))
 	code.WriteString("\n")
 	code.WriteString(fmt.Sprintf("        let labels: [%s: String] = [\n", swiftType))
-	for _, constant := range group.Constants {
+	for _, constant := range canonicalConstants(group) {
 		constName := parser.ToSwiftName(constant.Name)
 		label := constant.Label
 		if label == "" {
 			label = constant.Name
 		}
-		code.WriteString(fmt.Sprintf(`            %s: "%s",`, constName, label))
+		code.WriteString(fmt.Sprintf(`            %s: %s,`, constName, parser.FormatValue(label, "string", "swift")))
 		code.WriteString("\n")
 	}
 	code.WriteString("        ]\n")
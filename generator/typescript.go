@@ -2,7 +2,6 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -47,20 +46,29 @@ func (g *TypeScriptGenerator) Generate(constants *parser.ConstantsFile) error {
 	}
 	
 	// 写入文件
-	outputPath := g.GetOutputFilePath(constants.FileName)
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
+	outputPath := g.GetOutputFilePath(constants)
+	return WriteOutputFile(outputPath, code.String())
 }
 
 // generateConstGroup 生成const模式的常量组
 func (g *TypeScriptGenerator) generateConstGroup(group *parser.ConstantGroup, projectLabel string) string {
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionObject(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexObject(group)
+	}
+
 	var code strings.Builder
 	
 	// 生成注释
-	code.WriteString(fmt.Sprintf("// %s %s - %s\n", group.Name, group.Label, projectLabel))
+	code.WriteString(fmt.Sprintf("// %s %s - %s\n", group.Name, commentText(group.Label), commentText(projectLabel)))
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToTypeScriptName(constants[i].Name) < parser.ToTypeScriptName(constants[j].Name)
 	})
@@ -68,23 +76,81 @@ func (g *TypeScriptGenerator) generateConstGroup(group *parser.ConstantGroup, pr
 	// 生成常量定义
 	for _, constant := range constants {
 		constName := fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
-		value := parser.FormatValue(constant.Value, constant.Type, "typescript")
-		comment := constant.Label
+		value := parser.FormatConstantValue(constant, jsDataType(group), "typescript")
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, ""))
+			code.WriteString(fmt.Sprintf("export const %s = %s;\n", constName, value))
+			continue
+		}
+		comment := commentText(constant.Label)
 		code.WriteString(fmt.Sprintf("export const %s = %s; // %s\n", constName, value, comment))
 	}
+
+	// 附加属性的查询表
+	for _, attribute := range group.Attributes {
+		mapName := strings.ToUpper(group.Name) + "_" + strings.ToUpper(attribute.Name)
+		code.WriteString("\n")
+		code.WriteString(tsAttributeMap(group, attribute, mapName, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		code.WriteString("\n")
+		code.WriteString(g.tsLocalizedLabelFunc(group, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
+
+	// 标签模板的格式化函数
+	code.WriteString(tsTemplateFuncs(group))
+
+	// 树形常量组的父子关系查询函数
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n")
+		code.WriteString(tsTreeFuncs(group, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
+
+	// 状态机组的状态转换函数
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n")
+		code.WriteString(tsStateFuncs(group, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
+
+	// 命名子集的数组、类型及判断函数
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n")
+		code.WriteString(tsSubsetFuncs(group, func(constant *parser.Constant) string {
+			return fmt.Sprintf("%s_%s", strings.ToUpper(group.Name), strings.ToUpper(constant.Name))
+		}))
+	}
 	
 	return code.String()
 }
 
 // generateGroupClass 生成常量组（简化格式）
 func (g *TypeScriptGenerator) generateGroupClass(group *parser.ConstantGroup, _ string) string {
+	if group.Record != nil {
+		return g.generateRecordClass(group)
+	}
+	if parser.IsCollectionGroup(group) {
+		return g.generateCollectionObject(group)
+	}
+	if parser.IsRegexGroup(group) {
+		return g.generateRegexObject(group)
+	}
+
 	var code strings.Builder
 	
 	className := parser.ToJavaName(group.Name)
 	
 	// 按字母顺序排序常量
-	constants := make([]*parser.Constant, len(group.Constants))
-	copy(constants, group.Constants)
+	constants := expandAliases(group.Constants)
 	sort.Slice(constants, func(i, j int) bool {
 		return parser.ToTypeScriptName(constants[i].Name) < parser.ToTypeScriptName(constants[j].Name)
 	})
@@ -95,12 +161,16 @@ func (g *TypeScriptGenerator) generateGroupClass(group *parser.ConstantGroup, _
 	// 生成常量值
 	for _, constant := range constants {
 		fieldName := strings.ToUpper(constant.Name)
-		value := parser.FormatValue(constant.Value, constant.Type, "typescript")
-		comment := constant.Label
+		value := parser.FormatConstantValue(constant, jsDataType(group), "typescript")
+		comment := commentText(constant.Label)
 		if comment == "" {
 			comment = constant.Name
 		}
-		code.WriteString(fmt.Sprintf("  /** %s */\n", comment))
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, "  "))
+		} else {
+			code.WriteString(fmt.Sprintf("  /** %s */\n", comment))
+		}
 		code.WriteString(fmt.Sprintf("  %s: %s,\n", fieldName, value))
 	}
 	
@@ -109,14 +179,575 @@ func (g *TypeScriptGenerator) generateGroupClass(group *parser.ConstantGroup, _
 	// 生成类型定义
 	code.WriteString(fmt.Sprintf("export type %sValue = typeof %s[keyof typeof %s];\n", className, className, className))
 	code.WriteString(fmt.Sprintf("export type %sKey = keyof typeof %s;", className, className))
+
+	if group.Flags {
+		code.WriteString("\n\n")
+		code.WriteString(g.generateFlagsHelper(group))
+	}
+
+	// 附加属性的查询表
+	for _, attribute := range group.Attributes {
+		code.WriteString("\n\n")
+		code.WriteString(strings.TrimSuffix(tsAttributeMap(group, attribute, className+parser.ToJavaName(attribute.Name),
+			func(constant *parser.Constant) string {
+				return className + "." + strings.ToUpper(constant.Name)
+			}), "\n"))
+	}
+
+	// 按语言查询标签
+	if hasGroupLocales(group) {
+		code.WriteString("\n\n")
+		code.WriteString(strings.TrimSuffix(g.tsLocalizedLabelFunc(group, func(constant *parser.Constant) string {
+			return className + "." + strings.ToUpper(constant.Name)
+		}), "\n"))
+	}
+
+	// 标签模板的格式化函数
+	if funcs := tsTemplateFuncs(group); funcs != "" {
+		code.WriteString("\n" + strings.TrimSuffix(funcs, "\n"))
+	}
+
+	// 树形常量组的父子关系查询函数
+	if parser.IsTreeGroup(group) {
+		code.WriteString("\n\n")
+		code.WriteString(strings.TrimSuffix(tsTreeFuncs(group, func(constant *parser.Constant) string {
+			return className + "." + strings.ToUpper(constant.Name)
+		}), "\n"))
+	}
+
+	// 状态机组的状态转换函数
+	if parser.IsStateMachine(group) {
+		code.WriteString("\n\n")
+		code.WriteString(strings.TrimSuffix(tsStateFuncs(group, func(constant *parser.Constant) string {
+			return className + "." + strings.ToUpper(constant.Name)
+		}), "\n"))
+	}
+
+	// 命名子集的数组、类型及判断函数
+	if len(group.Subsets) > 0 {
+		code.WriteString("\n\n")
+		code.WriteString(strings.TrimSuffix(tsSubsetFuncs(group, func(constant *parser.Constant) string {
+			return className + "." + strings.ToUpper(constant.Name)
+		}), "\n"))
+	}
 	
 	return code.String()
 }
 
+// generateFlagsHelper 生成位标志组的组合操作对象（has/add/remove/toList/format/isValid）
+func (g *TypeScriptGenerator) generateFlagsHelper(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	labelsName := toCamelCase(group.Name) + "Labels"
+	dataType := jsDataType(group)
+	tsType := parser.GetTypeScriptType(dataType)
+	zero := parser.FormatValue(int64(0), dataType, "typescript")
+
+	code.WriteString(fmt.Sprintf("/** %s各标志及其标签，按位从低到高排列 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("const %s: ReadonlyArray<readonly [%sValue, string]> = [\n", labelsName, className))
+	for _, constant := range flagConstants(group) {
+		code.WriteString(fmt.Sprintf("  [%s.%s, %s],\n", className, strings.ToUpper(constant.Name),
+			parser.FormatValue(constantLabel(constant), "string", "typescript")))
+	}
+	code.WriteString("];\n\n")
+
+	code.WriteString(fmt.Sprintf("/** %s（位标志）的组合操作 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("export const %sFlags = {\n", className))
+	code.WriteString("  /** 所有已知标志的组合 */\n")
+	code.WriteString(fmt.Sprintf("  MASK: %s as %s,\n\n", parser.FormatValue(flagMask(group), dataType, "typescript"), tsType))
+
+	code.WriteString("  /** 判断组合值是否包含指定的全部标志 */\n")
+	code.WriteString(fmt.Sprintf("  has(mask: %s, flag: %s): boolean {\n", tsType, tsType))
+	code.WriteString("    return (mask & flag) === flag;\n")
+	code.WriteString("  },\n\n")
+
+	code.WriteString("  /** 返回添加指定标志后的组合值 */\n")
+	code.WriteString(fmt.Sprintf("  add(mask: %s, flag: %s): %s {\n", tsType, tsType, tsType))
+	code.WriteString("    return mask | flag;\n")
+	code.WriteString("  },\n\n")
+
+	code.WriteString("  /** 返回移除指定标志后的组合值 */\n")
+	code.WriteString(fmt.Sprintf("  remove(mask: %s, flag: %s): %s {\n", tsType, tsType, tsType))
+	code.WriteString("    return mask & ~flag;\n")
+	code.WriteString("  },\n\n")
+
+	code.WriteString("  /** 将组合值拆分为各个标志，按位从低到高排列 */\n")
+	code.WriteString(fmt.Sprintf("  toList(mask: %s): %sValue[] {\n", tsType, className))
+	code.WriteString(fmt.Sprintf("    return %s.filter(([flag]) => (mask & flag) === flag).map(([flag]) => flag);\n", labelsName))
+	code.WriteString("  },\n\n")
+
+	code.WriteString("  /** 格式化组合值，标签以|分隔，如 读|写；包含未知位时返回 Unknown(值) */\n")
+	code.WriteString(fmt.Sprintf("  format(mask: %s): string {\n", tsType))
+	code.WriteString(fmt.Sprintf("    if (!%sFlags.isValid(mask)) {\n", className))
+	code.WriteString("      return `Unknown(${mask})`;\n")
+	code.WriteString("    }\n")
+	code.WriteString(fmt.Sprintf("    return %s.filter(([flag]) => (mask & flag) === flag).map(([, label]) => label).join('|');\n", labelsName))
+	code.WriteString("  },\n\n")
+
+	code.WriteString("  /** 验证组合值是否只包含已知标志 */\n")
+	code.WriteString(fmt.Sprintf("  isValid(mask: %s): boolean {\n", tsType))
+	code.WriteString(fmt.Sprintf("    return (mask & ~%sFlags.MASK) === %s;\n", className, zero))
+	code.WriteString("  },\n")
+	code.WriteString("};")
+
+	return code.String()
+}
+
+
+
+// generateCollectionObject 生成集合值常量组的对象：列表生成为数组，映射生成为对象，整体以 as const 声明为深层只读，
+// 元素的类型收窄为字面量类型
+func (g *TypeScriptGenerator) generateCollectionObject(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("export const %s = {\n", parser.ToJavaName(group.Name)))
+	for _, constant := range expandAliases(group.Constants) {
+		// 空集合在 as const 中会被推断为 readonly []、{}，丢失元素类型，需显式断言
+		kind, elementType, _ := parser.CollectionType(constant.Type)
+		value := "[] as readonly " + parser.GetTypeScriptType(elementType) + "[]"
+		if items := collectionItems(constant, "typescript", ""); len(items) > 0 {
+			value = "[" + strings.Join(items, ", ") + "]"
+		}
+		if kind == "map" {
+			value = "{} as { readonly [key: string]: " + parser.GetTypeScriptType(elementType) + " }"
+			if items := collectionItems(constant, "typescript", ": "); len(items) > 0 {
+				value = "{ " + strings.Join(items, ", ") + " }"
+			}
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, "  "))
+		} else {
+			code.WriteString(fmt.Sprintf("  /** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("  %s: %s,\n", strings.ToUpper(constant.Name), value))
+	}
+	code.WriteString("} as const;\n")
+
+	return code.String()
+}
+
+// generateRegexObject 生成正则常量组的对象：每个常量是一个预编译的 RegExp，整体以 as const 声明为只读
+func (g *TypeScriptGenerator) generateRegexObject(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	code.WriteString(fmt.Sprintf("/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("export const %s = {\n", parser.ToJavaName(group.Name)))
+	for _, constant := range expandAliases(group.Constants) {
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, "  "))
+		} else {
+			code.WriteString(fmt.Sprintf("  /** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("  %s: new RegExp(%s),\n", strings.ToUpper(constant.Name),
+			regexLiteral(constant.Value.(string), "typescript")))
+	}
+	code.WriteString("} as const;\n")
+
+	return code.String()
+}
+
+// generateRecordClass 生成记录组的类：每个常量是一个冻结的只读实例，别名指向同一个实例，
+// 并提供按键名和按主值查找的静态方法
+func (g *TypeScriptGenerator) generateRecordClass(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	fields := recordFields(group)
+	primary := fields[0]
+	primaryType := parser.GetTypeScriptType(jsRecordFieldType(group, primary))
+	byPrimaryName := "by" + parser.ToJavaName(primary.Name)
+
+	code.WriteString(fmt.Sprintf("/** %s */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("export class %s {\n", className))
+	for _, constant := range expandAliases(group.Constants) {
+		value := className + "." + strings.ToUpper(constant.AliasOf)
+		if constant.AliasOf == "" {
+			args := []string{
+				parser.FormatValue(constant.Name, "string", "typescript"),
+				parser.FormatValue(constant.Label, "string", "typescript"),
+			}
+			for _, field := range fields {
+				args = append(args, recordFieldValue(group, constant, field, jsRecordFieldType(group, field), "typescript"))
+			}
+			value = fmt.Sprintf("new %s(%s)", className, strings.Join(args, ", "))
+		}
+		if hasDocDetails(constant) {
+			code.WriteString(tsDoc(constant, "  "))
+		} else {
+			code.WriteString(fmt.Sprintf("  /** %s */\n", commentText(constant.Label)))
+		}
+		code.WriteString(fmt.Sprintf("  static readonly %s = %s;\n", strings.ToUpper(constant.Name), value))
+	}
+
+	var records []string
+	for _, constant := range group.Constants {
+		records = append(records, className+"."+strings.ToUpper(constant.Name))
+	}
+	code.WriteString(fmt.Sprintf("\n  /** 所有%s，按源文件顺序排列 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("  static readonly allRecords: ReadonlyArray<%s> = [%s];\n\n", className, strings.Join(records, ", ")))
+
+	code.WriteString(fmt.Sprintf("  private static readonly byKey: ReadonlyMap<string, %s> = new Map<string, %s>([\n", className, className))
+	for _, constant := range expandAliases(group.Constants) {
+		code.WriteString(fmt.Sprintf("    [%s, %s.%s],\n", parser.FormatValue(constant.Name, "string", "typescript"),
+			className, strings.ToUpper(constant.Name)))
+	}
+	code.WriteString("  ]);\n\n")
+
+	code.WriteString(fmt.Sprintf("  private static readonly %s: ReadonlyMap<%s, %s> = new Map(\n", byPrimaryName, primaryType, className))
+	code.WriteString(fmt.Sprintf("    %s.allRecords.map((record): [%s, %s] => [record.%s, record]),\n", className, primaryType, className,
+		toCamelCase(primary.Name)))
+	code.WriteString("  );\n\n")
+
+	code.WriteString("  private constructor(\n")
+	code.WriteString("    /** 键名 */\n")
+	code.WriteString("    readonly key: string,\n")
+	code.WriteString("    /** 标签 */\n")
+	code.WriteString("    readonly label: string,\n")
+	for _, field := range fields {
+		code.WriteString(fmt.Sprintf("    /** %s */\n", commentText(attributeLabel(field))))
+		code.WriteString(fmt.Sprintf("    readonly %s: %s,\n", toCamelCase(field.Name), parser.GetTypeScriptType(jsRecordFieldType(group, field))))
+	}
+	code.WriteString("  ) {\n")
+	code.WriteString("    Object.freeze(this);\n")
+	code.WriteString("  }\n\n")
+
+	code.WriteString(fmt.Sprintf("  /** 按键名（含别名）查找%s，不存在时返回undefined */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("  static fromKey(key: string): %s | undefined {\n", className))
+	code.WriteString(fmt.Sprintf("    return %s.byKey.get(key);\n", className))
+	code.WriteString("  }\n\n")
+
+	code.WriteString(fmt.Sprintf("  /** 按%s查找%s，不存在时返回undefined */\n", commentText(attributeLabel(primary)), commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("  static from%s(value: %s): %s | undefined {\n", parser.ToJavaName(primary.Name), primaryType, className))
+	code.WriteString(fmt.Sprintf("    return %s.%s.get(value);\n", className, byPrimaryName))
+	code.WriteString("  }\n\n")
+
+	code.WriteString("  toString(): string {\n")
+	code.WriteString("    return this.key;\n")
+	code.WriteString("  }\n")
+
+	// 按语言查询标签，标签表按主值索引
+	if hasGroupLocales(group) {
+		tableName := className + ".localeLabels"
+		code.WriteString(fmt.Sprintf("\n  /** %s各语言的标签，缺少翻译的常量使用默认语言的标签 */\n", commentText(group.Label)))
+		code.WriteString(fmt.Sprintf("  private static readonly localeLabels: ReadonlyMap<string, ReadonlyMap<%s, string>> = %s;\n\n",
+			primaryType, jsLocaleTable(group, "  ", parser.LabelLocales(group, g.Config.DefaultLocale), group.Constants,
+				func(constant *parser.Constant) string {
+					return recordFieldValue(group, constant, primary, jsRecordFieldType(group, primary), "typescript")
+				}, "typescript", primaryType+", string")))
+		code.WriteString(fmt.Sprintf("  /** 获取%s在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言 */\n", commentText(group.Label)))
+		code.WriteString("  localizedLabel(locale: string): string {\n")
+		code.WriteString(fmt.Sprintf("    const labels = %s;\n", jsLocaleLookup(tableName, g.Config.DefaultLocale, "typescript")))
+		code.WriteString(fmt.Sprintf("    return labels.get(this.%s)!;\n", toCamelCase(primary.Name)))
+		code.WriteString("  }\n")
+	}
+	code.WriteString("}\n")
+	code.WriteString(tsTemplateFuncs(group))
+
+	return code.String()
+}
+
+// tsLocalizedLabelFunc 生成各语言的标签表和导出函数 get<组名>LocalizedLabel，keyExpr 返回常量作为键的表达式；
+// class模式下位标志组的函数借助组合操作对象按语言格式化组合值
+func (g *TypeScriptGenerator) tsLocalizedLabelFunc(group *parser.ConstantGroup, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	tableName := toCamelCase(group.Name) + "LocaleLabels"
+	tsType := parser.GetTypeScriptType(jsDataType(group))
+	flags := group.Flags && g.Config.Mode != "const"
+	constants := canonicalConstants(group)
+	if flags {
+		constants = flagConstants(group)
+	}
+
+	code.WriteString(fmt.Sprintf("/** %s各语言的标签，缺少翻译的常量使用默认语言的标签 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("const %s: ReadonlyMap<string, ReadonlyMap<%s, string>> = %s;\n\n", tableName, tsType,
+		jsLocaleTable(group, "", parser.LabelLocales(group, g.Config.DefaultLocale), constants, keyExpr, "typescript", tsType+", string")))
+
+	if flags {
+		code.WriteString("/** 按指定语言（如 en、en-US）格式化组合值，标签以|分隔，语言未知时使用默认语言；包含未知位时返回 Unknown(值) */\n")
+		code.WriteString(fmt.Sprintf("export function get%sLocalizedLabel(mask: %s, locale: string): string {\n", className, tsType))
+		code.WriteString(fmt.Sprintf("  if (!%sFlags.isValid(mask)) {\n", className))
+		code.WriteString("    return `Unknown(${mask})`;\n")
+		code.WriteString("  }\n")
+		code.WriteString(fmt.Sprintf("  const labels = %s;\n", jsLocaleLookup(tableName, g.Config.DefaultLocale, "typescript")))
+		code.WriteString(fmt.Sprintf("  return %sFlags.toList(mask).map((flag) => labels.get(flag)).join('|');\n", className))
+		code.WriteString("}\n")
+		return code.String()
+	}
+	code.WriteString("/** 获取值在指定语言（如 en、en-US）下的标签，语言未知时使用默认语言，未知值返回undefined */\n")
+	code.WriteString(fmt.Sprintf("export function get%sLocalizedLabel(value: %s, locale: string): string | undefined {\n", className, tsType))
+	code.WriteString(fmt.Sprintf("  const labels = %s;\n", jsLocaleLookup(tableName, g.Config.DefaultLocale, "typescript")))
+	code.WriteString("  return labels.get(value);\n")
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// tsTreeFuncs 生成树形常量组的父子关系表和导出函数 get<组名>Parent、get<组名>Children、get<组名>Ancestors、
+// is<组名>DescendantOf，keyExpr 返回常量作为键的表达式
+func tsTreeFuncs(group *parser.ConstantGroup, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	parentsName, childrenName := toCamelCase(group.Name)+"Parents", toCamelCase(group.Name)+"Children"
+	tsType := parser.GetTypeScriptType(jsDataType(group))
+
+	code.WriteString(fmt.Sprintf("/** %s中各常量的父常量 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("const %s: ReadonlyMap<%s, %s> = new Map<%s, %s>([\n", parentsName, tsType, tsType, tsType, tsType))
+	for _, constant := range group.Constants {
+		if parent := parentConstant(group, constant); parent != nil {
+			code.WriteString(fmt.Sprintf("  [%s, %s],\n", keyExpr(constant), keyExpr(parent)))
+		}
+	}
+	code.WriteString("]);\n\n")
+	code.WriteString(fmt.Sprintf("/** %s中各常量的直接子常量 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("const %s: ReadonlyMap<%s, readonly %s[]> = new Map<%s, readonly %s[]>([\n", childrenName,
+		tsType, tsType, tsType, tsType))
+	for _, constant := range group.Constants {
+		var children []string
+		for _, child := range childConstants(group, constant) {
+			children = append(children, keyExpr(child))
+		}
+		if len(children) > 0 {
+			code.WriteString(fmt.Sprintf("  [%s, [%s]],\n", keyExpr(constant), strings.Join(children, ", ")))
+		}
+	}
+	code.WriteString("]);\n\n")
+
+	code.WriteString("/** 获取值的父节点，根节点和未知值返回undefined */\n")
+	code.WriteString(fmt.Sprintf("export function get%sParent(value: %s): %s | undefined {\n", className, tsType, tsType))
+	code.WriteString(fmt.Sprintf("  return %s.get(value);\n", parentsName))
+	code.WriteString("}\n\n")
+	code.WriteString("/** 获取值的直接子节点，按定义顺序排列 */\n")
+	code.WriteString(fmt.Sprintf("export function get%sChildren(value: %s): %s[] {\n", className, tsType, tsType))
+	code.WriteString(fmt.Sprintf("  return [...(%s.get(value) || [])];\n", childrenName))
+	code.WriteString("}\n\n")
+	code.WriteString("/** 获取值的全部祖先节点，从父节点到根节点排列 */\n")
+	code.WriteString(fmt.Sprintf("export function get%sAncestors(value: %s): %s[] {\n", className, tsType, tsType))
+	code.WriteString(fmt.Sprintf("  const ancestors: %s[] = [];\n", tsType))
+	code.WriteString(fmt.Sprintf("  for (let parent = %s.get(value); parent !== undefined; parent = %s.get(parent)) {\n", parentsName, parentsName))
+	code.WriteString("    ancestors.push(parent);\n")
+	code.WriteString("  }\n")
+	code.WriteString("  return ancestors;\n")
+	code.WriteString("}\n\n")
+	code.WriteString("/** 判断值是否为 ancestor 的后代节点（不含自身） */\n")
+	code.WriteString(fmt.Sprintf("export function is%sDescendantOf(value: %s, ancestor: %s): boolean {\n", className, tsType, tsType))
+	code.WriteString(fmt.Sprintf("  return get%sAncestors(value).includes(ancestor);\n", className))
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// tsStateFuncs 生成状态机组的转换表和导出函数 can<组名>Transition、get<组名>NextStates、is<组名>Terminal，
+// keyExpr 返回常量作为键的表达式
+func tsStateFuncs(group *parser.ConstantGroup, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	tableName := toCamelCase(group.Name) + "Transitions"
+	tsType := parser.GetTypeScriptType(jsDataType(group))
+
+	code.WriteString(fmt.Sprintf("/** %s中各状态允许转换到的目标状态 */\n", commentText(group.Label)))
+	code.WriteString(fmt.Sprintf("const %s: ReadonlyMap<%s, readonly %s[]> = new Map<%s, readonly %s[]>([\n", tableName,
+		tsType, tsType, tsType, tsType))
+	for _, entry := range stateTableEntries(group, keyExpr) {
+		code.WriteString(fmt.Sprintf("  [%s, [%s]],\n", entry[0], entry[1]))
+	}
+	code.WriteString("]);\n\n")
+
+	code.WriteString("/** 判断能否从状态 from 转换到状态 to */\n")
+	code.WriteString(fmt.Sprintf("export function can%sTransition(from: %s, to: %s): boolean {\n", className, tsType, tsType))
+	code.WriteString(fmt.Sprintf("  return (%s.get(from) || []).includes(to);\n", tableName))
+	code.WriteString("}\n\n")
+	code.WriteString("/** 获取状态允许转换到的目标状态，终止状态和未知值返回空数组 */\n")
+	code.WriteString(fmt.Sprintf("export function get%sNextStates(from: %s): %s[] {\n", className, tsType, tsType))
+	code.WriteString(fmt.Sprintf("  return [...(%s.get(from) || [])];\n", tableName))
+	code.WriteString("}\n\n")
+	code.WriteString("/** 判断值是否为终止状态（没有可转换到的目标状态），未知值返回false */\n")
+	code.WriteString(fmt.Sprintf("export function is%sTerminal(value: %s): boolean {\n", className, tsType))
+	code.WriteString(fmt.Sprintf("  const next = %s.get(value);\n", tableName))
+	code.WriteString("  return next !== undefined && next.length === 0;\n")
+	code.WriteString("}\n")
+
+	return code.String()
+}
+
+// tsSubsetFuncs 生成命名子集的只读数组（如 ORDER_STATUS_ACTIVE）、成员的联合类型（如 OrderStatusActive）
+// 和类型守卫函数 is<组名><子集名>，keyExpr 返回成员的表达式
+func tsSubsetFuncs(group *parser.ConstantGroup, keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	className := parser.ToJavaName(group.Name)
+	tsType := parser.GetTypeScriptType(jsDataType(group))
+	for i, subset := range group.Subsets {
+		if i > 0 {
+			code.WriteString("\n")
+		}
+		arrayName := strings.ToUpper(group.Name) + "_" + strings.ToUpper(subset.Name)
+		typeName := className + parser.ToJavaName(subset.Name)
+		code.WriteString(fmt.Sprintf("/** %s的子集：%s */\n", commentText(group.Label), commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("export const %s = [%s] as const;\n", arrayName, subsetKeys(group, subset, keyExpr)))
+		code.WriteString(fmt.Sprintf("export type %s = typeof %s[number];\n\n", typeName, arrayName))
+		code.WriteString(fmt.Sprintf("/** 判断值是否属于子集“%s” */\n", commentText(subset.Label)))
+		code.WriteString(fmt.Sprintf("export function is%s(value: %s): value is %s {\n", typeName, tsType, typeName))
+		code.WriteString(fmt.Sprintf("  return (%s as readonly %s[]).includes(value);\n", arrayName, tsType))
+		code.WriteString("}\n")
+	}
+
+	return code.String()
+}
+
+// jsLocaleTable 生成TypeScript/JavaScript中各语言标签表（语言代码 -> 键 -> 标签）的Map表达式，
+// innerType 为内层Map的类型参数（JavaScript为空）
+func jsLocaleTable(group *parser.ConstantGroup, indent string, locales []string, constants []*parser.Constant,
+	keyExpr func(*parser.Constant) string, lang, innerType string) string {
+	var code strings.Builder
+
+	if innerType != "" {
+		innerType = "<" + innerType + ">"
+	}
+	code.WriteString("new Map([\n")
+	for _, locale := range locales {
+		code.WriteString(fmt.Sprintf("%s  ['%s', new Map%s([\n", indent, locale, innerType))
+		for _, constant := range constants {
+			code.WriteString(fmt.Sprintf("%s    [%s, %s],\n", indent, keyExpr(constant),
+				parser.FormatValue(localizedLabel(constant, locale), "string", lang)))
+		}
+		code.WriteString(indent + "  ])],\n")
+	}
+	code.WriteString(indent + "])")
+
+	return code.String()
+}
+
+// jsLocaleLookup 生成按语言选取标签表的表达式：先按完整的语言代码查找，再按语言部分（如 en-US 的 en），最后使用默认语言
+func jsLocaleLookup(tableName, defaultLocale, lang string) string {
+	expr := fmt.Sprintf("%s.get(locale) || %s.get(locale.split(/[-_]/)[0]) || %s.get('%s')", tableName, tableName, tableName, defaultLocale)
+	if lang == "typescript" {
+		return "(" + expr + ")!"
+	}
+	return expr
+}
+
+// tsTemplateFuncs 为标签含占位符的常量生成导出函数 format<组名><常量名>，函数体为模板字符串
+func tsTemplateFuncs(group *parser.ConstantGroup) string {
+	var code strings.Builder
+
+	for _, constant := range templateConstants(group) {
+		code.WriteString(fmt.Sprintf("\n/** %s */\n", templateDoc(constant)))
+		code.WriteString(fmt.Sprintf("export function format%s%s(%s): string {\n", parser.ToJavaName(group.Name), parser.ToJavaName(constant.Name),
+			templateParams(constant.Template, func(param parser.TemplateParam) string {
+				return toCamelCase(param.Name) + ": " + parser.GetTypeScriptType(param.Type)
+			})))
+		code.WriteString(fmt.Sprintf("  return %s;\n", jsTemplateLiteral(constant.Template)))
+		code.WriteString("}\n")
+	}
+
+	return code.String()
+}
+
+// jsTemplateLiteral 将标签模板转换为TypeScript/JavaScript的模板字符串，占位符替换为同名的小驼峰参数
+func jsTemplateLiteral(template *parser.LabelTemplate) string {
+	escaper := strings.NewReplacer(`\`, `\\`, "`", "\\`", "${", "\\${", "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	var text strings.Builder
+	text.WriteString("`")
+	for _, part := range template.Parts {
+		if part.Param == "" {
+			text.WriteString(escaper.Replace(part.Text))
+			continue
+		}
+		text.WriteString("${" + toCamelCase(part.Param) + "}")
+	}
+	text.WriteString("`")
+	return text.String()
+}
+
+// jsRecordFieldType 返回记录字段在TypeScript/JavaScript中使用的数据类型
+func jsRecordFieldType(group *parser.ConstantGroup, field *parser.Attribute) string {
+	if field.Name == group.Record.Primary {
+		return jsDataType(group)
+	}
+	return jsAttributeType(field, group)
+}
+
+// tsAttributeMap 生成值到附加属性的只读查询表，keyExpr 返回常量作为键的表达式
+func tsAttributeMap(group *parser.ConstantGroup, attribute *parser.Attribute, mapName string,
+	keyExpr func(*parser.Constant) string) string {
+	var code strings.Builder
+
+	keyType := parser.GetTypeScriptType(jsDataType(group))
+	attrType := jsAttributeType(attribute, group)
+	mapType := fmt.Sprintf("%s, %s", keyType, parser.GetTypeScriptType(attrType))
+	code.WriteString(fmt.Sprintf("/** %s的%s */\n", commentText(group.Label), commentText(attributeLabel(attribute))))
+	code.WriteString(fmt.Sprintf("export const %s: ReadonlyMap<%s> = new Map<%s>([\n", mapName, mapType, mapType))
+	for _, constant := range canonicalConstants(group) {
+		code.WriteString(fmt.Sprintf("  [%s, %s],\n", keyExpr(constant),
+			parser.FormatValue(constant.Attributes[attribute.Name], attrType, "typescript")))
+	}
+	code.WriteString("]);\n")
+
+	return code.String()
+}
+
+// jsAttributeType 返回附加属性在TypeScript/JavaScript中使用的数据类型：整数属性的值超出 Number.MAX_SAFE_INTEGER 时使用BigInt
+func jsAttributeType(attribute *parser.Attribute, group *parser.ConstantGroup) string {
+	if !parser.IsIntegerType(attribute.Type) {
+		return attribute.Type
+	}
+	if !parser.IsSafeInteger(attribute.Default) {
+		return "bigint"
+	}
+	for _, constant := range group.Constants {
+		if !parser.IsSafeInteger(constant.Attributes[attribute.Name]) {
+			return "bigint"
+		}
+	}
+	return attribute.Type
+}
+
+// jsDataType 返回常量组在TypeScript/JavaScript中使用的数据类型：
+// 整数组中只要有值超出 Number.MAX_SAFE_INTEGER，整组都使用BigInt，以免同组的值无法相互比较；
+// 位标志组的位运算在number上只有32位，用到第31位及以上时同样使用BigInt
+func jsDataType(group *parser.ConstantGroup) string {
+	dataType := group.Constants[0].Type
+	if !parser.IsIntegerType(dataType) {
+		return dataType
+	}
+	for _, constant := range group.Constants {
+		if !parser.IsSafeInteger(constant.Value) {
+			return "bigint"
+		}
+		if value, ok := constant.Value.(int64); ok && group.Flags && value > 1<<30 {
+			return "bigint"
+		}
+	}
+	return dataType
+}
 
+// tsDoc 生成常量的TSDoc/JSDoc注释，废弃常量附加 @deprecated 标记
+func tsDoc(constant *parser.Constant, indent string) string {
+	lines := docLines(constant)
+	if constant.Deprecated {
+		lines = append(lines, "@deprecated "+commentText(deprecationMessage(constant)))
+	}
+	return blockDoc(indent, lines)
+}
 
-// GenerateIndex 生成TypeScript的index.ts文件
+// GenerateIndex 生成TypeScript的index.ts文件（每个子目录各生成一个）
 func (g *TypeScriptGenerator) GenerateIndex(allConstants []*parser.ConstantsFile) error {
+	for _, dir := range buildIndexDirs(allConstants) {
+		if err := g.generateIndexFile(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateIndexFile 生成单个目录的index.ts文件
+func (g *TypeScriptGenerator) generateIndexFile(dir *indexDir) error {
 	var code strings.Builder
 	
 	// 文件头注释
@@ -128,11 +759,16 @@ func (g *TypeScriptGenerator) GenerateIndex(allConstants []*parser.ConstantsFile
 	code.WriteString(" */\n\n")
 	
 	// 导出所有文件
-	for _, constants := range allConstants {
+	for _, constants := range dir.Files {
 		code.WriteString(fmt.Sprintf("export * from './%s';\n", constants.FileName))
 	}
 	
+	// 子目录按命名空间导出
+	for _, subDir := range dir.SubDirs {
+		code.WriteString(fmt.Sprintf("export * as %s from './%s';\n", subDir, subDir))
+	}
+	
 	// 写入文件
-	outputPath := filepath.Join(g.Config.OutputDir, "index.ts")
-	return os.WriteFile(outputPath, []byte(code.String()), 0644)
-}
\ No newline at end of file
+	outputPath := filepath.Join(g.GetNamespaceDir(dir.Namespace), "index.ts")
+	return WriteOutputFile(outputPath, code.String())
+}
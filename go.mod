@@ -7,3 +7,5 @@ toolchain go1.24.4
 require github.com/spf13/pflag v1.0.5
 
 require golang.org/x/text v0.28.0
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -1,10 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -25,6 +25,16 @@ var (
 )
 
 func main() {
+	// 翻译文件的导出和导入作为子命令
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export-translations":
+			os.Exit(exportTranslations(os.Args[2:]))
+		case "import-translations":
+			os.Exit(importTranslations(os.Args[2:]))
+		}
+	}
+
 	var (
 		dir           string
 		output        string
@@ -32,16 +42,24 @@ func main() {
 		mode          string
 		pkgName       string
 		headerComment string
+		defaultLocale string
+		overlays      []string
+		diagrams      []string
 		help          bool
 		showVersion   bool
+		verbose       bool
 	)
 
-	flag.StringVarP(&dir, "dir", "d", "", "YAML配置文件目录 (必填)")
+	flag.StringVarP(&dir, "dir", "d", "", "常量源文件目录（YAML/JSON/CSV），递归扫描子目录 (必填)")
 	flag.StringVarP(&output, "output", "o", "", "输出代码目录 (必填)")
 	flag.StringVarP(&lang, "lang", "l", "", "目标语言 (python/go/java/swift/kotlin/typescript/javascript) (必填)")
 	flag.StringVarP(&mode, "mode", "m", "class", "生成模式 (class/const) (可选，默认为class)")
 	flag.StringVarP(&pkgName, "package", "p", "", "包名 (可选，Go/Java/Kotlin语言使用)")
 	flag.StringVarP(&headerComment, "header", "", "Generated by ConsCoder CLI tool. DO NOT EDIT.", "生成代码的头部注释 (可选)")
+	flag.StringVarP(&defaultLocale, "default-locale", "", parser.DefaultLocale, "默认语言，行内注释和单一标签属于该语言，按语言查询标签时缺少翻译则使用该语言 (可选)")
+	flag.StringSliceVarP(&overlays, "overlay", "", nil, "覆盖层目录，按常量组和常量名覆盖、新增或删除 --dir 中的常量，可重复指定，后面的覆盖层优先 (可选)")
+	flag.StringSliceVarP(&diagrams, "diagram", "", generator.DiagramFormatNames(), fmt.Sprintf("状态机组导出的状态图格式 (%s)，多种格式用逗号分隔，none 表示不导出 (可选)", strings.Join(generator.DiagramFormatNames(), "/")))
+	flag.BoolVarP(&verbose, "verbose", "", false, "输出详细信息，如auto自动分配的常量值 (可选)")
 	flag.BoolVarP(&help, "help", "h", false, "显示帮助信息")
 	flag.BoolVarP(&showVersion, "version", "v", false, "显示版本信息")
 
@@ -80,6 +98,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// 验证默认语言
+	if !parser.IsValidLocale(defaultLocale) {
+		fmt.Printf("错误: 默认语言 '%s' 不是合法的语言代码（如 zh、en、zh-Hant）\n", defaultLocale)
+		os.Exit(1)
+	}
+
+	// 验证状态图格式
+	if contains(diagrams, "none") {
+		diagrams = nil
+	}
+	for _, format := range diagrams {
+		if !generator.IsDiagramFormat(format) {
+			fmt.Printf("错误: 不支持的状态图格式 '%s'\n", format)
+			fmt.Printf("支持的格式: %s, none\n", strings.Join(generator.DiagramFormatNames(), ", "))
+			os.Exit(1)
+		}
+	}
+
 	// 设置默认包名
 	if pkgName == "" {
 		switch lang {
@@ -91,9 +127,11 @@ func main() {
 	}
 
 	// 验证目录是否存在
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		fmt.Printf("错误: 目录 '%s' 不存在\n", dir)
-		os.Exit(1)
+	for _, sourceDir := range append([]string{dir}, overlays...) {
+		if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
+			fmt.Printf("错误: 目录 '%s' 不存在\n", sourceDir)
+			os.Exit(1)
+		}
 	}
 
 	// 创建输出目录
@@ -102,38 +140,49 @@ func main() {
 		os.Exit(1)
 	}
 
-	// 读取所有YAML文件
-	yamlFiles, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	// 递归读取所有源文件（YAML/JSON/CSV）
+	sourceFiles, err := parser.FindSourceFiles(dir)
 	if err != nil {
-		log.Fatalf("错误: 读取YAML文件失败: %v", err)
+		log.Fatalf("错误: 读取源文件失败: %v", err)
 	}
 
-	if len(yamlFiles) == 0 {
-		fmt.Printf("警告: 在目录 '%s' 中没有找到YAML文件\n", dir)
+	if len(sourceFiles) == 0 {
+		fmt.Printf("警告: 在目录 '%s' 中没有找到YAML/JSON/CSV文件\n", dir)
 		os.Exit(0)
 	}
 
-	fmt.Printf("找到 %d 个YAML文件\n", len(yamlFiles))
+	fmt.Printf("找到 %d 个源文件\n", len(sourceFiles))
 
-	// 解析所有YAML文件
-	var allConstants []*parser.ConstantsFile
-	for _, yamlFile := range yamlFiles {
-		fmt.Printf("正在解析: %s\n", yamlFile)
+	// 解析所有源文件并合并覆盖层
+	allConstants, diagnostics := loadSources(dir, sourceFiles, overlays, defaultLocale)
 
-		constants, err := parser.ParseYAMLFile(yamlFile)
-		if err != nil {
-			log.Printf("警告: 解析文件 '%s' 失败: %v", yamlFile, err)
-			continue
-		}
+	// 再检查跨文件的常量组重名并解析跨文件的常量引用
+	if !diagnostics.HasErrors() {
+		diagnostics = append(diagnostics, parser.CheckGroupNames(allConstants)...)
+	}
+	if !diagnostics.HasErrors() {
+		diagnostics = append(diagnostics, parser.ResolveReferences(allConstants)...)
+	}
 
-		allConstants = append(allConstants, constants)
+	// 以编译器风格输出诊断，存在错误时不生成代码
+	for _, diag := range diagnostics {
+		fmt.Fprintln(os.Stderr, diag)
+	}
+	if diagnostics.HasErrors() {
+		fmt.Fprintf(os.Stderr, "%d 个错误，%d 个警告，未生成代码\n",
+			diagnostics.Count(parser.SeverityError), diagnostics.Count(parser.SeverityWarning))
+		os.Exit(1)
 	}
 
 	if len(allConstants) == 0 {
-		fmt.Println("错误: 没有成功解析任何YAML文件")
+		fmt.Println("错误: 没有成功解析任何源文件")
 		os.Exit(1)
 	}
 
+	if verbose {
+		printAutoValues(allConstants)
+	}
+
 	// 生成代码
 	config := generator.Config{
 		Language:      lang,
@@ -141,18 +190,44 @@ func main() {
 		OutputDir:     output,
 		PackageName:   pkgName,
 		HeaderComment: headerComment,
+		DefaultLocale: defaultLocale,
+		Diagrams:      diagrams,
 		Version:       Version,
 	}
 
+	// 不同的源文件生成同一个输出文件时不生成代码，以免相互覆盖
+	if pathDiags := generator.CheckOutputPaths(config, allConstants); len(pathDiags) > 0 {
+		for _, diag := range pathDiags {
+			fmt.Fprintln(os.Stderr, diag)
+		}
+		fmt.Fprintf(os.Stderr, "%d 个错误，未生成代码\n", len(pathDiags))
+		os.Exit(1)
+	}
+
 	gen := generator.New(config)
 
+	// 正则常量用到目标语言不支持的语法时给出警告，仍然生成代码
+	for _, diag := range generator.CheckRegexSupport(allConstants, lang) {
+		fmt.Fprintln(os.Stderr, diag)
+	}
+
+	failed := 0
 	for _, constants := range allConstants {
 		fmt.Printf("正在生成 %s 代码: %s\n", lang, constants.FileName)
 
 		if err := gen.Generate(constants); err != nil {
-			log.Printf("警告: 生成文件 '%s' 的代码失败: %v", constants.FileName, err)
+			var diag *parser.Diagnostic
+			if !errors.As(err, &diag) {
+				diag = &parser.Diagnostic{File: constants.FilePath, Severity: parser.SeverityError, Message: err.Error()}
+			}
+			fmt.Fprintln(os.Stderr, diag)
+			failed++
 			continue
 		}
+		if err := generator.WriteStateDiagrams(config, constants); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: error: 导出状态图失败: %v\n", constants.FilePath, err)
+			failed++
+		}
 	}
 
 	// 生成索引文件（Python的__init__.py, TypeScript的index.ts等）
@@ -163,9 +238,78 @@ func main() {
 		}
 	}
 
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d 个文件生成失败\n", failed)
+		os.Exit(1)
+	}
+
 	fmt.Println("代码生成完成!")
 }
 
+// loadSources 解析输入目录中的源文件，按顺序合并各覆盖层目录，再确定多语言标签的默认语言文本
+func loadSources(dir string, sourceFiles []string, overlays []string, defaultLocale string) ([]*parser.ConstantsFile, parser.Diagnostics) {
+	allConstants, diagnostics := parseSources(dir, sourceFiles, parser.ParseFile)
+	diagnostics = append(diagnostics, parser.CheckNamespaces(dir, allConstants)...)
+
+	var layers [][]*parser.ConstantsFile
+	for _, overlay := range overlays {
+		overlayFiles, err := parser.FindSourceFiles(overlay)
+		if err != nil {
+			diagnostics = append(diagnostics, &parser.Diagnostic{File: overlay, Severity: parser.SeverityError, Message: fmt.Sprintf("读取覆盖层失败: %v", err)})
+			continue
+		}
+		layer, diags := parseSources(overlay, overlayFiles, parser.ParseOverlayFile)
+		diagnostics = append(diagnostics, diags...)
+		layers = append(layers, layer)
+	}
+	if !diagnostics.HasErrors() && len(layers) > 0 {
+		var diags parser.Diagnostics
+		allConstants, diags = parser.ApplyOverlays(allConstants, layers, defaultLocale)
+		diagnostics = append(diagnostics, diags...)
+	}
+
+	if !diagnostics.HasErrors() {
+		diagnostics = append(diagnostics, parser.ApplyLocale(allConstants, defaultLocale)...)
+	}
+	return allConstants, diagnostics
+}
+
+// parseSources 用 parse 解析目录中的各个源文件，子目录作为命名空间
+func parseSources(dir string, sourceFiles []string, parse func(string) ([]*parser.ConstantsFile, parser.Diagnostics, error)) ([]*parser.ConstantsFile, parser.Diagnostics) {
+	var allConstants []*parser.ConstantsFile
+	var diagnostics parser.Diagnostics
+	for _, sourceFile := range sourceFiles {
+		fmt.Printf("正在解析: %s\n", sourceFile)
+
+		files, diags, err := parse(sourceFile)
+		if err != nil {
+			log.Printf("警告: 解析文件 '%s' 失败: %v", sourceFile, err)
+			continue
+		}
+		diagnostics = append(diagnostics, diags...)
+
+		for _, constants := range files {
+			constants.Namespace = parser.NamespaceOf(dir, sourceFile)
+			allConstants = append(allConstants, constants)
+		}
+	}
+	return allConstants, diagnostics
+}
+
+// printAutoValues 输出由auto指令自动分配的常量值
+func printAutoValues(allConstants []*parser.ConstantsFile) {
+	for _, constants := range allConstants {
+		for _, group := range constants.Groups {
+			for _, constant := range group.Constants {
+				if constant.AutoAssigned {
+					fmt.Printf("自动赋值: %s:%d: %s.%s = %v\n",
+						constants.FilePath, constant.Pos.Line, group.Name, constant.Name, constant.Value)
+				}
+			}
+		}
+	}
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -179,11 +323,15 @@ func printHelp() {
 	fmt.Println("常量代码生成器 (Constants Code Generator)")
 	fmt.Printf("版本: %s\n\n", Version)
 	fmt.Println("用法:")
-	fmt.Println("  cons-coder --dir <YAML目录> --output <输出目录> --lang <语言> [选项]")
+	fmt.Println("  cons-coder --dir <源文件目录> --output <输出目录> --lang <语言> [选项]")
+	fmt.Println("  cons-coder export-translations --dir <源文件目录> --output <输出目录> --locale <语言> [选项]")
+	fmt.Println("  cons-coder import-translations --dir <源文件目录> --input <翻译文件> [选项]")
 	fmt.Println()
 	fmt.Println("示例:")
 	fmt.Println("  cons-coder --dir ./data --output ./python-codes --lang python")
 	fmt.Println("  cons-coder --dir ./data --output ./go-codes --lang go --package constants")
+	fmt.Println("  cons-coder --dir ./data --overlay ./tenants/acme --output ./go-codes --lang go")
+	fmt.Println("  cons-coder export-translations --dir ./data --output ./i18n --locale en,ja --format po")
 	fmt.Println()
 	fmt.Println("选项:")
 	flag.PrintDefaults()
@@ -0,0 +1,248 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Attribute 常量组声明的附加属性（如颜色、图标、排序权重），组内每个常量都有该属性的值
+type Attribute struct {
+	Name    string      // 属性名
+	Type    string      // 数据类型 (int, int64, float, bool, string)
+	Label   string      // 属性说明
+	Default interface{} // 常量未给出值时使用的值，未声明时为类型的零值
+	Pos     Position    // 在源文件中的位置
+}
+
+// attributeText 常量在源文件中给出的属性值，按常量组声明的类型转换前保留原文
+type attributeText struct {
+	Text string
+	Pos  Position
+}
+
+// attributeTypes 附加属性支持的类型及其零值
+var attributeTypes = map[string]interface{}{
+	"int":    int64(0),
+	"int64":  int64(0),
+	"float":  float64(0),
+	"bool":   false,
+	"string": "",
+}
+
+// reservedAttributeNames 与生成代码中已有成员（如Swift枚举的id、位标志组的has、记录组的from_key）冲突的属性名
+var reservedAttributeNames = map[string]bool{
+	"name":            true,
+	"key":             true,
+	"id":              true,
+	"raw_value":       true,
+	"all":             true,
+	"mask":            true,
+	"flags":           true,
+	"has":             true,
+	"add":             true,
+	"remove":          true,
+	"to_list":         true,
+	"to_mask":         true,
+	"format":          true,
+	"format_value":    true,
+	"string":          true,
+	"is_valid":        true,
+	"from_string":     true,
+	"all_values":      true,
+	"all_keys":        true,
+	"key_value_pairs": true,
+	"all_records":     true,
+	"from_key":        true,
+	"localized_label": true,
+	"locale_labels":   true,
+}
+
+// isAttributesDirective 判断键值是否为attributes指令：值为映射，且不是映射形式的常量
+func isAttributesDirective(key string, node *yaml.Node) bool {
+	if key != "attributes" || node.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if constantFields[node.Content[i].Value] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAttributeDeclarations 解析属性声明，每项写作 name: type，
+// 或 name: {type: string, default: "#999", label: 颜色}；简写形式的行内注释作为属性说明
+func parseAttributeDeclarations(node *yaml.Node, diags *Diagnostics) []*Attribute {
+	var attributes []*Attribute
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		attribute := &Attribute{
+			Name:  strings.TrimSpace(keyNode.Value),
+			Label: firstCommentLine(valueNode.LineComment, keyNode.LineComment),
+			Pos:   nodePos(keyNode),
+		}
+
+		var defaultNode *yaml.Node
+		switch valueNode.Kind {
+		case yaml.ScalarNode:
+			attribute.Type = strings.TrimSpace(valueNode.Value)
+		case yaml.MappingNode:
+			for j := 0; j+1 < len(valueNode.Content); j += 2 {
+				fieldKey, fieldNode := valueNode.Content[j], valueNode.Content[j+1]
+				var err error
+				switch fieldKey.Value {
+				case "type":
+					err = fieldNode.Decode(&attribute.Type)
+				case "label":
+					err = fieldNode.Decode(&attribute.Label)
+				case "default":
+					defaultNode = fieldNode
+				default:
+					diags.errorf(nodePos(fieldKey), "属性 '%s' 的未知字段 '%s'", attribute.Name, fieldKey.Value)
+				}
+				if err != nil {
+					diags.errorf(nodePos(fieldNode), "属性 '%s' 的字段 '%s' 无效: %s", attribute.Name, fieldKey.Value, decodeErrorMessage(err))
+				}
+			}
+		default:
+			diags.errorf(nodePos(valueNode), "属性 '%s' 的声明必须是类型名或映射", attribute.Name)
+			continue
+		}
+
+		if err := checkAttributeDeclaration(attribute); err != nil {
+			diags.errorf(attribute.Pos, "%v", err)
+			continue
+		}
+		if defaultNode != nil {
+			if defaultNode.Kind != yaml.ScalarNode {
+				diags.errorf(nodePos(defaultNode), "属性 '%s' 的默认值必须是标量", attribute.Name)
+				continue
+			}
+			value, err := convertAttributeValue(defaultNode.Value, attribute.Type)
+			if err != nil {
+				diags.errorf(nodePos(defaultNode), "属性 '%s' 的默认值无效: %v", attribute.Name, err)
+				continue
+			}
+			attribute.Default = value
+		}
+		attributes = append(attributes, attribute)
+	}
+	return attributes
+}
+
+// checkAttributeDeclaration 校验属性名和类型，并以类型的零值作为默认值
+func checkAttributeDeclaration(attribute *Attribute) error {
+	if !identifierPattern.MatchString(attribute.Name) {
+		return fmt.Errorf("属性名 '%s' 不是合法的标识符", attribute.Name)
+	}
+	if constantFields[attribute.Name] || reservedAttributeNames[attribute.Name] {
+		return fmt.Errorf("属性名 '%s' 与常量的内置字段或生成的成员冲突", attribute.Name)
+	}
+	zero, supported := attributeTypes[attribute.Type]
+	if !supported {
+		return fmt.Errorf("属性 '%s' 的类型 '%s' 无效，只能是 int、int64、float、bool 或 string", attribute.Name, attribute.Type)
+	}
+	attribute.Default = zero
+	return nil
+}
+
+// convertAttributeValue 按属性类型转换属性值，int 类型的值必须在32位范围内
+func convertAttributeValue(text string, dataType string) (interface{}, error) {
+	if dataType == "string" {
+		return text, nil
+	}
+	value, err := ConvertValue(strings.TrimSpace(text), dataType)
+	if err != nil {
+		return nil, err
+	}
+	if IsIntegerType(dataType) && integerRanks[integerType(value)] > integerRanks[dataType] {
+		return nil, fmt.Errorf("'%s' 超出%s的范围", text, dataType)
+	}
+	return value, nil
+}
+
+// checkAttributes 按常量组声明的类型转换各常量的属性值，未给出的属性取默认值
+func checkAttributes(group *ConstantGroup, diags *Diagnostics) {
+	declared := make(map[string]*Attribute)
+	for _, attribute := range group.Attributes {
+		declared[attribute.Name] = attribute
+	}
+
+	// 属性在部分语言中生成为与常量同级的成员，不能与常量重名
+	for _, attribute := range group.Attributes {
+		for _, constant := range group.Constants {
+			if strings.EqualFold(attribute.Name, constant.Name) {
+				diags.errorf(attribute.Pos, "属性 '%s' 与常量组 '%s' 中的常量重名", attribute.Name, group.Name)
+			}
+			for _, alias := range constant.Aliases {
+				if strings.EqualFold(attribute.Name, alias.Name) {
+					diags.errorf(attribute.Pos, "属性 '%s' 与常量组 '%s' 中的别名重名", attribute.Name, group.Name)
+				}
+			}
+		}
+	}
+
+	for _, constant := range group.Constants {
+		var undeclared []string
+		for name := range constant.attributeTexts {
+			if declared[name] == nil {
+				undeclared = append(undeclared, name)
+			}
+		}
+		sort.Strings(undeclared)
+		for _, name := range undeclared {
+			diags.errorf(constant.attributeTexts[name].Pos, "常量 '%s' 的属性 '%s' 未在常量组 '%s' 中声明", constant.Name, name, group.Name)
+		}
+		if len(group.Attributes) == 0 {
+			continue
+		}
+
+		constant.Attributes = make(map[string]interface{})
+		for _, attribute := range group.Attributes {
+			constant.Attributes[attribute.Name] = attribute.Default
+			text, exists := constant.attributeTexts[attribute.Name]
+			if !exists {
+				continue
+			}
+			value, err := convertAttributeValue(text.Text, attribute.Type)
+			if err != nil {
+				diags.errorf(text.Pos, "常量 '%s' 的属性 '%s' 无效: %v", constant.Name, attribute.Name, err)
+				continue
+			}
+			constant.Attributes[attribute.Name] = value
+		}
+	}
+}
+
+// parseAttributeValues 解析映射形式常量的attributes字段，值必须是标量
+func parseAttributeValues(constant *Constant, node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return errorAt(nodePos(node), "常量 '%s' 的attributes必须是映射", constant.Name)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if valueNode.Kind == yaml.AliasNode {
+			valueNode = valueNode.Alias
+		}
+		if valueNode.Kind != yaml.ScalarNode {
+			return errorAt(nodePos(valueNode), "常量 '%s' 的属性 '%s' 必须是标量", constant.Name, keyNode.Value)
+		}
+		// null 表示使用默认值
+		if valueNode.ShortTag() == "!!null" {
+			continue
+		}
+		constant.setAttributeText(keyNode.Value, valueNode.Value, nodePos(valueNode))
+	}
+	return nil
+}
+
+// setAttributeText 记录源文件中给出的属性值
+func (c *Constant) setAttributeText(name string, text string, pos Position) {
+	if c.attributeTexts == nil {
+		c.attributeTexts = make(map[string]attributeText)
+	}
+	c.attributeTexts[name] = attributeText{Text: text, Pos: pos}
+}
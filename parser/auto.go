@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AutoIncrement 常量组的自增赋值规则，由 auto 指令声明
+type AutoIncrement struct {
+	Start int64 // 起始值
+	Step  int64 // 步长
+}
+
+// parseAutoDirective 解析auto指令：true 表示从0开始、步长为1，false 表示不自增；
+// 也可写作 "start=1, step=2"，未给出的参数取默认值
+func parseAutoDirective(text string) (*AutoIncrement, error) {
+	text = strings.TrimSpace(text)
+	if enabled, err := strconv.ParseBool(text); err == nil {
+		if !enabled {
+			return nil, nil
+		}
+		return &AutoIncrement{Start: 0, Step: 1}, nil
+	}
+
+	auto := &AutoIncrement{Start: 0, Step: 1}
+	for _, part := range strings.Split(text, ",") {
+		key, valueText, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("auto指令 '%s' 无效，应写作 start=1, step=1", text)
+		}
+
+		value, err := strconv.ParseInt(strings.TrimSpace(valueText), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("auto指令的 %s 必须是整数，实际为 '%s'", strings.TrimSpace(key), strings.TrimSpace(valueText))
+		}
+		switch strings.TrimSpace(key) {
+		case "start":
+			auto.Start = value
+		case "step":
+			if value == 0 {
+				return nil, fmt.Errorf("auto指令的 step 不能为0")
+			}
+			auto.Step = value
+		default:
+			return nil, fmt.Errorf("auto指令的未知参数 '%s'", strings.TrimSpace(key))
+		}
+	}
+	return auto, nil
+}
+
+// isAutoDirective 判断键值是否为auto指令（值为布尔值或 key=value 形式），
+// 以免与名为auto的普通常量混淆
+func isAutoDirective(key string, value string, isBool bool) bool {
+	return key == "auto" && (isBool || strings.Contains(value, "="))
+}
+
+// isFlagsDirective 判断键值是否为flags指令（值为布尔值）
+func isFlagsDirective(key string, isBool bool) bool {
+	return key == "flags" && isBool
+}
+
+// checkFlags 校验位标志组：值必须是int64范围内2的正整数次幂，且各标志互不相同
+func checkFlags(group *ConstantGroup, diags *Diagnostics) {
+	bits := make(map[int64]*Constant)
+	for _, constant := range group.Constants {
+		if constant.Value == nil {
+			// 引用在解析后再校验
+			continue
+		}
+		value, ok := constant.Value.(int64)
+		if !ok || value <= 0 || value&(value-1) != 0 {
+			diags.errorf(constant.ValuePos(), "位标志组 '%s' 的常量 '%s' 的值 %v 不是2的幂", group.Name, constant.Name, constant.Value)
+			continue
+		}
+		if other, duplicated := bits[value]; duplicated {
+			diags.errorf(constant.Pos, "位标志组 '%s' 的常量 '%s' 与 '%s' 使用了相同的位 %d", group.Name, constant.Name, other.Name, value)
+			continue
+		}
+		bits[value] = constant
+	}
+}
+
+// assignAutoValues 按源文件顺序为常量组中未给出值的常量自增赋值
+//
+// 显式给出的整数值会重置计数，后续常量从该值加步长继续；位标志组则依次分配下一个二进制位（默认从1开始）。
+// 未声明auto指令的常量组中缺少值的常量报告为错误，并从常量组中移除。
+func assignAutoValues(group *ConstantGroup, diags *Diagnostics) {
+	next := int64(0)
+	if group.Auto != nil {
+		next = group.Auto.Start
+		if group.Flags && next == 0 {
+			next = 1
+		}
+	}
+
+	var valid []*Constant
+	assigned := make(map[int64]*Constant)
+	for _, constant := range group.Constants {
+		if constant.Ref != nil {
+			// 引用的值在所有文件解析完成后才能确定，不参与自增计数
+			valid = append(valid, constant)
+			continue
+		}
+		if constant.Value == nil {
+			if group.Auto == nil {
+				diags.errorf(constant.Pos, "常量 '%s' 缺少值（可在常量组上声明 auto 指令自动赋值）", constant.Name)
+				continue
+			}
+			if constant.Type == "" {
+				constant.Type = "int"
+			}
+			if !IsIntegerType(constant.Type) {
+				diags.errorf(constant.ValuePos(), "常量 '%s' 的类型为%s，auto只能为整数赋值", constant.Name, constant.Type)
+				continue
+			}
+			constant.Value = next
+			constant.AutoAssigned = true
+		}
+		if group.Auto == nil {
+			valid = append(valid, constant)
+			continue
+		}
+
+		value, ok := constant.Value.(int64)
+		if !ok {
+			diags.errorf(constant.ValuePos(), "常量组 '%s' 使用auto自增赋值，常量 '%s' 的值必须是int64范围内的整数", group.Name, constant.Name)
+			continue
+		}
+		if other, duplicated := assigned[value]; duplicated && (constant.AutoAssigned || other.AutoAssigned) {
+			diags.warnf(constant.Pos, "常量 '%s' 的值 %d 与自动赋值的常量 '%s' 重复", constant.Name, value, other.Name)
+		}
+		assigned[value] = constant
+		next = value + group.Auto.Step
+		if group.Flags {
+			next = value << 1
+		}
+		valid = append(valid, constant)
+	}
+	group.Constants = valid
+}
@@ -0,0 +1,238 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 集合值常量：映射形式常量的 value 可以是列表或映射，类型写作 list<元素类型> 或 map<元素类型>（映射的键为字符串），
+// 用于允许上传的扩展名、地区到服务地址的映射等配置；生成器输出各语言的不可变集合，
+// class和const模式下生成相同的代码，常量按源文件顺序排列。
+
+// MapEntry 映射值中的一项，映射值按源文件顺序保存为 []MapEntry
+type MapEntry struct {
+	Key   string      // 键
+	Value interface{} // 值，类型由映射的元素类型决定
+}
+
+// collectionTypePattern 集合类型，如 list<string>、map<int>
+var collectionTypePattern = regexp.MustCompile(`^(list|map)<\s*([a-z0-9]+)\s*>$`)
+
+// collectionElementTypes 集合元素支持的类型
+var collectionElementTypes = map[string]bool{
+	"int":    true,
+	"int64":  true,
+	"float":  true,
+	"bool":   true,
+	"string": true,
+}
+
+// collectionKindNames 集合种类的名称，用于报告错误
+var collectionKindNames = map[string]string{
+	"list": "列表",
+	"map":  "映射",
+}
+
+// CollectionType 解析集合类型，返回集合的种类（list 或 map）和元素类型；不是集合类型时 ok 为false
+func CollectionType(dataType string) (kind, elementType string, ok bool) {
+	match := collectionTypePattern.FindStringSubmatch(dataType)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// IsCollectionType 判断数据类型是否为集合类型
+func IsCollectionType(dataType string) bool {
+	_, _, ok := CollectionType(dataType)
+	return ok
+}
+
+// IsCollectionGroup 判断常量组是否为集合值常量组（有常量的值是列表或映射）
+func IsCollectionGroup(group *ConstantGroup) bool {
+	for _, constant := range group.Constants {
+		if IsCollectionType(constant.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCollectionTypeName 校验显式指定的集合类型，元素类型只能是 int、int64、float、bool 或 string
+func checkCollectionTypeName(dataType string) error {
+	kind, elementType, ok := CollectionType(dataType)
+	if !ok {
+		return fmt.Errorf("不支持的类型 '%s'", dataType)
+	}
+	if !collectionElementTypes[elementType] {
+		return fmt.Errorf("%s的元素类型 '%s' 无效，只能是 int、int64、float、bool 或 string", kind, elementType)
+	}
+	return nil
+}
+
+// parseCollectionNode 解析列表或映射节点作为集合值，explicitType 为空时按元素推断元素类型；
+// 元素必须是同一类型的标量，整数元素按最大的值加宽为int64
+func parseCollectionNode(node *yaml.Node, explicitType string) (interface{}, string, error) {
+	kind := "list"
+	if node.Kind == yaml.MappingNode {
+		kind = "map"
+	}
+	elementType := ""
+	if explicitType != "" {
+		if err := checkCollectionTypeName(explicitType); err != nil {
+			return nil, "", err
+		}
+		var explicitKind string
+		explicitKind, elementType, _ = CollectionType(explicitType)
+		if explicitKind != kind {
+			return nil, "", fmt.Errorf("类型为%s，值必须是%s", explicitType, collectionKindNames[explicitKind])
+		}
+	}
+
+	var keys []string
+	var items []*yaml.Node
+	if kind == "map" {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keys = append(keys, node.Content[i].Value)
+			items = append(items, node.Content[i+1])
+		}
+	} else {
+		items = node.Content
+	}
+
+	values := make([]interface{}, len(items))
+	types := make([]string, len(items))
+	for i, item := range items {
+		if item.Kind == yaml.AliasNode {
+			item = item.Alias
+		}
+		if item.Kind != yaml.ScalarNode {
+			return nil, "", fmt.Errorf("集合的元素必须是标量")
+		}
+		var err error
+		if elementType != "" {
+			values[i], err = ConvertValue(item.Value, elementType)
+			types[i] = elementType
+		} else {
+			values[i], types[i], err = parseScalarValue(item)
+		}
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	elementType, err := collectionElementType(elementType, values, types)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dataType := fmt.Sprintf("%s<%s>", kind, elementType)
+	if kind == "map" {
+		entries := make([]MapEntry, len(values))
+		seen := make(map[string]bool)
+		for i, value := range values {
+			if seen[keys[i]] {
+				return nil, "", fmt.Errorf("映射的键 '%s' 重复", keys[i])
+			}
+			seen[keys[i]] = true
+			entries[i] = MapEntry{Key: keys[i], Value: value}
+		}
+		return entries, dataType, nil
+	}
+	return values, dataType, nil
+}
+
+// collectionElementType 确定集合的元素类型：各元素的类型必须一致，整数按最大的值加宽，
+// 超出int64的整数无法作为集合元素；没有元素时必须显式指定类型
+func collectionElementType(elementType string, values []interface{}, types []string) (string, error) {
+	if len(values) == 0 && elementType == "" {
+		return "", fmt.Errorf("空集合必须用type指定元素类型，如 list<string>")
+	}
+	for i, value := range values {
+		dataType := types[i]
+		if IsIntegerType(dataType) {
+			dataType = integerType(value)
+			if dataType == "bigint" {
+				return "", fmt.Errorf("集合元素 %v 超出int64的范围", value)
+			}
+		}
+		switch {
+		case elementType == "":
+			elementType = dataType
+		case IsIntegerType(elementType) && IsIntegerType(dataType):
+			if integerRanks[dataType] > integerRanks[elementType] {
+				elementType = dataType
+			}
+		case elementType != dataType:
+			return "", fmt.Errorf("集合元素的类型不一致：%s 与 %s", elementType, dataType)
+		}
+	}
+	return elementType, nil
+}
+
+// convertCollectionText 按集合类型转换CSV单元格中的文本：列表的元素以分号分隔，映射的每项写作 键=值
+func convertCollectionText(text, dataType string) (interface{}, error) {
+	if err := checkCollectionTypeName(dataType); err != nil {
+		return nil, err
+	}
+	kind, _, _ := CollectionType(dataType)
+	node := &yaml.Node{Kind: yaml.SequenceNode}
+	if kind == "map" {
+		node.Kind = yaml.MappingNode
+	}
+	for _, item := range splitList(text) {
+		if kind == "map" {
+			key, value, found := strings.Cut(item, "=")
+			if !found {
+				return nil, fmt.Errorf("映射的项 '%s' 必须写作 键=值", item)
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: strings.TrimSpace(key)})
+			item = strings.TrimSpace(value)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: item})
+	}
+	value, _, err := parseCollectionNode(node, dataType)
+	return value, err
+}
+
+// CollectionText 返回集合值的文本，如 [jpg, png]、{us: a.example.com}，用于比较和报告
+func CollectionText(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []MapEntry:
+		items := make([]string, len(v))
+		for i, entry := range v {
+			items[i] = fmt.Sprintf("%s: %v", entry.Key, entry.Value)
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprint(value)
+}
+
+// checkCollectionGroup 校验集合值常量组：组内的常量都必须是集合值（各常量的集合类型可以不同），
+// 不能是位标志组、记录组、树形常量组或状态机组，也不能声明附加属性、子集或使用标签模板
+func checkCollectionGroup(group *ConstantGroup, diags *Diagnostics) {
+	if !IsCollectionGroup(group) {
+		return
+	}
+	if group.Flags || group.Record != nil || IsTreeGroup(group) || IsStateMachine(group) ||
+		len(group.Attributes) > 0 || len(group.Subsets) > 0 {
+		diags.errorf(group.Pos, "集合值常量组 '%s' 不能是位标志组、记录组、树形常量组或状态机组，也不能声明附加属性或子集", group.Name)
+		return
+	}
+	for _, constant := range group.Constants {
+		switch {
+		case !IsCollectionType(constant.Type):
+			diags.errorf(constant.ValuePos(), "常量 '%s' 的值不是列表或映射，集合值常量组 '%s' 中的常量都必须是集合值", constant.Name, group.Name)
+		case constant.Template != nil:
+			diags.errorf(constant.Pos, "集合值常量组 '%s' 的常量 '%s' 的标签不能含占位符", group.Name, constant.Name)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// csvReader CSV表格源文件读取器，便于在电子表格中维护常量
+//
+// 首行为表头，列的顺序任意，支持的列如下：
+//
+//	group,group_label,key,value,type,label,description,deprecated,tags,replaced_by,aliases,deprecated_aliases,parent,transitions,subsets
+//
+// key和value为必填列；type为空时按值推断类型；group为空时归入以文件名命名的常量组；
+// deprecated可以是true/false或废弃说明；tags和别名列中的多项以分号分隔；parent为同组内父常量的key；
+// transitions列出状态允许转换到的目标状态的key（以分号分隔），有该列值的常量组为状态机组；
+// subsets列出常量所属的命名子集（以分号分隔），子集成员按行的顺序排列；value写作 ${group.key} 时引用其他常量。每个常量组生成一个独立的ConstantsFile。
+//
+// 表头写作 attr:name:type 的列声明附加属性（type省略时为string），只有该列有值的常量组才声明这个属性，空单元格取类型的零值。
+// 表头写作 label:en 的列给出该语言的标签，label列为默认语言的标签。
+type csvReader struct{}
+
+// csvColumns 支持的CSV列，值表示是否必填
+var csvColumns = map[string]bool{
+	"group":       false,
+	"group_label": false,
+	"key":         true,
+	"value":       true,
+	"type":        false,
+	"label":       false,
+	"description": false,
+	"deprecated":  false,
+	"tags":        false,
+
+	"replaced_by":        false,
+	"aliases":            false,
+	"deprecated_aliases": false,
+	"parent":             false,
+	"transitions":        false,
+	"subsets":            false,
+}
+
+// csvAttributePrefix 附加属性列的表头前缀
+const csvAttributePrefix = "attr:"
+
+// csvLabelPrefix 按语言给出标签的列的表头前缀
+const csvLabelPrefix = "label:"
+
+// parseAttributeColumn 解析附加属性列表头中前缀之后的 name[:type] 部分
+func parseAttributeColumn(header string) (*Attribute, error) {
+	name, dataType, found := strings.Cut(header, ":")
+	if !found {
+		dataType = "string"
+	}
+	attribute := &Attribute{Name: strings.TrimSpace(name), Type: strings.ToLower(strings.TrimSpace(dataType))}
+	if err := checkAttributeDeclaration(attribute); err != nil {
+		return nil, err
+	}
+	return attribute, nil
+}
+
+// identifierPattern 常量名和组名必须是合法的标识符
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// splitList 拆分以分号分隔的多项值，忽略空项
+func splitList(text string) []string {
+	var items []string
+	for _, item := range strings.Split(text, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// utf8BOM 电子表格导出的CSV常带有的BOM头
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// Extensions 支持 .csv
+func (csvReader) Extensions() []string {
+	return []string{".csv"}
+}
+
+// splitGroups CSV中的每个常量组各自生成一个文件
+func (csvReader) splitGroups() bool {
+	return true
+}
+
+// Read 解析CSV文件，校验所有记录后一并报告出错的行
+func (csvReader) Read(data []byte, fileName string) (string, []*ConstantGroup, Diagnostics) {
+	var diags Diagnostics
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			diags.errorf(Position{Line: parseErr.Line, Column: parseErr.Column}, "语法错误: %v", parseErr.Err)
+		} else {
+			diags.errorf(Position{}, "语法错误: %v", err)
+		}
+		return "", nil, diags
+	}
+	if len(records) == 0 {
+		return "", nil, nil
+	}
+
+	// 解析表头
+	columns := make(map[string]int)
+	var attributeColumns []int
+	attributes := make(map[int]*Attribute)
+	labelColumns := make(map[int]string)
+	for i, name := range records[0] {
+		if header := strings.TrimSpace(name); strings.HasPrefix(strings.ToLower(header), csvLabelPrefix) {
+			locale := strings.TrimSpace(header[len(csvLabelPrefix):])
+			if !IsValidLocale(locale) {
+				diags.errorf(Position{Line: 1, Column: i + 1}, "标签列 '%s' 的语言 '%s' 不是合法的语言代码", header, locale)
+				continue
+			}
+			duplicated := false
+			for column, other := range labelColumns {
+				if other == locale {
+					diags.errorf(Position{Line: 1, Column: i + 1}, "%s标签列与第%d列重复", locale, column+1)
+					duplicated = true
+				}
+			}
+			if !duplicated {
+				labelColumns[i] = locale
+			}
+			continue
+		}
+		if header := strings.TrimSpace(name); strings.HasPrefix(strings.ToLower(header), csvAttributePrefix) {
+			attribute, err := parseAttributeColumn(header[len(csvAttributePrefix):])
+			if err != nil {
+				diags.errorf(Position{Line: 1, Column: i + 1}, "%v", err)
+				continue
+			}
+			duplicated := false
+			for _, column := range attributeColumns {
+				if attributes[column].Name == attribute.Name {
+					diags.errorf(Position{Line: 1, Column: i + 1}, "属性 '%s' 与第%d列重复", attribute.Name, column+1)
+					duplicated = true
+				}
+			}
+			if duplicated {
+				continue
+			}
+			attribute.Pos = Position{Line: 1, Column: i + 1}
+			attributeColumns = append(attributeColumns, i)
+			attributes[i] = attribute
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, known := csvColumns[name]; !known {
+			diags.errorf(Position{Line: 1, Column: i + 1}, "未知的列 '%s'", name)
+			continue
+		}
+		columns[name] = i
+	}
+	for name, required := range csvColumns {
+		if _, exists := columns[name]; required && !exists {
+			diags.errorf(Position{Line: 1}, "缺少必填列 '%s'", name)
+		}
+	}
+	if diags.HasErrors() {
+		return "", nil, diags
+	}
+
+	var groups []*ConstantGroup
+	groupsByName := make(map[string]*ConstantGroup)
+	keyRows := make(map[string]int)
+
+	for i, record := range records[1:] {
+		row := i + 2
+		get := func(column string) string {
+			index, exists := columns[column]
+			if !exists || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		// pos 返回当前行中指定列的位置
+		pos := func(column string) Position {
+			index, exists := columns[column]
+			if !exists {
+				return Position{Line: row}
+			}
+			return Position{Line: row, Column: index + 1}
+		}
+
+		// 跳过空行
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		if len(record) != len(records[0]) {
+			diags.errorf(Position{Line: row}, "有%d列，表头为%d列", len(record), len(records[0]))
+			continue
+		}
+
+		groupName := get("group")
+		if groupName == "" {
+			groupName = fileName
+		} else if !identifierPattern.MatchString(groupName) {
+			diags.errorf(pos("group"), "组名 '%s' 不是合法的标识符", groupName)
+			continue
+		}
+
+		key := get("key")
+		if key == "" {
+			diags.errorf(pos("key"), "key不能为空")
+			continue
+		}
+		if !identifierPattern.MatchString(key) {
+			diags.errorf(pos("key"), "key '%s' 不是合法的标识符", key)
+			continue
+		}
+
+		// 集合类型的value可以为空，表示空列表或空映射
+		valueText, dataType := get("value"), get("type")
+		if valueText == "" && !IsCollectionType(dataType) {
+			diags.errorf(pos("value"), "常量 '%s' 的value不能为空", key)
+			continue
+		}
+		var value interface{}
+		ref, err := parseReference(valueText)
+		switch {
+		case err != nil || ref != nil:
+			// 引用的值在所有文件解析完成后填充
+		case IsCollectionType(dataType):
+			value, err = convertCollectionText(valueText, dataType)
+		case dataType != "":
+			value, err = ConvertValue(valueText, dataType)
+		default:
+			value, dataType, err = parseScalarValue(&yaml.Node{Kind: yaml.ScalarNode, Value: valueText})
+		}
+		if err != nil {
+			diags.errorf(pos("value"), "常量 '%s' 的值无效: %v", key, err)
+			continue
+		}
+
+		// deprecated为true/false，或直接写废弃说明；填写replaced_by即视为废弃
+		deprecated, deprecatedMessage := false, ""
+		if text := get("deprecated"); text != "" {
+			if deprecated, err = strconv.ParseBool(text); err != nil {
+				deprecated, deprecatedMessage = true, text
+			}
+		}
+		replacedBy := get("replaced_by")
+		if replacedBy != "" {
+			deprecated = true
+		}
+
+		var aliases []Alias
+		for _, column := range []string{"aliases", "deprecated_aliases"} {
+			for _, alias := range splitList(get(column)) {
+				aliases = append(aliases, Alias{Name: alias, Deprecated: column == "deprecated_aliases"})
+			}
+		}
+
+		// 同组内key唯一
+		rowKey := groupName + "." + key
+		if firstRow, duplicated := keyRows[rowKey]; duplicated {
+			diags.errorf(pos("key"), "常量组 '%s' 中的key '%s' 与第%d行重复", groupName, key, firstRow)
+			continue
+		}
+		keyRows[rowKey] = row
+
+		group, exists := groupsByName[groupName]
+		if !exists {
+			group = &ConstantGroup{Name: groupName, Pos: pos("group")}
+			groupsByName[groupName] = group
+			groups = append(groups, group)
+		}
+		if group.Label == "" {
+			group.Label = get("group_label")
+		}
+
+		constant := &Constant{
+			Name:        key,
+			Type:        dataType,
+			Label:       get("label"),
+			Value:       value,
+			Description: get("description"),
+			Deprecated:  deprecated,
+			Tags:        splitList(get("tags")),
+			Parent:      get("parent"),
+			Literal:     integerLiteral(valueText, dataType),
+			Ref:         ref,
+			Pos:         pos("key"),
+			valuePos:    pos("value"),
+
+			DeprecatedMessage: deprecatedMessage,
+			ReplacedBy:        replacedBy,
+			Aliases:           aliases,
+		}
+		for _, column := range attributeColumns {
+			if text := strings.TrimSpace(record[column]); text != "" {
+				constant.setAttributeText(attributes[column].Name, text, Position{Line: row, Column: column + 1})
+			}
+		}
+		for column, locale := range labelColumns {
+			if text := strings.TrimSpace(record[column]); text != "" {
+				if constant.Labels == nil {
+					constant.Labels = make(map[string]string)
+				}
+				constant.Labels[locale] = text
+			}
+		}
+		group.Constants = append(group.Constants, constant)
+		if next := splitList(get("transitions")); len(next) > 0 {
+			group.Transitions = append(group.Transitions, &Transition{From: key, To: next, Pos: pos("transitions")})
+		}
+		for _, name := range splitList(get("subsets")) {
+			group.Subsets = addSubsetMember(group.Subsets, name, key, pos("subsets"))
+		}
+	}
+
+	for _, group := range groups {
+		if group.Label == "" {
+			group.Label = group.Name
+		}
+		// 属性列只对有值的常量组生效，各组使用独立的属性声明
+		for _, column := range attributeColumns {
+			for _, constant := range group.Constants {
+				if _, exists := constant.attributeTexts[attributes[column].Name]; exists {
+					attribute := *attributes[column]
+					group.Attributes = append(group.Attributes, &attribute)
+					break
+				}
+			}
+		}
+	}
+
+	var label string
+	if len(groups) > 0 {
+		label = groups[0].Label
+	}
+	return label, groups, diags
+}
+
+// addSubsetMember 把常量加入名为 name 的子集，子集不存在时按首次出现的位置新建
+func addSubsetMember(subsets []*Subset, name, member string, pos Position) []*Subset {
+	for _, subset := range subsets {
+		if subset.Name == name {
+			subset.Members = append(subset.Members, member)
+			return subsets
+		}
+	}
+	return append(subsets, &Subset{Name: name, Label: name, Members: []string{member}, Pos: pos})
+}
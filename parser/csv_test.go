@@ -0,0 +1,23 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVValuePosition(t *testing.T) {
+	data := "group,key,label,value\nstatus,on,开,1\nstatus,off,关,1.5\n"
+	_, groups, diags := csvReader{}.Read([]byte(data), "status")
+	groups = checkGroups(groups, &diags)
+	if len(groups) != 1 || len(diags) != 1 {
+		t.Fatalf("Read() = %d groups, %v; want one type mismatch", len(groups), diags)
+	}
+	diag := diags[0]
+	if !strings.Contains(diag.Message, "值类型为float") {
+		t.Fatalf("diagnostic = %v, want a type mismatch", diag)
+	}
+	// 第3行的value列（第4列），而不是key列
+	if diag.Pos != (Position{Line: 3, Column: 4}) {
+		t.Errorf("diagnostic position = %d:%d, want 3:4", diag.Pos.Line, diag.Pos.Column)
+	}
+}
@@ -0,0 +1,224 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Severity 诊断的严重程度
+type Severity int
+
+const (
+	SeverityWarning Severity = iota // 警告：不影响代码生成
+	SeverityError                   // 错误：阻止代码生成
+)
+
+// String 返回编译器风格的级别名称
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Position 源文件中的位置，行列均从1开始，0表示未知
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Diagnostic 解析过程中发现的问题，定位到源文件的行列
+type Diagnostic struct {
+	File     string   // 源文件路径
+	Pos      Position // 出错位置
+	Severity Severity // 严重程度
+	Message  string   // 问题描述
+}
+
+// String 按编译器风格格式化，如 data/user.yaml:3:5: error: 缺少注释
+func (d *Diagnostic) String() string {
+	var location []string
+	if d.File != "" {
+		location = append(location, d.File)
+	}
+	if d.Pos.Line > 0 {
+		location = append(location, strconv.Itoa(d.Pos.Line))
+		if d.Pos.Column > 0 {
+			location = append(location, strconv.Itoa(d.Pos.Column))
+		}
+	}
+
+	if len(location) == 0 {
+		return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", strings.Join(location, ":"), d.Severity, d.Message)
+}
+
+// Error 实现error接口，解析函数可直接以error返回带位置的诊断
+func (d *Diagnostic) Error() string {
+	return d.String()
+}
+
+// Diagnostics 诊断列表
+type Diagnostics []*Diagnostic
+
+// Count 统计指定级别的诊断数量
+func (ds Diagnostics) Count(severity Severity) int {
+	count := 0
+	for _, d := range ds {
+		if d.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// HasErrors 是否包含错误级别的诊断
+func (ds Diagnostics) HasErrors() bool {
+	return ds.Count(SeverityError) > 0
+}
+
+// errorf 追加一条错误
+func (ds *Diagnostics) errorf(pos Position, format string, args ...interface{}) {
+	*ds = append(*ds, errorAt(pos, format, args...))
+}
+
+// warnf 追加一条警告
+func (ds *Diagnostics) warnf(pos Position, format string, args ...interface{}) {
+	*ds = append(*ds, &Diagnostic{Pos: pos, Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)})
+}
+
+// add 追加解析函数返回的错误，不带位置的普通错误按错误级别记录
+func (ds *Diagnostics) add(err error) {
+	var diag *Diagnostic
+	if !errors.As(err, &diag) {
+		diag = &Diagnostic{Severity: SeverityError, Message: err.Error()}
+	}
+	*ds = append(*ds, diag)
+}
+
+// errorAt 构造指定位置的错误诊断
+func errorAt(pos Position, format string, args ...interface{}) *Diagnostic {
+	return &Diagnostic{Pos: pos, Severity: SeverityError, Message: fmt.Sprintf(format, args...)}
+}
+
+// checkGroups 校验各读取器产出的常量组：空组给出警告并剔除，
+// 组名和常量名须是合法的标识符（见 checkName），组名和同组内的常量名（含别名）不能重复，替代常量必须存在，属性值须符合声明的类型（见 checkAttributes），
+// 标签中的占位符须格式正确（见 checkLabelTemplates），同组内的值类型必须一致（见 checkGroupTypes）
+func checkGroups(groups []*ConstantGroup, diags *Diagnostics) []*ConstantGroup {
+	var valid []*ConstantGroup
+	groupLines := make(map[string]int)
+	for _, group := range groups {
+		for _, deletion := range group.Deletions {
+			diags.errorf(deletion.Pos, "delete指令只能用于覆盖层（--overlay），不能删除常量 '%s'", deletion.Name)
+		}
+		assignAutoValues(group, diags)
+		if len(group.Constants) == 0 {
+			diags.warnf(group.Pos, "常量组 '%s' 中没有常量，已忽略", group.Name)
+			continue
+		}
+		if line, duplicated := groupLines[group.Name]; duplicated {
+			diags.errorf(group.Pos, "常量组 '%s' 与第%d行重复", group.Name, line)
+			continue
+		}
+		groupLines[group.Name] = group.Pos.Line
+		checkName(group.Pos, "常量组名", group.Name, diags)
+
+		// 别名与常量共用同一个命名空间
+		constantLines := make(map[string]int)
+		for _, constant := range group.Constants {
+			if line, duplicated := constantLines[constant.Name]; duplicated {
+				diags.errorf(constant.Pos, "常量组 '%s' 中的常量 '%s' 与第%d行重复", group.Name, constant.Name, line)
+			}
+			constantLines[constant.Name] = constant.Pos.Line
+			checkName(constant.Pos, "常量名", constant.Name, diags)
+
+			for _, alias := range constant.Aliases {
+				if !identifierPattern.MatchString(alias.Name) {
+					diags.errorf(constant.Pos, "常量 '%s' 的别名 '%s' 不是合法的标识符", constant.Name, alias.Name)
+					continue
+				}
+				if line, duplicated := constantLines[alias.Name]; duplicated {
+					diags.errorf(constant.Pos, "常量 '%s' 的别名 '%s' 与第%d行重复", constant.Name, alias.Name, line)
+				}
+				constantLines[alias.Name] = constant.Pos.Line
+			}
+		}
+		for _, constant := range group.Constants {
+			if constant.ReplacedBy == "" {
+				continue
+			}
+			if _, exists := constantLines[constant.ReplacedBy]; !exists || constant.ReplacedBy == constant.Name {
+				diags.errorf(constant.Pos, "常量 '%s' 的替代常量 '%s' 不是常量组 '%s' 中的其他常量",
+					constant.Name, constant.ReplacedBy, group.Name)
+			}
+		}
+		checkTree(group, diags)
+		checkTransitions(group, diags)
+		checkSubsets(group, diags)
+		checkAttributes(group, diags)
+		checkLabelTemplates(group, diags)
+		checkCollectionGroup(group, diags)
+		checkRegexGroup(group, diags)
+		checkGroupTypes(group, diags)
+		valid = append(valid, group)
+	}
+	return valid
+}
+
+// checkName 校验常量组名或常量名：不是合法的标识符时生成的代码无法编译，报告错误；
+// 是目标语言的关键字时，生成器会转换大小写或转义（如Swift的反引号），仍能编译，只报告警告。kind 为诊断信息中名称的类别
+func checkName(pos Position, kind, name string, diags *Diagnostics) {
+	if !identifierPattern.MatchString(name) {
+		diags.errorf(pos, "%s '%s' 不是合法的标识符（只能包含字母、数字和下划线，不能以数字开头）", kind, name)
+		return
+	}
+	if languages := keywordLanguages(name); len(languages) > 0 {
+		diags.warnf(pos, "%s '%s' 是 %s 的关键字，生成的代码中会转换大小写或转义，建议改名", kind, name, strings.Join(languages, "、"))
+	}
+}
+
+// checkGroupTypes 将整数常量组统一加宽后校验组内值类型一致（集合值常量组和正则常量组除外），位标志组另外校验各标志的位，记录组另外校验主值；
+// 尚未解析的引用不参与校验，由 ResolveReferences 解析后再次校验
+func checkGroupTypes(group *ConstantGroup, diags *Diagnostics) {
+	widenIntegers(group)
+	if group.Flags {
+		checkFlags(group, diags)
+	}
+	if group.Record != nil {
+		checkRecord(group, diags)
+	}
+	if !group.Flags && group.Record == nil {
+		switch {
+		case IsTreeGroup(group):
+			checkNodeValues(group, "树形常量组", diags)
+		case IsStateMachine(group):
+			checkNodeValues(group, "状态机组", diags)
+		}
+		if len(group.Subsets) > 0 {
+			checkSubsetValues(group, diags)
+		}
+	}
+
+	// 集合值常量组的各常量分别生成，集合类型可以不同；正则常量组的类型已由 checkRegexGroup 校验
+	if IsCollectionGroup(group) || IsRegexGroup(group) {
+		return
+	}
+
+	var first *Constant
+	for _, constant := range group.Constants {
+		if constant.Value == nil {
+			continue
+		}
+		if first == nil {
+			first = constant
+			continue
+		}
+		if constant.Type != first.Type {
+			diags.errorf(constant.ValuePos(), "常量 '%s' 的值类型为%s，与常量组 '%s' 的%s类型不一致",
+				constant.Name, constant.Type, group.Name, first.Type)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckGroupsNames(t *testing.T) {
+	group := &ConstantGroup{Name: "status", Pos: Position{Line: 1, Column: 1}}
+	for i, name := range []string{"ok", "foo-bar", "1bad", "class", "None"} {
+		group.Constants = append(group.Constants, &Constant{
+			Name: name, Value: int64(i), Type: "int", Label: name, Pos: Position{Line: i + 2, Column: 3},
+		})
+	}
+	invalid := &ConstantGroup{Name: "my-group", Pos: Position{Line: 8, Column: 1}, Constants: []*Constant{
+		{Name: "a", Value: int64(1), Type: "int", Label: "A", Pos: Position{Line: 9, Column: 3}},
+	}}
+
+	var diags Diagnostics
+	checkGroups([]*ConstantGroup{group, invalid}, &diags)
+	want := []struct {
+		line     int
+		severity Severity
+		message  string
+	}{
+		{3, SeverityError, "常量名 'foo-bar' 不是合法的标识符"},
+		{4, SeverityError, "常量名 '1bad' 不是合法的标识符"},
+		{5, SeverityWarning, "常量名 'class' 是 Java、Kotlin、Swift、Python、TypeScript/JavaScript 的关键字"},
+		{6, SeverityWarning, "常量名 'None' 是 Python 的关键字"},
+		{8, SeverityError, "常量组名 'my-group' 不是合法的标识符"},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("checkGroups() = %v, want %d diagnostics", diags, len(want))
+	}
+	for i, w := range want {
+		diag := diags[i]
+		if diag.Pos.Line != w.line || diag.Severity != w.severity || !strings.Contains(diag.Message, w.message) {
+			t.Errorf("diagnostic %d = %v, want line %d %s containing %q", i, diag, w.line, w.severity, w.message)
+		}
+	}
+}
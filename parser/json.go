@@ -0,0 +1,301 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// jsonReader JSON格式源文件读取器
+//
+// JSON没有注释，标签通过字段显式给出，文件结构如下：
+//
+//	{
+//	  "label": "订单相关",
+//	  "constants": {"normal": 1, "admin": {"value": 2, "label": "管理员"}, "guest": {"value": 3, "label": {"zh": "访客", "en": "Guest"}}},
+//	  "groups": {
+//	    "order_status": {"label": "订单状态", "constants": {"pending": {"value": 1, "label": "待支付"}}}
+//	  }
+//	}
+//
+// 顶层constants归入以文件名命名的常量组，groups中的每一项各自成为一个常量组。
+// 文件顶层或常量组可以声明 "auto": "start=1, step=1"，值为null的常量按顺序自增赋值；
+// 声明 "flags": true 表示位标志组，"record": "primary=code" 表示记录组；"attributes": {"color": "string"} 声明附加属性，常量对象在attributes字段中给出属性值。值写作 "${group.key}" 时引用其他常量。
+// 声明 "transitions": {"pending": ["paid", "cancelled"]} 表示状态机组，列出各状态允许转换到的目标状态。
+// 声明 "subsets": {"active": ["pending", "paid"], "finished": {"tag": "final"}} 定义命名子集，成员直接列出或按标记选取。
+// 常量对象的 "parent" 字段指定同组内的父常量，也可以把子常量写在 "children" 对象中，构成树形常量组。
+// 覆盖层中的文件可以声明 "delete": ["guest"] 删除基础目录中的常量。
+type jsonReader struct{}
+
+// Extensions 支持 .json
+func (jsonReader) Extensions() []string {
+	return []string{".json"}
+}
+
+// Read 解析JSON文件
+func (jsonReader) Read(data []byte, fileName string) (string, []*ConstantGroup, Diagnostics) {
+	var diags Diagnostics
+
+	// 先用标准库校验语法，以得到准确的JSON错误信息
+	var syntaxCheck interface{}
+	if err := json.Unmarshal(data, &syntaxCheck); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			diags.errorf(offsetPosition(data, syntaxErr.Offset), "语法错误: %v", err)
+		} else {
+			diags.errorf(Position{}, "语法错误: %v", err)
+		}
+		return "", nil, diags
+	}
+
+	// JSON是YAML的子集，借助YAML节点树保留键的顺序和行号
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", nil, yamlErrors(err)
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		diags.errorf(nodePos(root), "顶层必须是对象")
+		return "", nil, diags
+	}
+
+	var label string
+	var constants []*Constant
+	var groups []*ConstantGroup
+	var auto *AutoIncrement
+	var flags bool
+	var record *RecordSchema
+	var attributes []*Attribute
+	var transitions []*Transition
+	var subsets []*Subset
+	var deletions []Deletion
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		fieldKey, fieldNode := root.Content[i], root.Content[i+1]
+
+		switch fieldKey.Value {
+		case "label":
+			if err := fieldNode.Decode(&label); err != nil {
+				diags.errorf(nodePos(fieldNode), "label无效: %s", decodeErrorMessage(err))
+			}
+		case "auto":
+			auto = parseAutoNode(fieldNode, &diags)
+		case "flags":
+			if err := fieldNode.Decode(&flags); err != nil {
+				diags.errorf(nodePos(fieldNode), "flags无效: %s", decodeErrorMessage(err))
+			}
+		case "record":
+			record = parseRecordNode(fieldNode, &diags)
+		case "attributes":
+			attributes = parseJSONAttributes(fieldNode, &diags)
+		case "transitions":
+			transitions = parseJSONTransitions(fieldNode, &diags)
+		case "subsets":
+			subsets = parseJSONSubsets(fieldNode, &diags)
+		case "delete":
+			deletions = parseJSONDeletions(fieldNode, &diags)
+		case "constants":
+			constants = parseJSONConstants(fieldNode, &diags)
+		case "groups":
+			groups = parseJSONGroups(fieldNode, &diags)
+		default:
+			diags.errorf(nodePos(fieldKey), "未知字段 '%s'", fieldKey.Value)
+		}
+	}
+
+	// 顶层常量组成以文件名命名的常量组
+	if len(constants) > 0 || len(deletions) > 0 {
+		fileGroup := &ConstantGroup{
+			Name:       fileName,
+			Label:      label,
+			Constants:  constants,
+			Auto:       auto,
+			Flags:      flags,
+			Attributes: attributes,
+			Record:     record,
+			Deletions:  deletions,
+			Pos:        nodePos(root),
+
+			Transitions: transitions,
+			Subsets:     subsets,
+		}
+		groups = append([]*ConstantGroup{fileGroup}, groups...)
+	}
+
+	return label, groups, diags
+}
+
+// offsetPosition 将字节偏移量换算为行列位置，列按字符计数
+func offsetPosition(data []byte, offset int64) Position {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:])
+	if column == 0 {
+		column = 1
+	}
+	return Position{Line: line, Column: column}
+}
+
+// parseJSONGroups 解析groups对象，每个键是一个常量组
+func parseJSONGroups(node *yaml.Node, diags *Diagnostics) []*ConstantGroup {
+	if node.Kind != yaml.MappingNode {
+		diags.errorf(nodePos(node), "groups必须是对象")
+		return nil
+	}
+
+	var groups []*ConstantGroup
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, groupNode := node.Content[i], node.Content[i+1]
+		name := keyNode.Value
+		if groupNode.Kind != yaml.MappingNode {
+			diags.errorf(nodePos(groupNode), "常量组 '%s' 必须是对象", name)
+			continue
+		}
+
+		group := &ConstantGroup{
+			Name:  name,
+			Label: name,
+			Pos:   nodePos(keyNode),
+		}
+		for j := 0; j+1 < len(groupNode.Content); j += 2 {
+			fieldKey, fieldNode := groupNode.Content[j], groupNode.Content[j+1]
+
+			switch fieldKey.Value {
+			case "label":
+				if err := fieldNode.Decode(&group.Label); err != nil {
+					diags.errorf(nodePos(fieldNode), "常量组 '%s' 的label无效: %s", name, decodeErrorMessage(err))
+				}
+			case "auto":
+				group.Auto = parseAutoNode(fieldNode, diags)
+			case "flags":
+				if err := fieldNode.Decode(&group.Flags); err != nil {
+					diags.errorf(nodePos(fieldNode), "常量组 '%s' 的flags无效: %s", name, decodeErrorMessage(err))
+				}
+			case "record":
+				group.Record = parseRecordNode(fieldNode, diags)
+			case "attributes":
+				group.Attributes = parseJSONAttributes(fieldNode, diags)
+			case "transitions":
+				group.Transitions = parseJSONTransitions(fieldNode, diags)
+			case "subsets":
+				group.Subsets = parseJSONSubsets(fieldNode, diags)
+			case "delete":
+				group.Deletions = parseJSONDeletions(fieldNode, diags)
+			case "constants":
+				group.Constants = parseJSONConstants(fieldNode, diags)
+			default:
+				diags.errorf(nodePos(fieldKey), "常量组 '%s' 的未知字段 '%s'", name, fieldKey.Value)
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// parseJSONAttributes 解析attributes对象，每项写作 "name": "type" 或 {"type": ..., "default": ..., "label": ...}
+func parseJSONAttributes(node *yaml.Node, diags *Diagnostics) []*Attribute {
+	if node.Kind != yaml.MappingNode {
+		diags.errorf(nodePos(node), "attributes必须是对象")
+		return nil
+	}
+	return parseAttributeDeclarations(node, diags)
+}
+
+// parseJSONTransitions 解析transitions对象，每项写作 "源状态": ["目标状态", ...]
+func parseJSONTransitions(node *yaml.Node, diags *Diagnostics) []*Transition {
+	if node.Kind != yaml.MappingNode {
+		diags.errorf(nodePos(node), "transitions必须是对象")
+		return nil
+	}
+	return parseTransitionsNode(node, diags)
+}
+
+// parseJSONSubsets 解析subsets对象，每项写作 "子集名": ["成员", ...] 或 {"tag": ..., "label": ...}
+func parseJSONSubsets(node *yaml.Node, diags *Diagnostics) []*Subset {
+	if node.Kind != yaml.MappingNode {
+		diags.errorf(nodePos(node), "subsets必须是对象")
+		return nil
+	}
+	return parseSubsetsNode(node, diags)
+}
+
+// parseJSONDeletions 解析delete数组，每项是要删除的常量名
+func parseJSONDeletions(node *yaml.Node, diags *Diagnostics) []Deletion {
+	if !isDeleteList(node) {
+		diags.errorf(nodePos(node), "delete必须是常量名的数组")
+		return nil
+	}
+	return parseDeleteNode(node)
+}
+
+// parseJSONConstants 解析constants对象，值可以是标量或带value字段的对象
+func parseJSONConstants(node *yaml.Node, diags *Diagnostics) []*Constant {
+	if node.Kind != yaml.MappingNode {
+		diags.errorf(nodePos(node), "constants必须是对象")
+		return nil
+	}
+
+	var constants []*Constant
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		name := keyNode.Value
+
+		if valueNode.Kind == yaml.MappingNode {
+			constant, err := parseConstantMapping(name, valueNode, "")
+			if err != nil {
+				diags.add(err)
+				continue
+			}
+			constant.Pos = nodePos(keyNode)
+			if children := childrenNode(valueNode); children != nil {
+				constant.children = parseJSONConstants(children, diags)
+			}
+			constants = append(constants, constant)
+			continue
+		}
+
+		// null由auto指令赋值
+		if valueNode.ShortTag() == "!!null" {
+			constants = append(constants, &Constant{
+				Name: name,
+				Pos:  nodePos(keyNode),
+			})
+			continue
+		}
+
+		ref, err := scalarReference(valueNode)
+		if err != nil {
+			diags.errorf(nodePos(valueNode), "常量 '%s' 的值无效: %v", name, err)
+			continue
+		}
+		if ref != nil {
+			constants = append(constants, &Constant{
+				Name: name,
+				Ref:  ref,
+				Pos:  nodePos(keyNode),
+			})
+			continue
+		}
+
+		value, dataType, err := parseScalarValue(valueNode)
+		if err != nil {
+			diags.errorf(nodePos(valueNode), "常量 '%s' 的值无效: %v", name, err)
+			continue
+		}
+		constants = append(constants, &Constant{
+			Name:  name,
+			Type:  dataType,
+			Value: value,
+			Pos:   nodePos(keyNode),
+		})
+	}
+
+	return constants
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale 未指定默认语言时使用的语言代码，行内注释和单语言的label均视为该语言的标签
+const DefaultLocale = "zh"
+
+// localePattern 语言代码，如 zh、en、ja、zh-Hant、en_US
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([_-][A-Za-z0-9]{2,8})*$`)
+
+// IsValidLocale 判断是否为合法的语言代码
+func IsValidLocale(locale string) bool {
+	return localePattern.MatchString(locale)
+}
+
+// parseLocalizedLabel 解析按语言给出的标签，如 {zh: 管理员, en: Admin}
+func parseLocalizedLabel(constant *Constant, node *yaml.Node) error {
+	constant.Labels = make(map[string]string)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		locale := strings.TrimSpace(keyNode.Value)
+		if !IsValidLocale(locale) {
+			return errorAt(nodePos(keyNode), "常量 '%s' 的标签语言 '%s' 不是合法的语言代码（如 zh、en、zh-Hant）", constant.Name, locale)
+		}
+		if valueNode.Kind != yaml.ScalarNode || valueNode.ShortTag() == "!!null" {
+			return errorAt(nodePos(valueNode), "常量 '%s' 的%s标签必须是字符串", constant.Name, locale)
+		}
+		constant.Labels[locale] = valueNode.Value
+	}
+	return nil
+}
+
+// ApplyLocale 在所有文件解析完成后确定按语言给出标签的常量在默认语言下的标签，并解析其中的占位符
+//
+// 标签中有默认语言的文本时以其作为常量的标签；没有时沿用行内注释，两者都没有时报告错误。
+// 应在 ResolveReferences 之前调用，以便引用方沿用目标常量的标签。
+func ApplyLocale(files []*ConstantsFile, defaultLocale string) Diagnostics {
+	var all Diagnostics
+	for _, file := range files {
+		var diags Diagnostics
+		for _, group := range file.Groups {
+			for _, constant := range group.Constants {
+				if constant.Labels == nil {
+					continue
+				}
+				if label, exists := constant.Labels[defaultLocale]; exists {
+					constant.Label = label
+				} else if constant.Label == "" {
+					diags.errorf(constant.Pos, "常量 '%s' 的标签缺少默认语言 '%s' 的文本", constant.Name, defaultLocale)
+					continue
+				}
+				checkLabelTemplate(constant, &diags)
+			}
+		}
+		for _, diag := range diags {
+			diag.File = file.FilePath
+		}
+		all = append(all, diags...)
+	}
+	return all
+}
+
+// LabelLocales 返回常量组中给出过标签的语言，默认语言在前，其余按字母顺序排列；
+// 组内没有按语言给出的标签时返回nil
+func LabelLocales(group *ConstantGroup, defaultLocale string) []string {
+	seen := make(map[string]bool)
+	var others []string
+	for _, constant := range group.Constants {
+		for locale := range constant.Labels {
+			if !seen[locale] && locale != defaultLocale {
+				others = append(others, locale)
+			}
+			seen[locale] = true
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+	sort.Strings(others)
+	return append([]string{defaultLocale}, others...)
+}
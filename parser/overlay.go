@@ -0,0 +1,395 @@
+package parser
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Deletion 覆盖层中由delete指令删除的常量
+type Deletion struct {
+	Name string   // 要删除的常量名
+	Pos  Position // 在覆盖层源文件中的位置
+}
+
+// isDeleteDirectiveNode 判断键值节点是否为delete指令，值为常量名的列表，如 delete: [guest, legacy]
+func isDeleteDirectiveNode(keyNode, valueNode *yaml.Node) bool {
+	return strings.TrimSpace(keyNode.Value) == "delete" && isDeleteList(valueNode)
+}
+
+// isDeleteList 判断节点是否为常量名的列表
+func isDeleteList(node *yaml.Node) bool {
+	if node.Kind != yaml.SequenceNode {
+		return false
+	}
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDeleteNode 解析delete指令列出的常量名
+func parseDeleteNode(node *yaml.Node) []Deletion {
+	var deletions []Deletion
+	for _, item := range node.Content {
+		deletions = append(deletions, Deletion{Name: strings.TrimSpace(item.Value), Pos: nodePos(item)})
+	}
+	return deletions
+}
+
+// overlayMerger 按顺序合并各覆盖层，记录需要重新校验的常量组
+type overlayMerger struct {
+	files         []*ConstantsFile
+	defaultLocale string
+	overridden    map[*Constant]string              // 常量的值最近一次被覆盖时所在的覆盖层文件
+	changed       []*ConstantGroup                  // 合并后需要重新校验的常量组，按首次改动的顺序排列
+	changedFiles  map[*ConstantGroup]string         // 重新校验时诊断所属的源文件
+	addedFiles    map[string]*ConstantsFile         // 覆盖层新增常量组所在的新文件，键为覆盖层文件路径
+	owners        map[*ConstantGroup]*ConstantsFile // 常量组所在的文件
+	diags         Diagnostics
+}
+
+// ApplyOverlays 按顺序把各覆盖层（--overlay 目录中的源文件，见 ParseOverlayFile）合并到基础目录的常量文件中，
+// 返回合并后的文件列表
+//
+// 覆盖层与基础目录按命名空间（子目录）和常量组名对应，组内按常量名合并：
+//   - 覆盖：同名常量中覆盖层给出的字段（值、标签、按语言的标签、描述、废弃、别名、标记、属性值）
+//     替换基础常量的对应字段，按语言的标签逐个语言合并，未给出的字段保持不变
+//   - 新增：只在覆盖层中出现的常量追加到常量组末尾；只在覆盖层中出现的常量组加入同名的文件，
+//     没有同名文件时作为新文件生成。新增的常量和常量组均报告警告，以免拼错的名称被悄悄加入
+//   - 删除：delete指令列出的常量从常量组中删除，删除不存在的常量报告错误
+//
+// 状态机组的 transitions 指令按源状态合并，覆盖层中同一源状态的转换替换基础目录中的声明；删除常量时一并删除其转换。
+// 命名子集按子集名合并，覆盖层中的同名子集替换基础目录中的定义。
+// 常量组的标签、auto 指令以基础目录为准；覆盖层不能改变常量组的 flags、record 和 attributes 声明，
+// 也不能改变常量值的类型（整数位宽除外），否则报告错误。后面的覆盖层以不同的值再次覆盖同一常量时报告警告。
+// 合并后的常量组重新进行自增赋值和校验。应在 ApplyLocale 之前调用。
+func ApplyOverlays(files []*ConstantsFile, layers [][]*ConstantsFile, defaultLocale string) ([]*ConstantsFile, Diagnostics) {
+	m := &overlayMerger{
+		files:         files,
+		defaultLocale: defaultLocale,
+		overridden:    make(map[*Constant]string),
+		changedFiles:  make(map[*ConstantGroup]string),
+		addedFiles:    make(map[string]*ConstantsFile),
+		owners:        make(map[*ConstantGroup]*ConstantsFile),
+	}
+	for _, file := range files {
+		for _, group := range file.Groups {
+			m.owners[group] = file
+		}
+	}
+
+	for _, layer := range layers {
+		for _, overlay := range layer {
+			for _, group := range overlay.Groups {
+				if base := m.findGroup(overlay.Namespace, group.Name); base != nil {
+					m.mergeGroup(overlay.FilePath, base, group)
+				} else {
+					m.addGroup(overlay, group)
+				}
+			}
+		}
+	}
+	if m.diags.HasErrors() {
+		return m.files, m.diags
+	}
+
+	// 重新校验合并后的常量组，校验未通过或已没有常量的组不再生成
+	dropped := make(map[*ConstantGroup]bool)
+	for _, group := range m.changed {
+		var diags Diagnostics
+		if len(checkGroups([]*ConstantGroup{group}, &diags)) == 0 {
+			dropped[group] = true
+		}
+		for _, diag := range diags {
+			diag.File = m.changedFiles[group]
+		}
+		m.diags = append(m.diags, diags...)
+	}
+
+	var merged []*ConstantsFile
+	for _, file := range m.files {
+		var groups []*ConstantGroup
+		for _, group := range file.Groups {
+			if !dropped[group] {
+				groups = append(groups, group)
+			}
+		}
+		file.Groups = groups
+		if len(groups) > 0 {
+			merged = append(merged, file)
+		}
+	}
+	return merged, m.diags
+}
+
+// findGroup 在基础目录及已合并的新文件中按命名空间和组名查找常量组
+func (m *overlayMerger) findGroup(namespace, name string) *ConstantGroup {
+	for _, file := range m.files {
+		if file.Namespace != namespace {
+			continue
+		}
+		for _, group := range file.Groups {
+			if group.Name == name {
+				return group
+			}
+		}
+	}
+	return nil
+}
+
+// markChanged 记录需要重新校验的常量组，file 为常量组所在的源文件
+func (m *overlayMerger) markChanged(group *ConstantGroup, file string) {
+	if _, exists := m.changedFiles[group]; !exists {
+		m.changed = append(m.changed, group)
+		m.changedFiles[group] = file
+	}
+}
+
+// errorf 追加一条覆盖层文件中的错误
+func (m *overlayMerger) errorf(file string, pos Position, format string, args ...interface{}) {
+	m.diags.errorf(pos, format, args...)
+	m.diags[len(m.diags)-1].File = file
+}
+
+// warnf 追加一条覆盖层文件中的警告
+func (m *overlayMerger) warnf(file string, pos Position, format string, args ...interface{}) {
+	m.diags.warnf(pos, format, args...)
+	m.diags[len(m.diags)-1].File = file
+}
+
+// addGroup 加入只在覆盖层中定义的常量组：有同一命名空间的同名文件时加入该文件，否则作为新文件
+func (m *overlayMerger) addGroup(overlay *ConstantsFile, group *ConstantGroup) {
+	for _, deletion := range group.Deletions {
+		m.errorf(overlay.FilePath, deletion.Pos, "要删除的常量 '%s' 不存在：常量组 '%s' 只在覆盖层中定义", deletion.Name, group.Name)
+	}
+	group.Deletions = nil
+	if len(group.Constants) == 0 {
+		return
+	}
+	m.warnf(overlay.FilePath, group.Pos, "常量组 '%s' 只在覆盖层中定义，已作为新的常量组加入", group.Name)
+	m.warnMissingLabels(overlay.FilePath, group.Constants)
+
+	var target *ConstantsFile
+	for _, file := range m.files {
+		if file.Namespace == overlay.Namespace && file.FileName == overlay.FileName {
+			target = file
+			break
+		}
+	}
+	if target == nil {
+		target = m.addedFiles[overlay.FilePath]
+	}
+	if target == nil {
+		target = &ConstantsFile{
+			FileName:     overlay.FileName,
+			FilePath:     overlay.FilePath,
+			Namespace:    overlay.Namespace,
+			Label:        overlay.Label,
+			LastModified: overlay.LastModified,
+		}
+		m.addedFiles[overlay.FilePath] = target
+		m.files = append(m.files, target)
+	}
+	target.Groups = append(target.Groups, group)
+	m.owners[group] = target
+	m.markChanged(group, overlay.FilePath)
+}
+
+// mergeGroup 把覆盖层中的常量组合并到基础常量组
+func (m *overlayMerger) mergeGroup(path string, base, group *ConstantGroup) {
+	if group.Flags && !base.Flags {
+		m.errorf(path, group.Pos, "覆盖层不能把常量组 '%s' 改为位标志组", group.Name)
+	}
+	if group.Record != nil && (base.Record == nil || *group.Record != *base.Record) {
+		m.errorf(path, group.Pos, "覆盖层中常量组 '%s' 的record指令与基础目录不同", group.Name)
+	}
+	if len(group.Attributes) > 0 {
+		m.errorf(path, group.Attributes[0].Pos, "覆盖层不能声明常量组 '%s' 的附加属性，属性值在常量的attributes字段中覆盖", group.Name)
+	}
+
+	overridden := make(map[string]bool)
+	for _, constant := range group.Constants {
+		overridden[constant.Name] = true
+	}
+	for _, deletion := range group.Deletions {
+		if overridden[deletion.Name] {
+			m.errorf(path, deletion.Pos, "常量 '%s' 在同一覆盖层中既被删除又被覆盖", deletion.Name)
+			continue
+		}
+		index := constantIndex(base, deletion.Name)
+		if index < 0 {
+			m.errorf(path, deletion.Pos, "要删除的常量 '%s' 在常量组 '%s' 中不存在", deletion.Name, group.Name)
+			continue
+		}
+		delete(m.overridden, base.Constants[index])
+		base.Constants = append(base.Constants[:index], base.Constants[index+1:]...)
+		base.Transitions = removeTransition(base.Transitions, deletion.Name)
+	}
+	m.mergeTransitions(base, group)
+	m.mergeSubsets(base, group)
+
+	var added []*Constant
+	for _, constant := range group.Constants {
+		index := constantIndex(base, constant.Name)
+		if index < 0 {
+			if constant.Value == nil && constant.Ref == nil && base.Auto == nil {
+				m.errorf(path, constant.Pos, "覆盖层中新增的常量 '%s' 缺少值（常量组 '%s' 没有 auto 指令）", constant.Name, group.Name)
+				continue
+			}
+			m.warnf(path, constant.Pos, "常量 '%s' 只在覆盖层中定义，已加入常量组 '%s'", constant.Name, group.Name)
+			added = append(added, constant)
+			base.Constants = append(base.Constants, constant)
+			continue
+		}
+		m.mergeConstant(path, base.Constants[index], constant)
+	}
+	m.warnMissingLabels(path, added)
+
+	m.markChanged(base, m.owners[base].FilePath)
+}
+
+// mergeTransitions 合并覆盖层中的transitions指令：同一源状态的转换替换基础目录中的声明，新的源状态追加到末尾
+func (m *overlayMerger) mergeTransitions(base, group *ConstantGroup) {
+	for _, transition := range group.Transitions {
+		index := -1
+		for i, existing := range base.Transitions {
+			if existing.From == transition.From {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			base.Transitions = append(base.Transitions, transition)
+			continue
+		}
+		base.Transitions[index] = transition
+	}
+}
+
+// mergeSubsets 合并覆盖层中的subsets指令：同名子集替换基础目录中的定义，新的子集追加到末尾
+func (m *overlayMerger) mergeSubsets(base, group *ConstantGroup) {
+	for _, subset := range group.Subsets {
+		index := -1
+		for i, existing := range base.Subsets {
+			if existing.Name == subset.Name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			base.Subsets = append(base.Subsets, subset)
+			continue
+		}
+		base.Subsets[index] = subset
+	}
+}
+
+// removeTransition 删除源状态为 from 的转换；常量组仍是状态机组时保持非nil
+func removeTransition(transitions []*Transition, from string) []*Transition {
+	if transitions == nil {
+		return nil
+	}
+	kept := []*Transition{}
+	for _, transition := range transitions {
+		if transition.From != from {
+			kept = append(kept, transition)
+		}
+	}
+	return kept
+}
+
+// warnMissingLabels 覆盖层中新增的常量没有基础常量的标签可沿用，缺少标签时与基础目录一样报告警告
+func (m *overlayMerger) warnMissingLabels(path string, constants []*Constant) {
+	for _, constant := range constants {
+		if constant.Label == "" && constant.Labels == nil {
+			m.warnf(path, constant.Pos, "常量 '%s' 缺少行内注释，标签为空", constant.Name)
+		}
+	}
+}
+
+// mergeConstant 用覆盖层常量给出的字段替换基础常量的对应字段
+func (m *overlayMerger) mergeConstant(path string, base, overlay *Constant) {
+	if overlay.Value != nil || overlay.Ref != nil {
+		if base.Value != nil && overlay.Value != nil && valueKind(base.Type) != valueKind(overlay.Type) {
+			m.errorf(path, overlay.Pos, "覆盖层中常量 '%s' 的值类型为%s，与基础目录中的%s类型不同", overlay.Name, overlay.Type, base.Type)
+			return
+		}
+		if previous, exists := m.overridden[base]; exists && previous != path && constantValueText(base) != constantValueText(overlay) {
+			m.warnf(path, overlay.Pos, "常量 '%s' 的值已由覆盖层 '%s' 覆盖为 %s，此处再次覆盖为 %s",
+				overlay.Name, previous, constantValueText(base), constantValueText(overlay))
+		}
+		base.Value = overlay.Value
+		base.Type = overlay.Type
+		base.Literal = overlay.Literal
+		base.Ref = overlay.Ref
+		base.AutoAssigned = false
+		base.valuePos = Position{}
+		m.overridden[base] = path
+	}
+
+	if overlay.Label != "" {
+		base.Label = overlay.Label
+		if base.Labels != nil {
+			base.Labels[m.defaultLocale] = overlay.Label
+		}
+	}
+	for locale, label := range overlay.Labels {
+		if base.Labels == nil {
+			base.Labels = make(map[string]string)
+		}
+		base.Labels[locale] = label
+	}
+	if overlay.Description != "" {
+		base.Description = overlay.Description
+	}
+	if overlay.Deprecated {
+		base.Deprecated = true
+		if overlay.DeprecatedMessage != "" {
+			base.DeprecatedMessage = overlay.DeprecatedMessage
+		}
+		if overlay.ReplacedBy != "" {
+			base.ReplacedBy = overlay.ReplacedBy
+		}
+	}
+	if len(overlay.Aliases) > 0 {
+		base.Aliases = overlay.Aliases
+	}
+	if len(overlay.Tags) > 0 {
+		base.Tags = overlay.Tags
+	}
+	if overlay.Parent != "" {
+		base.Parent = overlay.Parent
+	}
+	for name, text := range overlay.attributeTexts {
+		base.setAttributeText(name, text.Text, text.Pos)
+	}
+}
+
+// constantIndex 返回常量组中指定常量的下标，不存在时返回-1
+func constantIndex(group *ConstantGroup, name string) int {
+	for i, constant := range group.Constants {
+		if constant.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// valueKind 返回值类型的种类，各种位宽的整数视为同一种
+func valueKind(dataType string) string {
+	if IsIntegerType(dataType) {
+		return "int"
+	}
+	return dataType
+}
+
+// constantValueText 返回常量的值或引用的文本，用于比较和报告
+func constantValueText(constant *Constant) string {
+	if constant.Ref != nil {
+		return constant.Ref.String()
+	}
+	return CollectionText(constant.Value)
+}
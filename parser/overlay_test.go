@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSources 在临时目录中写入源文件，返回各文件按 parse 解析后的结果
+func writeSources(t *testing.T, sources map[string]string, parse func(string) ([]*ConstantsFile, Diagnostics, error)) []*ConstantsFile {
+	t.Helper()
+	dir := t.TempDir()
+	var files []*ConstantsFile
+	for name, content := range sources {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		parsed, diags, err := parse(path)
+		if err != nil || diags.HasErrors() {
+			t.Fatalf("parse %s: %v %v", name, err, diags)
+		}
+		files = append(files, parsed...)
+	}
+	return files
+}
+
+// groupValues 返回常量组中各常量的 名称=值 列表
+func groupValues(group *ConstantGroup) string {
+	var values []string
+	for _, constant := range group.Constants {
+		values = append(values, constant.Name+"="+constantValueText(constant))
+	}
+	return strings.Join(values, " ")
+}
+
+// diagnosticMessages 返回指定级别诊断的描述
+func diagnosticMessages(diags Diagnostics, severity Severity) []string {
+	var messages []string
+	for _, diag := range diags {
+		if diag.Severity == severity {
+			messages = append(messages, diag.Message)
+		}
+	}
+	return messages
+}
+
+const overlayBase = `# 用户角色
+user_role: # 用户角色
+  normal: 1 # 普通用户
+  admin: 2 # 管理员
+  guest: 3 # 访客
+`
+
+func TestApplyOverlays(t *testing.T) {
+	base := writeSources(t, map[string]string{"user_role.yaml": overlayBase}, ParseFile)
+	first := writeSources(t, map[string]string{"user_role.yaml": `user_role:
+  admin: # 超级管理员
+  guest: 30
+  owner: 9 # 所有者
+  delete: [normal]
+`}, ParseOverlayFile)
+	second := writeSources(t, map[string]string{"user_role.yaml": `user_role:
+  guest: 31
+extra: # 额外
+  x: 1 # X
+`}, ParseOverlayFile)
+
+	files, diags := ApplyOverlays(base, [][]*ConstantsFile{first, second}, "")
+	if diags.HasErrors() {
+		t.Fatalf("ApplyOverlays() errors: %v", diags)
+	}
+	if len(files) != 1 || len(files[0].Groups) != 2 {
+		t.Fatalf("ApplyOverlays() = %d files, want 1 file with the added group", len(files))
+	}
+
+	group := files[0].Groups[0]
+	if got, want := groupValues(group), "admin=2 guest=31 owner=9"; got != want {
+		t.Errorf("merged constants = %q, want %q", got, want)
+	}
+	if got := group.Constants[0].Label; got != "超级管理员" {
+		t.Errorf("admin label = %q, want the overlay label", got)
+	}
+	if got := group.Constants[1].Label; got != "访客" {
+		t.Errorf("guest label = %q, want the base label to be kept", got)
+	}
+	if got, want := groupValues(files[0].Groups[1]), "x=1"; files[0].Groups[1].Name != "extra" || got != want {
+		t.Errorf("added group = %s %q, want extra %q", files[0].Groups[1].Name, got, want)
+	}
+
+	warnings := diagnosticMessages(diags, SeverityWarning)
+	for _, want := range []string{"常量 'owner' 只在覆盖层中定义", "此处再次覆盖为 31", "常量组 'extra' 只在覆盖层中定义"} {
+		found := false
+		for _, warning := range warnings {
+			found = found || strings.Contains(warning, want)
+		}
+		if !found {
+			t.Errorf("warnings %q, want one containing %q", warnings, want)
+		}
+	}
+}
+
+func TestApplyOverlayErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay string
+		want    string
+	}{
+		{"删除不存在的常量", "user_role:\n  delete: [owner]\n", "要删除的常量 'owner' 在常量组 'user_role' 中不存在"},
+		{"删除并覆盖同一常量", "user_role:\n  admin: 5\n  delete: [admin]\n", "既被删除又被覆盖"},
+		{"改变值的类型", "user_role:\n  admin: \"2\"\n", "与基础目录中的int类型不同"},
+		{"改为位标志组", "user_role: # 用户角色\n  flags: true\n  admin: 2\n", "不能把常量组 'user_role' 改为位标志组"},
+		{"合并后重新校验", "user_role:\n  guest: {replaced_by: normal}\n  delete: [normal]\n", "替代常量 'normal' 不是常量组 'user_role' 中的其他常量"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := writeSources(t, map[string]string{"user_role.yaml": overlayBase}, ParseFile)
+			overlay := writeSources(t, map[string]string{"user_role.yaml": tt.overlay}, ParseOverlayFile)
+			_, diags := ApplyOverlays(base, [][]*ConstantsFile{overlay}, "")
+			errors := diagnosticMessages(diags, SeverityError)
+			if len(errors) == 0 || !strings.Contains(strings.Join(errors, "\n"), tt.want) {
+				t.Errorf("ApplyOverlays() errors = %q, want one containing %q", errors, tt.want)
+			}
+		})
+	}
+}
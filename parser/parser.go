@@ -2,8 +2,8 @@ package parser
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -12,147 +12,225 @@ import (
 	"golang.org/x/text/language"
 )
 
+// Alias 常量的别名，生成与常量同值的另一个名称
+type Alias struct {
+	Name       string // 别名
+	Deprecated bool   // 是否为废弃的旧名称，废弃时以常量本身作为替代
+}
+
 // Constant 表示单个常量定义
 type Constant struct {
-	Name  string      // 常量名称
-	Type  string      // 数据类型 (int, string)
-	Label string      // 中文标签/注释
-	Value interface{} // 常量值
+	Name              string                 // 常量名称
+	Type              string                 // 数据类型 (int, int64, bigint, float, bool, string)
+	Label             string                 // 中文标签/注释，按语言给出标签时为默认语言的标签
+	Labels            map[string]string      // 按语言给出的标签（语言代码 -> 文本），只写了单一标签时为nil
+	Value             interface{}            // 常量值
+	Description       string                 // 详细描述
+	Deprecated        bool                   // 是否已废弃
+	DeprecatedMessage string                 // 废弃说明，为空时使用默认说明
+	ReplacedBy        string                 // 替代该常量的同组常量名
+	Aliases           []Alias                // 与常量共享同一个值的别名
+	AliasOf           string                 // 别名所指向的常量名，仅由生成器展开别名时设置
+	Parent            string                 // 树形常量组中父常量的名称，根常量为空
+	Tags              []string               // 附加标记
+	Attributes        map[string]interface{} // 附加属性的值（未给出的属性为默认值），由常量组的属性声明决定
+	Template          *LabelTemplate         // 标签中的占位符，标签不含占位符时为nil
+	Literal           string                 // 整数在源文件中的写法（如 0x1F、0b1010、1_000），十进制无分隔时为空
+	AutoAssigned      bool                   // 值由auto指令自动分配
+	Ref               *Reference             // 对其他常量的引用（${group.key}），值由 ResolveReferences 填充
+	Pos               Position               // 在源文件中的位置
+
+	attributeTexts map[string]attributeText // 源文件中给出的属性值，由 checkAttributes 转换为 Attributes
+	valuePos       Position                 // 值所在的位置（CSV的value列），未记录时为零值，见 ValuePos
+	children       []*Constant              // 嵌套在children中的子常量，由 flattenChildren 展开到常量组中
+}
+
+// ValuePos 返回报告值的问题（如类型不一致）时使用的位置：CSV中为value列，其他格式为常量的位置
+func (c *Constant) ValuePos() Position {
+	if c.valuePos.Line > 0 {
+		return c.valuePos
+	}
+	return c.Pos
 }
 
 // ConstantGroup 表示一组常量
 type ConstantGroup struct {
-	Name      string      // 组名称
-	Label     string      // 组描述
-	Constants []*Constant // 常量列表
+	Name        string         // 组名称
+	Label       string         // 组描述
+	Constants   []*Constant    // 常量列表
+	Auto        *AutoIncrement // 自增赋值规则，nil表示不自增
+	Flags       bool           // 位标志组：值均为2的幂，可按位组合
+	Attributes  []*Attribute   // 附加属性声明，组内每个常量都有这些属性
+	Record      *RecordSchema  // 记录组结构，nil表示普通常量组
+	Transitions []*Transition  // 状态机组中各状态允许的转换，nil表示普通常量组
+	Subsets     []*Subset      // 命名子集，按源文件顺序排列
+	Deletions   []Deletion     // 覆盖层中由delete指令删除的常量，合并到基础常量组后清空
+	Pos         Position       // 在源文件中的位置
 }
 
 // ConstantsFile 表示解析后的完整文件信息
 type ConstantsFile struct {
 	FileName     string           // 文件名（不含扩展名）
 	FilePath     string           // 原始文件路径
+	Namespace    string           // 命名空间（相对输入目录的子目录，以/分隔，根目录为空）
 	Label        string           // 文件描述
 	Groups       []*ConstantGroup // 常量组列表
 	LastModified time.Time        // 文件最后修改时间
 }
 
-// ParseYAMLFile 解析单个YAML文件
-func ParseYAMLFile(filePath string) (*ConstantsFile, error) {
-	// 读取文件内容
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("读取文件失败: %w", err)
+// ConvertValue 将文本按指定的数据类型转换为常量值
+//
+// 整数支持 0x/0o/0b 前缀和数字间的下划线分隔，值在int64范围内时为int64，超出时为*big.Int；
+// int会在解析完成后按组内最大的值自动加宽（见 widenIntegers），int64和bigint用于显式指定位宽；
+// regex的值为正则表达式，转换时校验其语法。
+func ConvertValue(text string, dataType string) (interface{}, error) {
+	switch dataType {
+	case "int", "int64", "bigint":
+		bigVal, ok := parseInteger(text)
+		if !ok {
+			return nil, fmt.Errorf("'%s' 不是有效的整数", text)
+		}
+		if bigVal.IsInt64() {
+			return bigVal.Int64(), nil
+		}
+		if dataType == "int64" {
+			return nil, fmt.Errorf("'%s' 超出int64的范围", text)
+		}
+		return bigVal, nil
+	case "float":
+		floatVal, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsInf(floatVal, 0) || math.IsNaN(floatVal) {
+			return nil, fmt.Errorf("'%s' 不是有效的浮点数", text)
+		}
+		return floatVal, nil
+	case "bool":
+		boolVal, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' 不是有效的布尔值", text)
+		}
+		return boolVal, nil
+	case "string":
+		return text, nil
+	case "regex":
+		if err := checkRegex(text); err != nil {
+			return nil, err
+		}
+		return text, nil
+	default:
+		return nil, fmt.Errorf("不支持的类型 '%s'", dataType)
 	}
+}
 
-	// 获取文件信息
-	fileInfo, err := os.Stat(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("获取文件信息失败: %w", err)
+// parseInteger 解析整数字面量，支持正负号、0x/0o/0b前缀（不区分大小写）和数字间的下划线；
+// 无前缀时按十进制解析（前导0不表示八进制）
+func parseInteger(text string) (*big.Int, bool) {
+	sign, digits, base := splitIntegerLiteral(text)
+	if digits == "" || strings.HasPrefix(digits, "_") || strings.HasSuffix(digits, "_") ||
+		strings.Contains(digits, "__") || strings.ContainsAny(digits, "+-") {
+		return nil, false
 	}
 
-	// 提取文件名（不含扩展名）
-	fileName := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
-
-	// 解析YAML并提取注释
-	label, constants, err := parseYAMLWithComments(data)
-	if err != nil {
-		return nil, fmt.Errorf("解析YAML失败: %w", err)
+	value, ok := new(big.Int).SetString(strings.ReplaceAll(digits, "_", ""), base)
+	if !ok {
+		return nil, false
+	}
+	if sign == "-" {
+		value.Neg(value)
 	}
+	return value, true
+}
 
-	// 创建常量组
-	group := &ConstantGroup{
-		Name:      fileName,
-		Label:     label,
-		Constants: constants,
+// splitIntegerLiteral 将整数字面量拆分为符号、数字部分和进制
+func splitIntegerLiteral(text string) (sign string, digits string, base int) {
+	digits = text
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
 	}
 
-	return &ConstantsFile{
-		FileName:     fileName,
-		FilePath:     filePath,
-		Label:        label,
-		Groups:       []*ConstantGroup{group},
-		LastModified: fileInfo.ModTime(),
-	}, nil
-}
-
-// parseYAMLWithComments 解析YAML文件并提取注释
-func parseYAMLWithComments(data []byte) (string, []*Constant, error) {
-	lines := strings.Split(string(data), "\n")
-	
-	var label string
-	var constants []*Constant
-	
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		
-		// 跳过空行
-		if line == "" {
-			continue
+	base = 10
+	if len(digits) > 2 && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
 		}
-		
-		// 提取文件标签（第一行注释）
-		if strings.HasPrefix(line, "#") && label == "" {
-			label = strings.TrimSpace(strings.TrimPrefix(line, "#"))
-			continue
-		}
-		
-		// 解析常量行
-		if !strings.HasPrefix(line, "#") && strings.Contains(line, ":") {
-			constant, err := parseConstantLine(line)
-			if err != nil {
-				continue // 跳过无效行
-			}
-			constants = append(constants, constant)
+		if base != 10 {
+			digits = digits[2:]
 		}
 	}
-	
-	return label, constants, nil
+	return sign, digits, base
 }
 
-// parseConstantLine 解析单行常量定义
-func parseConstantLine(line string) (*Constant, error) {
-	// 分割键值对和注释
-	parts := strings.Split(line, "#")
-	if len(parts) < 2 {
-		return nil, fmt.Errorf("缺少注释")
+// integerLiteral 返回需要在生成代码中保留的整数写法：
+// 非十进制或带下划线分隔时返回原文（前缀统一为小写），普通十进制或非整数类型返回空；
+// 带前导0的十进制（如 0_10）在Go、Java中会被当作八进制，在Python、JavaScript中无法编译，按十进制输出
+func integerLiteral(text string, dataType string) string {
+	sign, digits, base := splitIntegerLiteral(text)
+	if !IsIntegerType(dataType) || base == 10 && (!strings.Contains(digits, "_") || len(digits) > 1 && digits[0] == '0') {
+		return ""
 	}
-	
-	// 解析键值对
-	kvPart := strings.TrimSpace(parts[0])
-	commentPart := strings.TrimSpace(parts[1])
-	
-	// 分割键和值
-	kvParts := strings.SplitN(kvPart, ":", 2)
-	if len(kvParts) != 2 {
-		return nil, fmt.Errorf("无效的键值对格式")
+
+	prefix := map[int]string{16: "0x", 8: "0o", 2: "0b"}[base]
+	if sign == "+" {
+		sign = ""
 	}
-	
-	name := strings.TrimSpace(kvParts[0])
-	valueStr := strings.TrimSpace(kvParts[1])
-	
-	// 移除引号
-	valueStr = strings.Trim(valueStr, `"'`)
-	
-	// 推断类型和解析值
-	var value interface{}
-	var dataType string
-	
-	// 尝试解析为整数
-	if intVal, err := strconv.Atoi(valueStr); err == nil {
-		value = intVal
-		dataType = "int"
-	} else {
-		// 默认为字符串
-		value = valueStr
-		dataType = "string"
+	return sign + prefix + digits
+}
+
+// integerRanks 整数类型按位宽由窄到宽的次序
+var integerRanks = map[string]int{
+	"int":    1,
+	"int64":  2,
+	"bigint": 3,
+}
+
+// IsIntegerType 判断数据类型是否为整数类型（int、int64、bigint）
+func IsIntegerType(dataType string) bool {
+	return integerRanks[dataType] > 0
+}
+
+// integerType 返回能容纳整数值的最窄整数类型：32位以内为int，64位以内为int64，否则为bigint
+func integerType(value interface{}) string {
+	switch v := value.(type) {
+	case int64:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return "int64"
+		}
+	case *big.Int:
+		return "bigint"
 	}
-	
-	return &Constant{
-		Name:  name,
-		Type:  dataType,
-		Label: commentPart,
-		Value: value,
-	}, nil
+	return "int"
+}
+
+// widenIntegers 将整数常量组的所有常量统一为组内所需的最宽整数类型，
+// 以便各语言使用同一个足以容纳全部值的类型（如Java的long）
+func widenIntegers(group *ConstantGroup) {
+	widest := ""
+	for _, constant := range group.Constants {
+		if !IsIntegerType(constant.Type) {
+			return
+		}
+		for _, dataType := range []string{constant.Type, integerType(constant.Value)} {
+			if integerRanks[dataType] > integerRanks[widest] {
+				widest = dataType
+			}
+		}
+	}
+	for _, constant := range group.Constants {
+		constant.Type = widest
+	}
+}
+
+// maxSafeInteger JavaScript的number能精确表示的最大整数（Number.MAX_SAFE_INTEGER）
+const maxSafeInteger = 1<<53 - 1
+
+// IsSafeInteger 判断整数值能否被JavaScript的number精确表示
+func IsSafeInteger(value interface{}) bool {
+	v, ok := value.(int64)
+	return ok && v >= -maxSafeInteger && v <= maxSafeInteger
 }
 
 // ToGoName 将下划线命名转换为Go风格的驼峰命名
@@ -215,6 +293,8 @@ func GetGoType(dataType string) string {
 	switch dataType {
 	case "int":
 		return "int"
+	case "int64":
+		return "int64"
 	case "string":
 		return "string"
 	case "float":
@@ -229,7 +309,7 @@ func GetGoType(dataType string) string {
 // GetPythonType 获取Python语言对应的类型
 func GetPythonType(dataType string) string {
 	switch dataType {
-	case "int":
+	case "int", "int64", "bigint":
 		return "int"
 	case "string":
 		return "str"
@@ -247,6 +327,8 @@ func GetJavaType(dataType string) string {
 	switch dataType {
 	case "int":
 		return "int"
+	case "int64":
+		return "long"
 	case "string":
 		return "String"
 	case "float":
@@ -263,6 +345,8 @@ func GetSwiftType(dataType string) string {
 	switch dataType {
 	case "int":
 		return "Int"
+	case "int64":
+		return "Int64"
 	case "string":
 		return "String"
 	case "float":
@@ -279,6 +363,8 @@ func GetKotlinType(dataType string) string {
 	switch dataType {
 	case "int":
 		return "Int"
+	case "int64":
+		return "Long"
 	case "string":
 		return "String"
 	case "float":
@@ -293,8 +379,10 @@ func GetKotlinType(dataType string) string {
 // GetTypeScriptType 获取TypeScript语言对应的类型
 func GetTypeScriptType(dataType string) string {
 	switch dataType {
-	case "int", "float":
+	case "int", "int64", "float":
 		return "number"
+	case "bigint":
+		return "bigint"
 	case "string":
 		return "string"
 	case "bool":
@@ -313,11 +401,14 @@ func GetJavaScriptType(dataType string) string {
 func FormatValue(value interface{}, dataType string, lang string) string {
 	caser := cases.Title(language.English)
 	valueStr := fmt.Sprintf("%v", value)
-	
+	if dataType == "float" {
+		valueStr = formatFloat(value)
+	}
+
 	switch lang {
 	case "python":
 		if dataType == "string" {
-			return fmt.Sprintf(`"%s"`, valueStr)
+			return quoteString(valueStr, '"', lang)
 		}
 		if dataType == "bool" {
 			return caser.String(strings.ToLower(valueStr))
@@ -325,25 +416,104 @@ func FormatValue(value interface{}, dataType string, lang string) string {
 		return valueStr
 	case "go":
 		if dataType == "string" {
-			return fmt.Sprintf(`"%s"`, valueStr)
+			return strconv.Quote(valueStr)
 		}
 		return valueStr
 	case "java", "kotlin":
 		if dataType == "string" {
-			return fmt.Sprintf(`"%s"`, valueStr)
+			return quoteString(valueStr, '"', lang)
 		}
-		return valueStr
+		// Kotlin不接受 -9223372036854775808L 字面量
+		if dataType == "int64" && lang == "kotlin" && value == int64(math.MinInt64) {
+			return "(-9223372036854775807L - 1L)"
+		}
+		return valueStr + integerSuffix(dataType, lang)
 	case "swift":
 		if dataType == "string" {
-			return fmt.Sprintf(`"%s"`, valueStr)
+			return quoteString(valueStr, '"', lang)
 		}
 		return valueStr
 	case "typescript", "javascript":
 		if dataType == "string" {
-			return fmt.Sprintf(`'%s'`, valueStr)
+			return quoteString(valueStr, '\'', lang)
 		}
-		return valueStr
+		return valueStr + integerSuffix(dataType, lang)
 	default:
 		return valueStr
 	}
 }
+
+// FormatConstantValue 格式化常量值，整数尽量保留源文件中的写法（十六进制、八进制、二进制、下划线分隔），
+// 目标语言不支持该写法时按十进制输出
+func FormatConstantValue(constant *Constant, dataType string, lang string) string {
+	if constant.Literal == "" || !IsIntegerType(dataType) {
+		return FormatValue(constant.Value, dataType, lang)
+	}
+
+	literal := constant.Literal
+	if sign, digits, base := splitIntegerLiteral(literal); base == 8 {
+		switch lang {
+		case "java":
+			// Java的八进制以前导0表示
+			literal = sign + "0" + digits
+		case "kotlin":
+			// Kotlin不支持八进制字面量
+			return FormatValue(constant.Value, dataType, lang)
+		}
+	}
+	if dataType == "int64" && lang == "kotlin" && constant.Value == int64(math.MinInt64) {
+		return FormatValue(constant.Value, dataType, lang)
+	}
+	return literal + integerSuffix(dataType, lang)
+}
+
+// integerSuffix 返回整数字面量在目标语言中需要的类型后缀，如Java/Kotlin的long写作 1L，BigInt写作 1n
+func integerSuffix(dataType string, lang string) string {
+	switch {
+	case dataType == "int64" && (lang == "java" || lang == "kotlin"):
+		return "L"
+	case dataType == "bigint" && (lang == "typescript" || lang == "javascript"):
+		return "n"
+	default:
+		return ""
+	}
+}
+
+// formatFloat 格式化浮点数，整数值也保留小数点（如 1.0），以免被目标语言当作整数
+func formatFloat(value interface{}) string {
+	floatVal, ok := value.(float64)
+	if !ok {
+		return fmt.Sprintf("%v", value)
+	}
+
+	text := strconv.FormatFloat(floatVal, 'g', -1, 64)
+	if !strings.ContainsAny(text, ".e") {
+		text += ".0"
+	}
+	return text
+}
+
+// quoteString 生成带转义的字符串字面量
+func quoteString(value string, quote rune, lang string) string {
+	var builder strings.Builder
+	builder.WriteRune(quote)
+	for _, r := range value {
+		switch {
+		case r == quote || r == '\\':
+			builder.WriteRune('\\')
+			builder.WriteRune(r)
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case r == '$' && lang == "kotlin":
+			builder.WriteString(`\$`)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteRune(quote)
+	return builder.String()
+}
@@ -0,0 +1,84 @@
+package parser
+
+import "testing"
+
+func TestParseInteger(t *testing.T) {
+	tests := []struct {
+		text  string
+		value string
+		ok    bool
+	}{
+		{"1_000", "1000", true},
+		{"0_10", "10", true},
+		{"007", "7", true},
+		{"-0x1F", "-31", true},
+		{"0o755", "493", true},
+		{"0b1010", "10", true},
+		{"0x_FF", "", false},
+		{"1__000", "", false},
+		{"1_", "", false},
+		{"0x", "", false},
+	}
+	for _, tt := range tests {
+		value, ok := parseInteger(tt.text)
+		if ok != tt.ok {
+			t.Errorf("parseInteger(%q) ok = %v, want %v", tt.text, ok, tt.ok)
+			continue
+		}
+		if ok && value.String() != tt.value {
+			t.Errorf("parseInteger(%q) = %s, want %s", tt.text, value, tt.value)
+		}
+	}
+}
+
+func TestIntegerLiteral(t *testing.T) {
+	tests := []struct {
+		text     string
+		dataType string
+		want     string
+	}{
+		{"1_000", "int", "1_000"},
+		{"-1_000", "int", "-1_000"},
+		{"0_10", "int", ""},
+		{"-0_10", "int", ""},
+		{"007", "int", ""},
+		{"0_0", "int", ""},
+		{"42", "int", ""},
+		{"0X1F", "int", "0x1F"},
+		{"+0o7_55", "int64", "0o7_55"},
+		{"0b1010", "int", "0b1010"},
+		{"1_000", "string", ""},
+	}
+	for _, tt := range tests {
+		if got := integerLiteral(tt.text, tt.dataType); got != tt.want {
+			t.Errorf("integerLiteral(%q, %s) = %q, want %q", tt.text, tt.dataType, got, tt.want)
+		}
+	}
+}
+
+func TestFormatConstantValue(t *testing.T) {
+	tests := []struct {
+		text string
+		lang string
+		want string
+	}{
+		{"0_10", "go", "10"},
+		{"0_10", "python", "10"},
+		{"0_10", "javascript", "10"},
+		{"0_10", "java", "10"},
+		{"1_000", "python", "1_000"},
+		{"0o755", "java", "0755"},
+		{"0o755", "kotlin", "493"},
+		{"0o755", "go", "0o755"},
+	}
+	for _, tt := range tests {
+		value, err := ConvertValue(tt.text, "int")
+		if err != nil {
+			t.Fatalf("ConvertValue(%q): %v", tt.text, err)
+		}
+		constant := &Constant{Value: value, Type: "int", Literal: integerLiteral(tt.text, "int")}
+		if got := FormatConstantValue(constant, "int", tt.lang); got != tt.want {
+			t.Errorf("FormatConstantValue(%q, %s) = %q, want %q", tt.text, tt.lang, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultRecordPrimary 记录组未指定主值字段名时使用的字段名
+const defaultRecordPrimary = "value"
+
+// RecordSchema 记录组的结构，由 record 指令声明：常量值作为主值字段，附加属性作为其余字段。
+// 生成器为记录组输出记录类型和各常量对应的记录，class和const模式下生成相同的代码，记录按源文件顺序排列
+type RecordSchema struct {
+	Primary string // 主值字段名，生成代码按该字段反查记录
+	Label   string // 主值字段的说明
+}
+
+// parseRecordDirective 解析record指令：true 表示记录组，主值字段名为value，false 表示普通常量组；
+// 也可写作 "primary=code, label=错误码"，未给出的参数取默认值
+func parseRecordDirective(text string) (*RecordSchema, error) {
+	text = strings.TrimSpace(text)
+	if enabled, err := strconv.ParseBool(text); err == nil {
+		if !enabled {
+			return nil, nil
+		}
+		return &RecordSchema{Primary: defaultRecordPrimary}, nil
+	}
+
+	record := &RecordSchema{Primary: defaultRecordPrimary}
+	for _, part := range strings.Split(text, ",") {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("record指令 '%s' 无效，应写作 primary=code, label=错误码", text)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "primary":
+			if !identifierPattern.MatchString(value) {
+				return nil, fmt.Errorf("record指令的主值字段名 '%s' 不是合法的标识符", value)
+			}
+			if value != defaultRecordPrimary && (constantFields[value] || reservedAttributeNames[value]) {
+				return nil, fmt.Errorf("record指令的主值字段名 '%s' 与常量的内置字段或生成的成员冲突", value)
+			}
+			record.Primary = value
+		case "label":
+			record.Label = value
+		default:
+			return nil, fmt.Errorf("record指令的未知参数 '%s'", strings.TrimSpace(key))
+		}
+	}
+	return record, nil
+}
+
+// isRecordDirective 判断键值是否为record指令（值为布尔值或 key=value 形式），
+// 以免与名为record的普通常量混淆
+func isRecordDirective(key string, value string, isBool bool) bool {
+	return key == "record" && (isBool || strings.Contains(value, "="))
+}
+
+// checkRecord 校验记录组：不能同时是位标志组，主值字段不能与附加属性重名，
+// 主值只能是整数或字符串且互不相同，以便按主值反查记录
+func checkRecord(group *ConstantGroup, diags *Diagnostics) {
+	if group.Flags {
+		diags.errorf(group.Pos, "常量组 '%s' 不能同时是位标志组和记录组", group.Name)
+		return
+	}
+	for _, attribute := range group.Attributes {
+		if attribute.Name == group.Record.Primary {
+			diags.errorf(attribute.Pos, "属性 '%s' 与记录组 '%s' 的主值字段重名", attribute.Name, group.Name)
+		}
+	}
+
+	values := make(map[string]*Constant)
+	for _, constant := range group.Constants {
+		if constant.Value == nil {
+			// 引用在解析后再校验
+			continue
+		}
+		if !IsIntegerType(constant.Type) && constant.Type != "string" {
+			diags.errorf(constant.ValuePos(), "记录组 '%s' 的常量 '%s' 的值类型为%s，主值只能是整数或字符串", group.Name, constant.Name, constant.Type)
+			continue
+		}
+		key := fmt.Sprint(constant.Value)
+		if other, duplicated := values[key]; duplicated {
+			diags.errorf(constant.ValuePos(), "记录组 '%s' 的常量 '%s' 与 '%s' 的主值 %v 相同（同一记录的其他名称可写作别名）",
+				group.Name, constant.Name, other.Name, constant.Value)
+			continue
+		}
+		values[key] = constant
+	}
+}
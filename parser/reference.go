@@ -0,0 +1,261 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Reference 常量值对另一个常量的引用，源文件中写作 ${group.key}
+type Reference struct {
+	Group string // 引用的常量组名
+	Key   string // 引用的常量名
+
+	// 以下字段由 ResolveReferences 填充
+	Target        *Constant      // 引用的目标常量
+	TargetGroup   *ConstantGroup // 目标常量所在的常量组
+	TargetFile    *ConstantsFile // 目标常量所在的文件
+	SameNamespace bool           // 目标与引用方位于同一命名空间（生成器据此决定能否输出符号引用）
+}
+
+// String 返回引用在源文件中的写法
+func (r *Reference) String() string {
+	return fmt.Sprintf("${%s.%s}", r.Group, r.Key)
+}
+
+// referencePattern 常量引用 ${group.key}
+var referencePattern = regexp.MustCompile(`^\$\{\s*([A-Za-z_][A-Za-z0-9_]*)\.([A-Za-z_][A-Za-z0-9_]*)\s*\}$`)
+
+// parseReference 解析引用写法，不是引用时返回nil；以 ${ 开头、} 结尾但格式不对时返回错误
+func parseReference(text string) (*Reference, error) {
+	text = strings.TrimSpace(text)
+	if match := referencePattern.FindStringSubmatch(text); match != nil {
+		return &Reference{Group: match[1], Key: match[2]}, nil
+	}
+	if strings.HasPrefix(text, "${") && strings.HasSuffix(text, "}") {
+		return nil, fmt.Errorf("引用 '%s' 无效，应写作 ${常量组.常量}", text)
+	}
+	return nil, nil
+}
+
+// resolveState 引用解析过程中常量的状态
+type resolveState int
+
+const (
+	unresolved resolveState = iota // 尚未解析
+	resolving                      // 正在解析（用于检测循环引用）
+	resolved                       // 解析成功
+	failed                         // 解析失败
+)
+
+// constantOwner 常量所在的文件和常量组
+type constantOwner struct {
+	file  *ConstantsFile
+	group *ConstantGroup
+}
+
+// referenceResolver 跨文件解析常量引用
+type referenceResolver struct {
+	groups map[string][]constantOwner // 组名 -> 定义该组的位置（不同文件可能有同名组）
+	owners map[*Constant]constantOwner
+	state  map[*Constant]resolveState
+	stack  []*Constant // 正在解析的引用链
+	diags  Diagnostics
+}
+
+// CheckGroupNames 检查同一命名空间的各文件中常量组是否重名：生成的代码中常量组名处于同一作用域
+// （如Go的同一个包、TypeScript的索引文件），重名的常量组无法同时生成
+func CheckGroupNames(files []*ConstantsFile) Diagnostics {
+	type groupOwner struct {
+		file  *ConstantsFile
+		group *ConstantGroup
+	}
+	seen := make(map[string]groupOwner) // 命名空间/组名 -> 首次定义的位置
+	var diags Diagnostics
+	for _, file := range files {
+		for _, group := range file.Groups {
+			key := file.Namespace + "/" + group.Name
+			first, exists := seen[key]
+			if !exists {
+				seen[key] = groupOwner{file: file, group: group}
+				continue
+			}
+			if first.file == file {
+				// 同一文件中的重名组在解析时已报告
+				continue
+			}
+			diag := errorAt(group.Pos, "常量组 '%s' 已在 %s:%d 中定义，同一命名空间中的常量组不能重名",
+				group.Name, first.file.FilePath, first.group.Pos.Line)
+			diag.File = file.FilePath
+			diags = append(diags, diag)
+		}
+	}
+	return diags
+}
+
+// ResolveReferences 在所有文件解析完成后解析常量之间的引用
+//
+// 引用的值、类型和字面写法取自目标常量，未填写标签时沿用目标常量的标签；引用链可以多级，
+// 但不能形成循环。同一命名空间中的组名不会重复（见 CheckGroupNames），组名在多个命名空间中出现时，
+// 优先选择同一命名空间中的常量组。解析后重新校验包含引用的常量组的值类型。
+func ResolveReferences(files []*ConstantsFile) Diagnostics {
+	r := &referenceResolver{
+		groups: make(map[string][]constantOwner),
+		owners: make(map[*Constant]constantOwner),
+		state:  make(map[*Constant]resolveState),
+	}
+	for _, file := range files {
+		for _, group := range file.Groups {
+			owner := constantOwner{file: file, group: group}
+			r.groups[group.Name] = append(r.groups[group.Name], owner)
+			for _, constant := range group.Constants {
+				r.owners[constant] = owner
+			}
+		}
+	}
+
+	for _, file := range files {
+		for _, group := range file.Groups {
+			hasReference := false
+			for _, constant := range group.Constants {
+				if constant.Ref != nil {
+					hasReference = true
+					r.resolve(constant)
+				}
+			}
+			if !hasReference {
+				continue
+			}
+
+			// 引用的类型确定后，重新统一整数宽度并校验类型
+			var diags Diagnostics
+			checkGroupTypes(group, &diags)
+			for _, diag := range diags {
+				diag.File = file.FilePath
+			}
+			r.diags = append(r.diags, diags...)
+		}
+	}
+	return r.diags
+}
+
+// errorf 在常量所在文件中记录一条错误
+func (r *referenceResolver) errorf(constant *Constant, format string, args ...interface{}) {
+	diag := errorAt(constant.Pos, format, args...)
+	diag.File = r.owners[constant].file.FilePath
+	r.diags = append(r.diags, diag)
+}
+
+// qualifiedName 返回常量的 group.key 形式名称
+func (r *referenceResolver) qualifiedName(constant *Constant) string {
+	return r.owners[constant].group.Name + "." + constant.Name
+}
+
+// resolve 解析常量的引用（目标仍是引用时递归解析），返回是否成功
+func (r *referenceResolver) resolve(constant *Constant) bool {
+	switch r.state[constant] {
+	case resolved:
+		return true
+	case failed:
+		return false
+	case resolving:
+		// 从引用链中首次出现该常量的位置开始即为循环
+		var chain []string
+		for i := len(r.stack) - 1; i >= 0; i-- {
+			chain = append([]string{r.qualifiedName(r.stack[i])}, chain...)
+			if r.stack[i] == constant {
+				break
+			}
+		}
+		chain = append(chain, r.qualifiedName(constant))
+		r.errorf(r.stack[len(r.stack)-1], "循环引用: %s", strings.Join(chain, " -> "))
+		return false
+	}
+	if constant.Ref == nil {
+		r.state[constant] = resolved
+		return true
+	}
+
+	r.state[constant] = resolving
+	r.stack = append(r.stack, constant)
+	ok := r.resolveReference(constant)
+	r.stack = r.stack[:len(r.stack)-1]
+	if ok {
+		r.state[constant] = resolved
+	} else {
+		r.state[constant] = failed
+	}
+	return ok
+}
+
+// resolveReference 查找引用的目标并复制其值
+func (r *referenceResolver) resolveReference(constant *Constant) bool {
+	ref := constant.Ref
+	owner := r.owners[constant]
+
+	target, targetOwner, err := r.lookup(ref, owner.file)
+	if err != nil {
+		r.errorf(constant, "常量 '%s' %v", constant.Name, err)
+		return false
+	}
+	if !r.resolve(target) {
+		return false
+	}
+
+	// 显式指定的类型可以比目标更宽（如 int 引用为 int64），其余情况必须一致
+	dataType := target.Type
+	if constant.Type != "" && constant.Type != target.Type {
+		if !IsIntegerType(constant.Type) || !IsIntegerType(target.Type) ||
+			integerRanks[integerType(target.Value)] > integerRanks[constant.Type] {
+			r.errorf(constant, "常量 '%s' 声明为%s类型，但引用的 %s 为%s类型",
+				constant.Name, constant.Type, ref, target.Type)
+			return false
+		}
+		dataType = constant.Type
+	}
+
+	constant.Type = dataType
+	constant.Value = target.Value
+	constant.Literal = target.Literal
+	if constant.Label == "" {
+		constant.Label = target.Label
+		constant.Labels = target.Labels
+		constant.Template = target.Template
+	}
+	ref.Target = target
+	ref.TargetGroup = targetOwner.group
+	ref.TargetFile = targetOwner.file
+	ref.SameNamespace = targetOwner.file.Namespace == owner.file.Namespace
+	return true
+}
+
+// lookup 按组名和常量名查找引用目标
+func (r *referenceResolver) lookup(ref *Reference, from *ConstantsFile) (*Constant, constantOwner, error) {
+	candidates := r.groups[ref.Group]
+	if len(candidates) == 0 {
+		return nil, constantOwner{}, fmt.Errorf("引用的常量组 '%s' 不存在", ref.Group)
+	}
+
+	// 同名组出现在多个命名空间中时，优先同一命名空间
+	owner, found := candidates[0], len(candidates) == 1
+	for _, candidate := range candidates {
+		if !found && candidate.file.Namespace == from.Namespace {
+			owner, found = candidate, true
+		}
+	}
+	if !found {
+		var paths []string
+		for _, candidate := range candidates {
+			paths = append(paths, candidate.file.FilePath)
+		}
+		return nil, constantOwner{}, fmt.Errorf("引用的常量组 '%s' 在多个文件中定义（%s），无法确定引用目标",
+			ref.Group, strings.Join(paths, "、"))
+	}
+
+	for _, constant := range owner.group.Constants {
+		if constant.Name == ref.Key {
+			return constant, owner, nil
+		}
+	}
+	return nil, constantOwner{}, fmt.Errorf("引用的常量 '%s.%s' 不存在", ref.Group, ref.Key)
+}
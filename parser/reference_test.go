@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// referenceFile 构造只有一个常量组的文件，值以 ${ 开头的常量为引用
+func referenceFile(path, namespace, group string, values map[string]string) *ConstantsFile {
+	g := &ConstantGroup{Name: group, Pos: Position{Line: 1, Column: 1}}
+	for name, text := range values {
+		constant := &Constant{Name: name}
+		ref, err := parseReference(text)
+		if err != nil {
+			panic(err)
+		}
+		if ref != nil {
+			constant.Ref = ref
+		} else {
+			constant.Value, constant.Type, constant.Literal = mustInteger(text), "int", ""
+		}
+		g.Constants = append(g.Constants, constant)
+	}
+	return &ConstantsFile{FilePath: path, Namespace: namespace, Groups: []*ConstantGroup{g}}
+}
+
+func mustInteger(text string) interface{} {
+	value, err := ConvertValue(text, "int")
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+func TestCheckGroupNames(t *testing.T) {
+	files := []*ConstantsFile{
+		referenceFile("a.yaml", "", "status", map[string]string{"on": "1"}),
+		referenceFile("b.yaml", "", "status", map[string]string{"off": "0"}),
+		referenceFile("sub/c.yaml", "sub", "status", map[string]string{"on": "1"}),
+	}
+	diags := CheckGroupNames(files)
+	if len(diags) != 1 || diags[0].File != "b.yaml" || !strings.Contains(diags[0].Message, "a.yaml:1") {
+		t.Errorf("CheckGroupNames = %v, want one error in b.yaml naming a.yaml:1", diags)
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	root := referenceFile("status.yaml", "", "status", map[string]string{"on": "1"})
+	sub := referenceFile("sub/status.yaml", "sub", "status", map[string]string{"on": "2"})
+	refs := referenceFile("sub/refs.yaml", "sub", "refs", map[string]string{"on": "${status.on}", "alias": "${refs.on}"})
+	if diags := ResolveReferences([]*ConstantsFile{root, sub, refs}); len(diags) > 0 {
+		t.Fatalf("ResolveReferences: %v", diags)
+	}
+	for _, constant := range refs.Groups[0].Constants {
+		if constant.Value != int64(2) || !constant.Ref.SameNamespace {
+			t.Errorf("%s = %v (same namespace %v), want 2 from the sub namespace", constant.Name, constant.Value, constant.Ref.SameNamespace)
+		}
+	}
+}
+
+func TestResolveReferenceErrors(t *testing.T) {
+	tests := []struct {
+		values map[string]string
+		want   string
+	}{
+		{map[string]string{"a": "${refs.b}", "b": "${refs.a}"}, "循环引用"},
+		{map[string]string{"a": "${refs.a}"}, "循环引用: refs.a -> refs.a"},
+		{map[string]string{"a": "${missing.a}"}, "引用的常量组 'missing' 不存在"},
+		{map[string]string{"a": "${refs.b}", "c": "1"}, "引用的常量 'refs.b' 不存在"},
+	}
+	for _, tt := range tests {
+		diags := ResolveReferences([]*ConstantsFile{referenceFile("refs.yaml", "", "refs", tt.values)})
+		if len(diags) == 0 || !strings.Contains(diags[0].Message, tt.want) {
+			t.Errorf("ResolveReferences(%v) = %v, want error containing %q", tt.values, diags, tt.want)
+		}
+	}
+
+	// 同名组分布在两个其他命名空间中时无法确定引用目标
+	files := []*ConstantsFile{
+		referenceFile("x/status.yaml", "x", "status", map[string]string{"on": "1"}),
+		referenceFile("y/status.yaml", "y", "status", map[string]string{"on": "2"}),
+		referenceFile("refs.yaml", "", "refs", map[string]string{"on": "${status.on}"}),
+	}
+	if diags := ResolveReferences(files); len(diags) != 1 || !strings.Contains(diags[0].Message, "无法确定引用目标") {
+		t.Errorf("ResolveReferences = %v, want an ambiguous group error", diags)
+	}
+}
@@ -0,0 +1,293 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+)
+
+// 正则常量：常量的类型写作 regex，值为正则表达式，用于手机号、证件号等校验规则；解析时校验表达式的语法，
+// 生成器输出各语言预编译的正则对象，class和const模式下生成相同的代码，常量按源文件顺序排列。
+// 各语言正则引擎支持的语法不同，生成时按目标语言对不支持的语法给出警告。
+
+// 正则表达式中并非所有正则引擎都支持的语法
+const (
+	RegexLookahead        = "lookahead"          // 先行断言 (?=...)、(?!...)
+	RegexLookbehind       = "lookbehind"         // 后行断言 (?<=...)、(?<!...)
+	RegexBackreference    = "backreference"      // 反向引用 \1、\k<name>、(?P=name)
+	RegexAtomicGroup      = "atomic_group"       // 原子组 (?>...)
+	RegexPossessive       = "possessive"         // 占有量词 *+、++、?+、{n}+
+	RegexNamedGroup       = "named_group"        // 命名捕获组 (?<name>...)
+	RegexPythonNamedGroup = "python_named_group" // Python风格的命名捕获组 (?P<name>...)
+	RegexUnicodeClass     = "unicode_class"      // Unicode属性 \p{L}、\P{L}
+	RegexInlineFlags      = "inline_flags"       // 行内标志 (?i)、(?i:...)
+	RegexStartAnchor      = "start_anchor"       // 输入开头 \A
+	RegexEndAnchor        = "end_anchor"         // 输入结尾 \z
+	RegexEndAnchorNewline = "end_anchor_newline" // 输入结尾（允许结尾的换行）\Z
+	RegexPosixClass       = "posix_class"        // POSIX字符类 [[:alpha:]]
+	RegexQuote            = "quote"              // 字面引用 \Q...\E
+	RegexUnicodeEscape    = "unicode_escape"     // Unicode转义 \u0041
+	RegexEngineEscape     = "engine_escape"      // RE2不支持的其他字母转义，如 \h、\R、\G
+)
+
+// regexFeatureOrder 报告扩展语法时的顺序
+var regexFeatureOrder = []string{
+	RegexLookahead, RegexLookbehind, RegexBackreference, RegexAtomicGroup, RegexPossessive,
+	RegexNamedGroup, RegexPythonNamedGroup, RegexUnicodeClass, RegexInlineFlags,
+	RegexStartAnchor, RegexEndAnchor, RegexEndAnchorNewline, RegexPosixClass,
+	RegexQuote, RegexUnicodeEscape, RegexEngineEscape,
+}
+
+// re2Escapes Go的regexp（RE2语法）支持的字母转义，其他字母转义是别的引擎特有的（如 \h、\u0041）
+const re2Escapes = "aftnrvxAbBzdDsSwWpPQE"
+
+// repeatPattern 计数量词，如 {3}、{2,}、{2,5}
+var repeatPattern = regexp.MustCompile(`^\{\d+(,\d*)?\}`)
+
+// unicodeEscapePattern Unicode转义 \uXXXX
+var unicodeEscapePattern = regexp.MustCompile(`^\\u[0-9A-Fa-f]{4}`)
+
+// IsRegexGroup 判断常量组是否为正则常量组（常量的类型为regex）
+func IsRegexGroup(group *ConstantGroup) bool {
+	for _, constant := range group.Constants {
+		if constant.Type == "regex" {
+			return true
+		}
+	}
+	return false
+}
+
+// RegexFeatures 返回正则表达式用到的扩展语法，按 regexFeatureOrder 的顺序排列
+func RegexFeatures(pattern string) []string {
+	_, used, _ := scanRegex(pattern)
+	var features []string
+	for _, feature := range regexFeatureOrder {
+		if used[feature] {
+			features = append(features, feature)
+		}
+	}
+	return features
+}
+
+// checkRegex 校验正则表达式的语法：扩展语法替换为RE2的等价写法后用Go的regexp/syntax解析，
+// 反向引用的组号或组名必须有对应的捕获组
+func checkRegex(pattern string) error {
+	normalized, _, refs := scanRegex(pattern)
+	re, err := syntax.Parse(normalized, syntax.Perl)
+	if err != nil {
+		if syntaxErr, ok := err.(*syntax.Error); ok {
+			return fmt.Errorf("不是有效的正则表达式: %s: `%s`", syntaxErr.Code, syntaxErr.Expr)
+		}
+		return fmt.Errorf("不是有效的正则表达式: %v", err)
+	}
+	for _, ref := range refs {
+		if number, err := strconv.Atoi(ref); err == nil {
+			if number > re.MaxCap() {
+				return fmt.Errorf("反向引用 \\%d 没有对应的捕获组", number)
+			}
+			continue
+		}
+		found := false
+		for _, name := range re.CapNames() {
+			found = found || name == ref
+		}
+		if !found {
+			return fmt.Errorf("反向引用的组名 '%s' 不存在", ref)
+		}
+	}
+	return nil
+}
+
+// scanRegex 扫描正则表达式，返回把扩展语法替换为RE2的等价写法（断言、原子组替换为非捕获组，反向引用替换为空组，
+// 其他引擎特有的转义替换为占位字符）后的表达式、用到的扩展语法，以及反向引用的组号或组名；
+// 替换掉的写法都记为扩展语法，由生成器按目标语言给出警告
+func scanRegex(pattern string) (string, map[string]bool, []string) {
+	var out strings.Builder
+	features := make(map[string]bool)
+	var refs []string
+	inClass, quoted := false, false
+
+	// 量词之后紧跟的 + 为占有量词，RE2没有对应的写法，直接去掉
+	possessive := func(i int) int {
+		if i+1 < len(pattern) && pattern[i+1] == '+' {
+			features[RegexPossessive] = true
+			return i + 1
+		}
+		return i
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		rest := pattern[i:]
+		switch {
+		case quoted:
+			// \Q...\E 之间的字符都是字面字符
+			if strings.HasPrefix(rest, `\E`) {
+				quoted = false
+				out.WriteString(`\E`)
+				i++
+			} else {
+				out.WriteByte(c)
+			}
+		case c == '\\' && i+1 < len(pattern):
+			next := pattern[i+1]
+			switch {
+			case next == 'Q':
+				features[RegexQuote] = true
+				quoted = true
+				out.WriteString(`\Q`)
+				i++
+			case next >= '1' && next <= '9' && !inClass:
+				end := i + 1
+				for end < len(pattern) && pattern[end] >= '0' && pattern[end] <= '9' {
+					end++
+				}
+				features[RegexBackreference] = true
+				refs = append(refs, pattern[i+1:end])
+				out.WriteString("(?:)")
+				i = end - 1
+			case next == 'k' && strings.HasPrefix(rest[2:], "<") && strings.Contains(rest, ">"):
+				end := i + strings.Index(rest, ">")
+				features[RegexBackreference] = true
+				refs = append(refs, pattern[i+3:end])
+				out.WriteString("(?:)")
+				i = end
+			case next == 'p' || next == 'P':
+				// \pL 或 \p{...}：属性名因引擎而异，不做校验
+				features[RegexUnicodeClass] = true
+				end := i + 3
+				if strings.HasPrefix(rest[2:], "{") && strings.Contains(rest, "}") {
+					end = i + strings.Index(rest, "}") + 1
+				}
+				out.WriteString("x")
+				i = min(end, len(pattern)) - 1
+			case next == 'A':
+				features[RegexStartAnchor] = true
+				out.WriteString(`\A`)
+				i++
+			case next == 'z':
+				features[RegexEndAnchor] = true
+				out.WriteString(`\z`)
+				i++
+			case next == 'Z':
+				features[RegexEndAnchorNewline] = true
+				out.WriteString(`\z`)
+				i++
+			case unicodeEscapePattern.MatchString(rest):
+				features[RegexUnicodeEscape] = true
+				out.WriteString("x")
+				i += 5
+			case (next >= 'a' && next <= 'z' || next >= 'A' && next <= 'Z') && !strings.ContainsRune(re2Escapes, rune(next)):
+				features[RegexEngineEscape] = true
+				out.WriteString("x")
+				i++
+			default:
+				out.WriteString(rest[:2])
+				i++
+			}
+		case inClass:
+			if strings.HasPrefix(rest, "[:") && strings.Contains(rest, ":]") {
+				features[RegexPosixClass] = true
+				end := i + strings.Index(rest, ":]") + 2
+				out.WriteString(pattern[i:end])
+				i = end - 1
+				break
+			}
+			inClass = c != ']'
+			out.WriteByte(c)
+		case c == '[':
+			// 紧跟在 [ 或 [^ 之后的 ] 是字面字符
+			inClass = true
+			end := i + 1
+			if strings.HasPrefix(rest[1:], "^") {
+				end++
+			}
+			if end < len(pattern) && pattern[end] == ']' {
+				end++
+			}
+			out.WriteString(pattern[i:end])
+			i = end - 1
+		case strings.HasPrefix(rest, "(?"):
+			group := rest[2:]
+			switch {
+			case strings.HasPrefix(group, "=") || strings.HasPrefix(group, "!"):
+				features[RegexLookahead] = true
+				out.WriteString("(?:")
+				i += 2
+			case strings.HasPrefix(group, "<=") || strings.HasPrefix(group, "<!"):
+				features[RegexLookbehind] = true
+				out.WriteString("(?:")
+				i += 3
+			case strings.HasPrefix(group, ">"):
+				features[RegexAtomicGroup] = true
+				out.WriteString("(?:")
+				i += 2
+			case strings.HasPrefix(group, "P=") && strings.Contains(group, ")"):
+				features[RegexBackreference] = true
+				end := strings.Index(group, ")")
+				refs = append(refs, group[2:end])
+				out.WriteString("(?:)")
+				i += 2 + end
+			case strings.HasPrefix(group, "P<"):
+				features[RegexPythonNamedGroup] = true
+				out.WriteString("(?P<")
+				i += 3
+			case strings.HasPrefix(group, "<"):
+				features[RegexNamedGroup] = true
+				out.WriteString("(?P<")
+				i += 2
+			default:
+				// 行内标志，如 (?i)、(?i-s:...)；各引擎支持的标志不同，不做校验
+				end := 0
+				for end < len(group) && (group[end] >= 'a' && group[end] <= 'z' || group[end] >= 'A' && group[end] <= 'Z' || group[end] == '-') {
+					end++
+				}
+				if end == 0 || end == len(group) || (group[end] != ')' && group[end] != ':') {
+					out.WriteString("(?")
+					i++
+					break
+				}
+				features[RegexInlineFlags] = true
+				if group[end] == ':' {
+					out.WriteString("(?:")
+				} else {
+					out.WriteString("(?:)")
+				}
+				i += 2 + end
+			}
+		case c == '{' && repeatPattern.MatchString(rest):
+			repeat := repeatPattern.FindString(rest)
+			out.WriteString(repeat)
+			i = possessive(i + len(repeat) - 1)
+		case c == '*' || c == '+' || c == '?':
+			out.WriteByte(c)
+			i = possessive(i)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String(), features, refs
+}
+
+// checkRegexGroup 校验正则常量组：组内的常量都必须是正则表达式，不能是位标志组、记录组、树形常量组或状态机组，
+// 也不能声明附加属性、子集或使用标签模板
+func checkRegexGroup(group *ConstantGroup, diags *Diagnostics) {
+	if !IsRegexGroup(group) {
+		return
+	}
+	if group.Flags || group.Record != nil || IsTreeGroup(group) || IsStateMachine(group) ||
+		len(group.Attributes) > 0 || len(group.Subsets) > 0 {
+		diags.errorf(group.Pos, "正则常量组 '%s' 不能是位标志组、记录组、树形常量组或状态机组，也不能声明附加属性或子集", group.Name)
+		return
+	}
+	for _, constant := range group.Constants {
+		switch {
+		case constant.Type != "regex":
+			diags.errorf(constant.ValuePos(), "常量 '%s' 的类型不是regex，正则常量组 '%s' 中的常量都必须是正则表达式", constant.Name, group.Name)
+		case constant.Template != nil:
+			diags.errorf(constant.Pos, "正则常量组 '%s' 的常量 '%s' 的标签不能含占位符", group.Name, constant.Name)
+		}
+	}
+}
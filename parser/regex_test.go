@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegexFeatures(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{`^1[3-9]\d{9}$`, nil},
+		{`^(?=.*\d)(?<!x).{8,}$`, []string{RegexLookahead, RegexLookbehind}},
+		{`(\w)\1`, []string{RegexBackreference}},
+		{`(?P<y>\d)(?P=y)`, []string{RegexBackreference, RegexPythonNamedGroup}},
+		{`(?<y>\d)\k<y>`, []string{RegexBackreference, RegexNamedGroup}},
+		{`(?>a+)b*+`, []string{RegexAtomicGroup, RegexPossessive}},
+		{`a{2,3}+`, []string{RegexPossessive}},
+		{`(?i)abc`, []string{RegexInlineFlags}},
+		{`\A\p{L}+\z`, []string{RegexUnicodeClass, RegexStartAnchor, RegexEndAnchor}},
+		{`a\Z`, []string{RegexEndAnchorNewline}},
+		{`[[:alpha:]]+`, []string{RegexPosixClass}},
+		{`\Qa.b\E`, []string{RegexQuote}},
+		{`\Q(?=\1\E`, []string{RegexQuote}},
+		{`^\h+$`, []string{RegexEngineEscape}},
+		{`[\h\R]`, []string{RegexEngineEscape}},
+		{`\u0041`, []string{RegexUnicodeEscape}},
+		{`\x41\t\.`, nil},
+		{`[\1]`, nil},
+	}
+	for _, tt := range tests {
+		if got := RegexFeatures(tt.pattern); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("RegexFeatures(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestScanRegex(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		normalized string
+		refs       []string
+	}{
+		{`^(?=.*\d).{8,}$`, `^(?:.*\d).{8,}$`, nil},
+		{`(\w)\1`, `(\w)(?:)`, []string{"1"}},
+		{`(?P<y>\d)(?P=y)`, `(?P<y>\d)(?:)`, []string{"y"}},
+		{`(?<y>\d)\k<y>`, `(?P<y>\d)(?:)`, []string{"y"}},
+		{`a*+`, `a*`, nil},
+		{`(?i)a(?s:.)`, `(?:)a(?:.)`, nil},
+		{`^\h\u0041$`, `^xx$`, nil},
+		{`\Qa.b\E`, `\Qa.b\E`, nil},
+		{`a\Z`, `a\z`, nil},
+	}
+	for _, tt := range tests {
+		normalized, _, refs := scanRegex(tt.pattern)
+		if normalized != tt.normalized || !reflect.DeepEqual(refs, tt.refs) {
+			t.Errorf("scanRegex(%q) = %q, %v, want %q, %v", tt.pattern, normalized, refs, tt.normalized, tt.refs)
+		}
+	}
+}
+
+func TestCheckRegex(t *testing.T) {
+	valid := []string{`^1[3-9]\d{9}$`, `(\w)\1`, `(?P<y>\d)(?P=y)`, `^\h+$`, `\Q(\E`, `[]a]`, `[^]a]`}
+	for _, pattern := range valid {
+		if err := checkRegex(pattern); err != nil {
+			t.Errorf("checkRegex(%q) = %v, want nil", pattern, err)
+		}
+	}
+	invalid := []string{`(ab`, `[a`, `a{2,1}`, `(a)\2`, `(?P<x>a)(?P=y)`, `*a`}
+	for _, pattern := range invalid {
+		if err := checkRegex(pattern); err == nil {
+			t.Errorf("checkRegex(%q) = nil, want error", pattern)
+		}
+	}
+}
@@ -0,0 +1,457 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlConstantNode YAML源文件中一个常量的节点，列表中只写常量名的项没有值节点
+type yamlConstantNode struct {
+	Group    string
+	Name     string
+	Key      *yaml.Node
+	Value    *yaml.Node
+	Flow     bool // 值节点位于流式（{} 或 []）集合中
+	TopLevel bool // 顶层常量，属于以文件名命名的常量组
+}
+
+// yamlConstantNodes 按 parseYAMLWithComments 的规则找出源文件中的全部常量节点
+func yamlConstantNodes(root *yaml.Node, fileName string) []yamlConstantNode {
+	var nodes []yamlConstantNode
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		if isDirectiveNode(keyNode, valueNode) {
+			continue
+		}
+		if isGroupNode(valueNode) {
+			nodes = append(nodes, yamlGroupConstantNodes(strings.TrimSpace(keyNode.Value), valueNode)...)
+			continue
+		}
+		nodes = append(nodes, yamlConstantNode{
+			Group:    fileName,
+			Name:     strings.TrimSpace(keyNode.Value),
+			Key:      keyNode,
+			Value:    valueNode,
+			Flow:     root.Style&yaml.FlowStyle != 0,
+			TopLevel: true,
+		})
+		nodes = append(nodes, yamlChildConstantNodes(fileName, valueNode)...)
+	}
+	return nodes
+}
+
+// yamlGroupConstantNodes 找出嵌套常量组中的常量节点
+func yamlGroupConstantNodes(group string, node *yaml.Node) []yamlConstantNode {
+	var nodes []yamlConstantNode
+	flow := node.Style&yaml.FlowStyle != 0
+	if node.Kind == yaml.SequenceNode {
+		for _, itemNode := range node.Content {
+			switch {
+			case itemNode.Kind == yaml.ScalarNode:
+				nodes = append(nodes, yamlConstantNode{Group: group, Name: strings.TrimSpace(itemNode.Value), Key: itemNode, Flow: flow})
+			case itemNode.Kind == yaml.MappingNode && len(itemNode.Content) == 2:
+				if isDirectiveNode(itemNode.Content[0], itemNode.Content[1]) {
+					continue
+				}
+				nodes = append(nodes, yamlConstantNode{
+					Group: group,
+					Name:  strings.TrimSpace(itemNode.Content[0].Value),
+					Key:   itemNode.Content[0],
+					Value: itemNode.Content[1],
+					Flow:  flow || itemNode.Style&yaml.FlowStyle != 0,
+				})
+				nodes = append(nodes, yamlChildConstantNodes(group, itemNode.Content[1])...)
+			}
+		}
+		return nodes
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if isDirectiveNode(node.Content[i], node.Content[i+1]) {
+			continue
+		}
+		nodes = append(nodes, yamlConstantNode{
+			Group: group,
+			Name:  strings.TrimSpace(node.Content[i].Value),
+			Key:   node.Content[i],
+			Value: node.Content[i+1],
+			Flow:  flow,
+		})
+		nodes = append(nodes, yamlChildConstantNodes(group, node.Content[i+1])...)
+	}
+	return nodes
+}
+
+// yamlChildConstantNodes 找出嵌套在常量children中的子常量节点（含更深层的子常量）
+func yamlChildConstantNodes(group string, value *yaml.Node) []yamlConstantNode {
+	children := childrenNode(value)
+	if children == nil || children.Kind != yaml.MappingNode {
+		return nil
+	}
+	return yamlGroupConstantNodes(group, children)
+}
+
+// isDirectiveNode 判断键值节点是否为auto、flags、record、attributes、transitions、subsets或delete指令
+func isDirectiveNode(keyNode, valueNode *yaml.Node) bool {
+	return isAutoDirectiveNode(keyNode, valueNode) ||
+		isFlagsDirectiveNode(keyNode, valueNode) ||
+		isRecordDirectiveNode(keyNode, valueNode) ||
+		isAttributesDirective(strings.TrimSpace(keyNode.Value), valueNode) ||
+		isTransitionsDirective(keyNode, valueNode) ||
+		isSubsetsDirective(keyNode, valueNode) ||
+		isDeleteDirectiveNode(keyNode, valueNode)
+}
+
+// textEdit 对源文件内容的一处修改：以 Text 替换 [Start, End) 的字节
+type textEdit struct {
+	Start, End int
+	Text       string
+}
+
+// yamlSource 带行偏移的YAML源文件内容，用于把节点的行列换算为字节偏移
+type yamlSource struct {
+	data       []byte
+	lineStarts []int
+	newline    string
+}
+
+func newYAMLSource(data []byte) *yamlSource {
+	source := &yamlSource{data: data, lineStarts: []int{0}, newline: "\n"}
+	for i, b := range data {
+		if b == '\n' {
+			source.lineStarts = append(source.lineStarts, i+1)
+		}
+	}
+	if bytes.Contains(data, []byte("\r\n")) {
+		source.newline = "\r\n"
+	}
+	return source
+}
+
+// offset 返回节点起始位置的字节偏移，yaml.v3的列号按字符计数
+func (s *yamlSource) offset(node *yaml.Node) int {
+	if node.Line < 1 || node.Line > len(s.lineStarts) {
+		return len(s.data)
+	}
+	offset := s.lineStarts[node.Line-1]
+	for column := 1; column < node.Column && offset < len(s.data); column++ {
+		_, size := utf8.DecodeRune(s.data[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// lineStart 返回偏移所在行的行首偏移
+func (s *yamlSource) lineStart(offset int) int {
+	return bytes.LastIndexByte(s.data[:offset], '\n') + 1
+}
+
+// scalarEnd 返回标量节点（包括键）在源文件中的结束偏移；流式集合中的普通标量在 , ] } 处结束
+func (s *yamlSource) scalarEnd(node *yaml.Node, flow bool) (int, error) {
+	start := s.offset(node)
+	if start >= len(s.data) {
+		return start, nil
+	}
+	switch s.data[start] {
+	case '"':
+		for i := start + 1; i < len(s.data); i++ {
+			switch s.data[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1, nil
+			}
+		}
+	case '\'':
+		for i := start + 1; i < len(s.data); i++ {
+			if s.data[i] == '\'' {
+				if i+1 < len(s.data) && s.data[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1, nil
+			}
+		}
+	case '|', '>':
+		return 0, errorAt(nodePos(node), "不支持写回块标量（| 或 >）")
+	default:
+		end := start
+		for end < len(s.data) {
+			c := s.data[end]
+			if c == '\n' || c == '\r' || (c == '#' && end > start && (s.data[end-1] == ' ' || s.data[end-1] == '\t')) {
+				break
+			}
+			if flow && (c == ',' || c == ']' || c == '}') {
+				break
+			}
+			// 普通标量不含 ": "，键在冒号处结束
+			if c == ':' && (end+1 == len(s.data) || strings.IndexByte(" \t\r\n", s.data[end+1]) >= 0) {
+				break
+			}
+			end++
+		}
+		for end > start && (s.data[end-1] == ' ' || s.data[end-1] == '\t') {
+			end--
+		}
+		return end, nil
+	}
+	return 0, errorAt(nodePos(node), "引号未闭合")
+}
+
+// scalarText 返回标量节点在源文件中的原文
+func (s *yamlSource) scalarText(node *yaml.Node, flow bool) (string, error) {
+	end, err := s.scalarEnd(node, flow)
+	if err != nil {
+		return "", err
+	}
+	return string(s.data[s.offset(node):end]), nil
+}
+
+// indentBefore 返回节点所在行在节点之前的缩进，节点之前还有其他内容时返回false
+func (s *yamlSource) indentBefore(node *yaml.Node) (string, bool) {
+	offset := s.offset(node)
+	indent := string(s.data[s.lineStart(offset):offset])
+	return indent, strings.TrimLeft(indent, " \t") == ""
+}
+
+// LocalizeYAMLFile 把按常量组、常量名给出的某种语言的标签写入YAML源文件的内容并返回，只改动相关的标签文本，
+// 注释、格式和其他内容保持不变。原有的行内注释或单一标签作为默认语言的标签保留
+func LocalizeYAMLFile(filePath, defaultLocale, locale string, labels map[string]map[string]string) ([]byte, error) {
+	if _, ok := readerFor(filePath).(yamlReader); !ok {
+		return nil, fmt.Errorf("只支持写回YAML源文件，请手动更新")
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	fileName := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	result, err := setLocalizedLabels(data, fileName, defaultLocale, locale, labels)
+	if err != nil {
+		var diag *Diagnostic
+		if errors.As(err, &diag) {
+			diag.File = filePath
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// setLocalizedLabels 在YAML源文件内容中写入指定语言的标签，返回修改后的内容
+func setLocalizedLabels(data []byte, fileName, defaultLocale, locale string, labels map[string]map[string]string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, yamlErrors(err)[0]
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("顶层必须是键值映射")
+	}
+
+	source := newYAMLSource(data)
+	var edits []textEdit
+	found := make(map[string]bool)
+	for _, node := range yamlConstantNodes(doc.Content[0], fileName) {
+		text, exists := labels[node.Group][node.Name]
+		if !exists {
+			continue
+		}
+		found[node.Group+"."+node.Name] = true
+		edit, err := source.labelEdit(node, defaultLocale, locale, text)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+	}
+	for group, constants := range labels {
+		for name := range constants {
+			if !found[group+"."+name] {
+				return nil, fmt.Errorf("找不到常量 '%s.%s'", group, name)
+			}
+		}
+	}
+
+	// 从后往前修改，前面的偏移不受影响
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Start > edits[j].Start
+	})
+	result := append([]byte(nil), data...)
+	for _, edit := range edits {
+		result = append(result[:edit.Start], append([]byte(edit.Text), result[edit.End:]...)...)
+	}
+
+	if err := checkLocalizedLabels(result, fileName, locale, labels); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkLocalizedLabels 重新解析写回后的内容，确认各常量的标签与写入的一致
+func checkLocalizedLabels(data []byte, fileName, locale string, labels map[string]map[string]string) error {
+	var diags Diagnostics
+	_, groups := parseYAMLWithComments(data, fileName, &diags)
+	if diags.HasErrors() {
+		for _, diag := range diags {
+			if diag.Severity == SeverityError {
+				return fmt.Errorf("写回后的内容无法解析: %v", diag)
+			}
+		}
+	}
+	for _, group := range groups {
+		for _, constant := range group.Constants {
+			text, exists := labels[group.Name][constant.Name]
+			if exists && constant.Labels[locale] != text {
+				return errorAt(constant.Pos, "常量 '%s' 写回后的%s标签与写入的不一致", constant.Name, locale)
+			}
+		}
+	}
+	return nil
+}
+
+// labelEdit 计算为常量写入指定语言标签的修改
+func (s *yamlSource) labelEdit(node yamlConstantNode, defaultLocale, locale, text string) (textEdit, error) {
+	entry := locale + ": " + yamlFlowScalar(text)
+
+	// 列表中只写常量名: - pending # 待支付 → - pending: {label: {en: Pending}} # 待支付
+	if node.Value == nil {
+		end, err := s.scalarEnd(node.Key, node.Flow)
+		if err != nil {
+			return textEdit{}, err
+		}
+		start := s.offset(node.Key)
+		return textEdit{Start: start, End: end, Text: string(s.data[start:end]) + ": {label: {" + entry + "}}"}, nil
+	}
+
+	value := node.Value
+	switch value.Kind {
+	case yaml.AliasNode:
+		return textEdit{}, errorAt(nodePos(value), "常量 '%s' 使用锚点引用，无法写回标签", node.Name)
+	case yaml.ScalarNode:
+		return s.scalarConstantEdit(node, entry)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			if value.Content[i].Value == "label" {
+				return s.labelFieldEdit(node, value.Content[i+1], defaultLocale, locale, entry, value.Style&yaml.FlowStyle != 0 || node.Flow)
+			}
+		}
+		return s.appendEntryEdit(value, "label: {"+entry+"}", node.Flow)
+	}
+	return textEdit{}, errorAt(nodePos(value), "常量 '%s' 的值无法写回标签", node.Name)
+}
+
+// scalarConstantEdit 把标量形式的常量改为映射形式: a: 1 # 标签 → a: {value: 1, label: {en: X}} # 标签
+func (s *yamlSource) scalarConstantEdit(node yamlConstantNode, entry string) (textEdit, error) {
+	value := node.Value
+
+	// 省略值的常量: key: # 标签 → key: {label: {en: X}} # 标签
+	if value.ShortTag() == "!!null" && value.Style&yaml.TaggedStyle == 0 {
+		// 顶层只有label的映射会被视为常量组
+		if node.TopLevel {
+			return textEdit{}, errorAt(nodePos(node.Key), "顶层常量 '%s' 省略了值，无法写回标签，请改为映射形式", node.Name)
+		}
+		keyEnd, err := s.scalarEnd(node.Key, node.Flow)
+		if err != nil {
+			return textEdit{}, err
+		}
+		colon := keyEnd + bytes.IndexByte(s.data[keyEnd:], ':')
+		if colon < keyEnd {
+			return textEdit{}, errorAt(nodePos(node.Key), "常量 '%s' 缺少冒号", node.Name)
+		}
+		end := colon + 1
+		if value.Value != "" {
+			if end, err = s.scalarEnd(value, node.Flow); err != nil {
+				return textEdit{}, err
+			}
+		}
+		return textEdit{Start: colon + 1, End: end, Text: " {label: {" + entry + "}}"}, nil
+	}
+
+	raw, err := s.scalarText(value, node.Flow)
+	if err != nil {
+		return textEdit{}, err
+	}
+	// 块上下文中的普通标量可能含有流式集合的分隔符，放入 {} 前加引号
+	if value.Style == 0 && strings.ContainsAny(raw, ",[]{}") {
+		raw = strconv.Quote(value.Value)
+	}
+	start := s.offset(value)
+	return textEdit{Start: start, End: start + len(raw), Text: "{value: " + raw + ", label: {" + entry + "}}"}, nil
+}
+
+// labelFieldEdit 修改映射形式常量已有的label字段
+func (s *yamlSource) labelFieldEdit(node yamlConstantNode, label *yaml.Node, defaultLocale, locale, entry string, flow bool) (textEdit, error) {
+	switch label.Kind {
+	case yaml.ScalarNode:
+		// label: 管理员 → label: {zh: 管理员, en: Admin}
+		end, err := s.scalarEnd(label, flow)
+		if err != nil {
+			return textEdit{}, err
+		}
+		text := "{" + defaultLocale + ": " + yamlFlowScalar(label.Value) + ", " + entry + "}"
+		return textEdit{Start: s.offset(label), End: end, Text: text}, nil
+	case yaml.MappingNode:
+		flow = flow || label.Style&yaml.FlowStyle != 0
+		for i := 0; i+1 < len(label.Content); i += 2 {
+			if strings.TrimSpace(label.Content[i].Value) != locale {
+				continue
+			}
+			target := label.Content[i+1]
+			end, err := s.scalarEnd(target, flow)
+			if err != nil {
+				return textEdit{}, err
+			}
+			return textEdit{Start: s.offset(target), End: end, Text: strings.TrimPrefix(entry, locale+": ")}, nil
+		}
+		return s.appendEntryEdit(label, entry, flow)
+	}
+	return textEdit{}, errorAt(nodePos(label), "常量 '%s' 的label无法写回", node.Name)
+}
+
+// appendEntryEdit 在流式映射的末尾或块式映射的第一个键之前加入一个键值
+func (s *yamlSource) appendEntryEdit(mapping *yaml.Node, entry string, flow bool) (textEdit, error) {
+	if mapping.Style&yaml.FlowStyle != 0 {
+		if len(mapping.Content) == 0 {
+			start := s.offset(mapping) + bytes.IndexByte(s.data[s.offset(mapping):], '{') + 1
+			return textEdit{Start: start, End: start, Text: entry}, nil
+		}
+		last := mapping.Content[len(mapping.Content)-1]
+		if last.Kind != yaml.ScalarNode {
+			return textEdit{}, errorAt(nodePos(last), "映射的最后一项不是标量，无法写回标签")
+		}
+		end, err := s.scalarEnd(last, true)
+		if err != nil {
+			return textEdit{}, err
+		}
+		return textEdit{Start: end, End: end, Text: ", " + entry}, nil
+	}
+
+	if len(mapping.Content) == 0 {
+		return textEdit{}, errorAt(nodePos(mapping), "空映射无法写回标签")
+	}
+	first := mapping.Content[0]
+	indent, ok := s.indentBefore(first)
+	if !ok {
+		return textEdit{}, errorAt(nodePos(first), "映射的第一个键之前还有其他内容，无法写回标签")
+	}
+	start := s.lineStart(s.offset(first))
+	return textEdit{Start: start, End: start, Text: indent + entry + s.newline}, nil
+}
+
+// yamlFlowScalar 把文本写为可放入流式映射的YAML标量：能按原样解析为同一字符串时不加引号，否则写为双引号字符串
+func yamlFlowScalar(text string) string {
+	if text != "" && text == strings.TrimSpace(text) &&
+		!strings.ContainsAny(text, ",[]{}#:&*!|>'\"%@`?\n\r\t") && !strings.HasPrefix(text, "-") {
+		var decoded map[string]interface{}
+		if yaml.Unmarshal([]byte("k: "+text), &decoded) == nil && decoded["k"] == text {
+			return text
+		}
+	}
+	return strconv.Quote(text)
+}
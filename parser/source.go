@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SourceReader 常量源文件读取器，每种源文件格式各自实现
+type SourceReader interface {
+	// Extensions 返回该格式支持的文件扩展名（小写，含点号）
+	Extensions() []string
+	// Read 解析文件内容，返回文件标签、常量组列表和解析诊断
+	Read(data []byte, fileName string) (string, []*ConstantGroup, Diagnostics)
+}
+
+// groupSplitter 可选接口：读取器实现后，源文件中的每个常量组各自成为一个ConstantsFile
+type groupSplitter interface {
+	splitGroups() bool
+}
+
+// sourceReaders 已注册的源文件读取器
+var sourceReaders = []SourceReader{
+	yamlReader{},
+	jsonReader{},
+	csvReader{},
+}
+
+// readerFor 根据文件扩展名选择读取器，不支持的格式返回nil
+func readerFor(filePath string) SourceReader {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	for _, reader := range sourceReaders {
+		for _, readerExt := range reader.Extensions() {
+			if ext == readerExt {
+				return reader
+			}
+		}
+	}
+	return nil
+}
+
+// FindSourceFiles 递归查找目录下所有受支持格式的源文件，按路径排序返回
+func FindSourceFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// 跳过隐藏目录
+		if d.IsDir() && path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && readerFor(path) != nil {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// NamespaceOf 根据文件相对于输入根目录的子目录得出命名空间，如 "order/pay"
+func NamespaceOf(rootDir, filePath string) string {
+	rel, err := filepath.Rel(rootDir, filepath.Dir(filePath))
+	if err != nil || rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// NamespaceSegments 将命名空间拆分为各级名称，并规范化为小写下划线形式
+func NamespaceSegments(namespace string) []string {
+	if namespace == "" {
+		return nil
+	}
+
+	segments := strings.Split(namespace, "/")
+	for i, segment := range segments {
+		segment = strings.ToLower(segment)
+		segments[i] = strings.NewReplacer("-", "_", " ", "_", ".", "_").Replace(segment)
+	}
+	return segments
+}
+
+// targetKeywords 各目标语言的关键字：不能用作命名空间，用作常量组名、常量名时报告警告
+var targetKeywords = []struct {
+	language string
+	keywords string
+}{
+	{"Go", "break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var"},
+	{"Java", "_ abstract assert boolean break byte case catch char class const continue default do double else enum extends false final finally float for goto if implements import instanceof int interface long native new null package private protected public return short static strictfp super switch synchronized this throw throws transient true try void volatile while"},
+	{"Kotlin", "as break class continue do else false for fun if in interface is null object package return super this throw true try typealias typeof val var when while"},
+	{"Swift", "any as associatedtype break case catch class continue default defer deinit do else enum extension fallthrough false fileprivate for func guard if import in init inout internal is let nil open operator private protocol public repeat rethrows return self static struct subscript super switch throw throws true try typealias var where while"},
+	{"Python", "False None True and as assert async await break class continue def del elif else except finally for from global if import in is lambda nonlocal not or pass raise return try while with yield"},
+	{"TypeScript/JavaScript", "await break case catch class const continue debugger default delete do else enum export extends false finally for function if implements import in instanceof interface let new null package private protected public return static super switch this throw true try typeof var void while with yield"},
+}
+
+// CheckNamespaces 检查命名空间的各级名称：子目录名规范化后用作各语言的包名、模块名，
+// 必须是有效的标识符（不能以数字开头），也不能是任一目标语言的关键字，如目录 2024、class。
+// 每个有问题的目录只报告一次，诊断的文件为该目录的路径
+func CheckNamespaces(rootDir string, files []*ConstantsFile) Diagnostics {
+	var diags Diagnostics
+	reported := make(map[string]bool)
+	for _, file := range files {
+		if file.Namespace == "" {
+			continue
+		}
+		folders := strings.Split(file.Namespace, "/")
+		for i, segment := range NamespaceSegments(file.Namespace) {
+			folder := filepath.Join(rootDir, filepath.FromSlash(strings.Join(folders[:i+1], "/")))
+			if reported[folder] {
+				continue
+			}
+			if message := checkNamespaceSegment(folders[i], segment); message != "" {
+				reported[folder] = true
+				diags = append(diags, &Diagnostic{File: folder, Severity: SeverityError, Message: message})
+			}
+		}
+	}
+	return diags
+}
+
+// checkNamespaceSegment 检查规范化后的一级命名空间名称，返回问题描述，没有问题时返回空串
+func checkNamespaceSegment(folder, segment string) string {
+	for i, r := range segment {
+		if !(r == '_' || unicode.IsLetter(r) || i > 0 && unicode.IsDigit(r)) {
+			return fmt.Sprintf("目录名 '%s' 不能用作命名空间：规范化后的 '%s' 不是有效的标识符（只能包含字母、数字和下划线，不能以数字开头）", folder, segment)
+		}
+	}
+	if languages := keywordLanguages(segment); len(languages) > 0 {
+		return fmt.Sprintf("目录名 '%s' 不能用作命名空间：'%s' 是 %s 的关键字", folder, segment, strings.Join(languages, "、"))
+	}
+	return ""
+}
+
+// keywordLanguages 返回以 name 为关键字的目标语言，name 不是关键字时返回空
+func keywordLanguages(name string) []string {
+	var languages []string
+	for _, entry := range targetKeywords {
+		for _, keyword := range strings.Fields(entry.keywords) {
+			if keyword == name {
+				languages = append(languages, entry.language)
+				break
+			}
+		}
+	}
+	return languages
+}
+
+// ParseFile 解析单个常量源文件，按扩展名选择对应格式的读取器
+//
+// 通常一个源文件对应一个ConstantsFile；表格类格式（如CSV）按常量组拆分为多个。
+// 源文件内容的问题以诊断返回（已填写文件路径），error仅表示文件无法读取或格式不受支持。
+func ParseFile(filePath string) ([]*ConstantsFile, Diagnostics, error) {
+	return parseFile(filePath, false)
+}
+
+// ParseOverlayFile 解析覆盖层目录中的源文件（见 ApplyOverlays）
+//
+// 覆盖层中的常量只需给出要覆盖的字段，缺少行内注释时沿用基础常量的标签，不再报告；
+// 自增赋值和各项校验在合并到基础常量组之后进行。
+func ParseOverlayFile(filePath string) ([]*ConstantsFile, Diagnostics, error) {
+	return parseFile(filePath, true)
+}
+
+// parseFile 读取并解析源文件，overlay 表示覆盖层中的文件
+func parseFile(filePath string, overlay bool) ([]*ConstantsFile, Diagnostics, error) {
+	reader := readerFor(filePath)
+	if reader == nil {
+		return nil, nil, fmt.Errorf("不支持的文件格式 '%s'", filepath.Ext(filePath))
+	}
+
+	// 读取文件内容
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+
+	// 获取文件信息
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取文件信息失败: %w", err)
+	}
+
+	// 提取文件名（不含扩展名）
+	fileName := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+
+	label, groups, diags := reader.Read(data, fileName)
+	for _, group := range groups {
+		group.Constants = flattenChildren(group.Constants, &diags)
+	}
+	if overlay {
+		var errs Diagnostics
+		for _, diag := range diags {
+			if diag.Severity == SeverityError {
+				errs = append(errs, diag)
+			}
+		}
+		diags = errs
+	} else {
+		groups = checkGroups(groups, &diags)
+	}
+	if len(groups) == 0 && !diags.HasErrors() {
+		diags.warnf(Position{}, "文件中没有定义常量")
+	}
+	for _, diag := range diags {
+		diag.File = filePath
+	}
+	if len(groups) == 0 {
+		return nil, diags, nil
+	}
+
+	// 按常量组拆分
+	if splitter, ok := reader.(groupSplitter); ok && splitter.splitGroups() {
+		files := make([]*ConstantsFile, 0, len(groups))
+		for _, group := range groups {
+			files = append(files, &ConstantsFile{
+				FileName:     group.Name,
+				FilePath:     filePath,
+				Label:        group.Label,
+				Groups:       []*ConstantGroup{group},
+				LastModified: fileInfo.ModTime(),
+			})
+		}
+		return files, diags, nil
+	}
+
+	return []*ConstantsFile{{
+		FileName:     fileName,
+		FilePath:     filePath,
+		Label:        label,
+		Groups:       groups,
+		LastModified: fileInfo.ModTime(),
+	}}, diags, nil
+}
@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckNamespaces(t *testing.T) {
+	files := []*ConstantsFile{
+		{FilePath: "data/a.yaml"},
+		{FilePath: "data/order/pay-center/b.yaml", Namespace: "order/pay-center"},
+		{FilePath: "data/2024/orders/c.yaml", Namespace: "2024/orders"},
+		{FilePath: "data/2024/orders/d.yaml", Namespace: "2024/orders"},
+		{FilePath: "data/class/e.yaml", Namespace: "class"},
+		{FilePath: "data/Func/f.yaml", Namespace: "Func"},
+		{FilePath: "data/v1.2/g.yaml", Namespace: "v1.2"},
+		{FilePath: "data/a+b/h.yaml", Namespace: "a+b"},
+	}
+	want := []struct {
+		folder  string
+		message string
+	}{
+		{"data/2024", "'2024' 不是有效的标识符"},
+		{"data/class", "'class' 是 Java、Kotlin、Swift、Python、TypeScript/JavaScript 的关键字"},
+		{"data/Func", "'func' 是 Go、Swift 的关键字"},
+		{"data/a+b", "'a+b' 不是有效的标识符"},
+	}
+
+	diags := CheckNamespaces("data", files)
+	if len(diags) != len(want) {
+		t.Fatalf("CheckNamespaces() = %v, want %d diagnostics", diags, len(want))
+	}
+	for i, w := range want {
+		diag := diags[i]
+		if diag.File != filepath.FromSlash(w.folder) || diag.Severity != SeverityError || !strings.Contains(diag.Message, w.message) {
+			t.Errorf("diagnostic %d = %v, want %s: error containing %q", i, diag, w.folder, w.message)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 状态机组：常量组声明 transitions 指令，列出各状态允许转换到的目标状态，
+// 用于订单、支付等状态；生成器据此输出判断能否转换、查询后继状态和终止状态的辅助函数。
+
+// Transition 状态机组中一个状态允许转换到的目标状态
+type Transition struct {
+	From string   // 源状态的常量名
+	To   []string // 目标状态的常量名，按源文件顺序排列
+	Pos  Position // 在源文件中的位置
+}
+
+// IsStateMachine 判断常量组是否为状态机组（声明了transitions指令）
+func IsStateMachine(group *ConstantGroup) bool {
+	return group.Transitions != nil
+}
+
+// InitialState 返回状态机组的初始状态，即源文件中定义的第一个常量
+func InitialState(group *ConstantGroup) *Constant {
+	if len(group.Constants) == 0 {
+		return nil
+	}
+	return group.Constants[0]
+}
+
+// NextStates 返回状态允许转换到的目标状态的常量名，终止状态返回nil
+func NextStates(group *ConstantGroup, state string) []string {
+	for _, transition := range group.Transitions {
+		if transition.From == state {
+			return transition.To
+		}
+	}
+	return nil
+}
+
+// isTransitionsDirective 判断键值节点是否为transitions指令：映射的每一项都是目标状态的列表，
+// 如 transitions: {pending: [paid, cancelled]}，以免与名为transitions的常量或常量组混淆
+func isTransitionsDirective(keyNode, valueNode *yaml.Node) bool {
+	if strings.TrimSpace(keyNode.Value) != "transitions" || valueNode.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(valueNode.Content); i += 2 {
+		if valueNode.Content[i+1].Kind != yaml.SequenceNode {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTransitionsNode 解析transitions指令，每项写作 源状态: [目标状态, ...]，空列表表示终止状态；
+// 存在无效的项时返回nil，以免据不完整的转换再报告无法到达的状态
+func parseTransitionsNode(node *yaml.Node, diags *Diagnostics) []*Transition {
+	transitions := []*Transition{}
+	valid := true
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		transition := &Transition{From: strings.TrimSpace(keyNode.Value), Pos: nodePos(keyNode)}
+		if !isDeleteList(valueNode) {
+			diags.errorf(nodePos(valueNode), "状态 '%s' 的目标状态必须是常量名的列表", transition.From)
+			valid = false
+			continue
+		}
+		for _, item := range valueNode.Content {
+			transition.To = append(transition.To, strings.TrimSpace(item.Value))
+		}
+		transitions = append(transitions, transition)
+	}
+	if !valid {
+		return nil
+	}
+	return transitions
+}
+
+// checkTransitions 校验状态机组：源状态和目标状态必须是同组内的常量（不能是别名），
+// 同一状态的目标状态不能重复，除废弃的常量外每个状态都能从初始状态到达；位标志组和记录组不能是状态机组
+func checkTransitions(group *ConstantGroup, diags *Diagnostics) {
+	if !IsStateMachine(group) {
+		return
+	}
+	if group.Flags || group.Record != nil {
+		diags.errorf(group.Pos, "位标志组和记录组不能声明transitions，常量组 '%s' 不能是状态机组", group.Name)
+		return
+	}
+
+	constants := make(map[string]*Constant)
+	aliases := make(map[string]bool)
+	for _, constant := range group.Constants {
+		constants[constant.Name] = constant
+		for _, alias := range constant.Aliases {
+			aliases[alias.Name] = true
+		}
+	}
+	checkState := func(name string, pos Position) bool {
+		switch {
+		case aliases[name]:
+			diags.errorf(pos, "状态 '%s' 是别名，请使用常量名", name)
+		case constants[name] == nil:
+			diags.errorf(pos, "状态 '%s' 不是常量组 '%s' 中的常量", name, group.Name)
+		default:
+			return true
+		}
+		return false
+	}
+
+	valid := true
+	sources := make(map[string]bool)
+	for _, transition := range group.Transitions {
+		if !checkState(transition.From, transition.Pos) {
+			valid = false
+			continue
+		}
+		if sources[transition.From] {
+			diags.errorf(transition.Pos, "状态 '%s' 的转换重复声明", transition.From)
+			valid = false
+		}
+		sources[transition.From] = true
+
+		targets := make(map[string]bool)
+		for _, to := range transition.To {
+			if !checkState(to, transition.Pos) {
+				valid = false
+				continue
+			}
+			if targets[to] {
+				diags.errorf(transition.Pos, "状态 '%s' 的目标状态 '%s' 重复", transition.From, to)
+				valid = false
+			}
+			targets[to] = true
+		}
+	}
+	if !valid || len(group.Constants) == 0 {
+		return
+	}
+
+	// 从初始状态出发沿转换遍历，未到达的状态多半是漏写了转换
+	initial := InitialState(group)
+	reached := map[string]bool{initial.Name: true}
+	queue := []string{initial.Name}
+	for len(queue) > 0 {
+		for _, to := range NextStates(group, queue[0]) {
+			if !reached[to] {
+				reached[to] = true
+				queue = append(queue, to)
+			}
+		}
+		queue = queue[1:]
+	}
+	for _, constant := range group.Constants {
+		if !reached[constant.Name] && !constant.Deprecated {
+			diags.errorf(constant.Pos, "状态 '%s' 无法从初始状态 '%s' 到达", constant.Name, initial.Name)
+		}
+	}
+}
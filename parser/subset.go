@@ -0,0 +1,220 @@
+package parser
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 命名子集：常量组声明 subsets 指令，按名称列出组内常量的子集（如“进行中的状态”），
+// 成员可以直接列出，也可以按标记选取；生成器据此输出不可变集合和判断是否属于子集的辅助函数。
+
+// Subset 常量组中的一个命名子集
+type Subset struct {
+	Name    string   // 子集名称
+	Label   string   // 子集描述
+	Members []string // 直接列出的成员常量名，按源文件顺序排列
+	Tag     string   // 按标记选取成员时的标记，与Members二选一
+	Pos     Position // 在源文件中的位置
+}
+
+// reservedSubsetNames 与生成代码中已有成员（如isValid、状态机组的isTerminal、树形常量组的isDescendantOf）冲突的子集名
+var reservedSubsetNames = map[string]bool{
+	"valid":         true,
+	"terminal":      true,
+	"descendant_of": true,
+	"key":           true,
+	"value":         true,
+}
+
+// subsetFields 映射形式的子集可以使用的字段
+var subsetFields = map[string]bool{
+	"members": true,
+	"tag":     true,
+	"label":   true,
+}
+
+// SubsetMembers 返回子集的成员常量：直接列出的成员按列出的顺序，按标记选取的成员按源文件顺序
+func SubsetMembers(group *ConstantGroup, subset *Subset) []*Constant {
+	var members []*Constant
+	if subset.Tag != "" {
+		for _, constant := range group.Constants {
+			for _, tag := range constant.Tags {
+				if tag == subset.Tag {
+					members = append(members, constant)
+					break
+				}
+			}
+		}
+		return members
+	}
+	for _, name := range subset.Members {
+		for _, constant := range group.Constants {
+			if constant.Name == name {
+				members = append(members, constant)
+				break
+			}
+		}
+	}
+	return members
+}
+
+// isSubsetsDirective 判断键值节点是否为subsets指令：映射的每一项都是成员列表，或带members、tag字段的映射，
+// 如 subsets: {active: [pending, paid], finished: {tag: final}}，以免与名为subsets的常量或常量组混淆
+func isSubsetsDirective(keyNode, valueNode *yaml.Node) bool {
+	if strings.TrimSpace(keyNode.Value) != "subsets" || valueNode.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(valueNode.Content); i += 2 {
+		if !isSubsetNode(valueNode.Content[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubsetNode 判断节点是否为一个子集的定义：成员列表，或只含子集字段且给出了members或tag的映射
+func isSubsetNode(node *yaml.Node) bool {
+	if node.Kind == yaml.SequenceNode {
+		return true
+	}
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	selected := false
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		field := node.Content[i].Value
+		if !subsetFields[field] {
+			return false
+		}
+		selected = selected || field == "members" || field == "tag"
+	}
+	return selected
+}
+
+// parseSubsetsNode 解析subsets指令，每项写作 子集名: [成员, ...] 或 子集名: {tag: 标记, label: 描述}，
+// 列表形式的子集以行内注释为描述，没有描述时使用子集名
+func parseSubsetsNode(node *yaml.Node, diags *Diagnostics) []*Subset {
+	var subsets []*Subset
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		subset := &Subset{
+			Name:  strings.TrimSpace(keyNode.Value),
+			Label: firstCommentLine(keyNode.LineComment, valueNode.LineComment),
+			Pos:   nodePos(keyNode),
+		}
+		if !isSubsetNode(valueNode) {
+			diags.errorf(nodePos(valueNode), "子集 '%s' 必须是常量名的列表，或带members、tag字段的映射", subset.Name)
+			continue
+		}
+
+		members := valueNode
+		if valueNode.Kind == yaml.MappingNode {
+			members = nil
+			for j := 0; j+1 < len(valueNode.Content); j += 2 {
+				fieldNode := valueNode.Content[j+1]
+				var err error
+				switch valueNode.Content[j].Value {
+				case "members":
+					members = fieldNode
+				case "tag":
+					err = fieldNode.Decode(&subset.Tag)
+				case "label":
+					err = fieldNode.Decode(&subset.Label)
+				}
+				if err != nil {
+					diags.errorf(nodePos(fieldNode), "子集 '%s' 的%s无效: %s", subset.Name, valueNode.Content[j].Value, decodeErrorMessage(err))
+				}
+			}
+		}
+		if members != nil {
+			if !isDeleteList(members) {
+				diags.errorf(nodePos(members), "子集 '%s' 的成员必须是常量名的列表", subset.Name)
+				continue
+			}
+			subset.Members = []string{}
+			for _, item := range members.Content {
+				subset.Members = append(subset.Members, strings.TrimSpace(item.Value))
+			}
+		}
+		if subset.Members != nil && subset.Tag != "" {
+			diags.errorf(subset.Pos, "子集 '%s' 不能同时给出members和tag", subset.Name)
+			continue
+		}
+		if subset.Label == "" {
+			subset.Label = subset.Name
+		}
+		subsets = append(subsets, subset)
+	}
+	return subsets
+}
+
+// checkSubsets 校验命名子集：子集名须是合法的标识符且不与组内常量、别名或生成的成员重名，
+// 直接列出的成员必须是同组内的常量（不能是别名）且不重复，子集至少有一个成员；
+// 位标志组和记录组不能声明子集
+func checkSubsets(group *ConstantGroup, diags *Diagnostics) {
+	if len(group.Subsets) == 0 {
+		return
+	}
+	if group.Flags || group.Record != nil {
+		diags.errorf(group.Pos, "位标志组和记录组不能声明subsets，常量组 '%s' 不能有命名子集", group.Name)
+		return
+	}
+
+	constants := make(map[string]bool)
+	aliases := make(map[string]bool)
+	for _, constant := range group.Constants {
+		constants[constant.Name] = true
+		for _, alias := range constant.Aliases {
+			aliases[alias.Name] = true
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, subset := range group.Subsets {
+		switch {
+		case !identifierPattern.MatchString(subset.Name):
+			diags.errorf(subset.Pos, "子集名 '%s' 不是合法的标识符", subset.Name)
+		case names[subset.Name]:
+			diags.errorf(subset.Pos, "常量组 '%s' 的子集 '%s' 重复声明", group.Name, subset.Name)
+		case constants[subset.Name] || aliases[subset.Name]:
+			diags.errorf(subset.Pos, "子集名 '%s' 与常量组 '%s' 中的常量或别名重名", subset.Name, group.Name)
+		case reservedSubsetNames[subset.Name]:
+			diags.errorf(subset.Pos, "子集名 '%s' 与生成的成员冲突", subset.Name)
+		}
+		names[subset.Name] = true
+
+		if subset.Tag == "" && len(subset.Members) == 0 {
+			diags.errorf(subset.Pos, "子集 '%s' 没有成员", subset.Name)
+			continue
+		}
+		if subset.Tag != "" {
+			if len(SubsetMembers(group, subset)) == 0 {
+				diags.errorf(subset.Pos, "子集 '%s' 的标记 '%s' 没有选中常量组 '%s' 中的任何常量", subset.Name, subset.Tag, group.Name)
+			}
+			continue
+		}
+		members := make(map[string]bool)
+		for _, member := range subset.Members {
+			switch {
+			case aliases[member]:
+				diags.errorf(subset.Pos, "子集 '%s' 的成员 '%s' 是别名，请使用常量名", subset.Name, member)
+			case !constants[member]:
+				diags.errorf(subset.Pos, "子集 '%s' 的成员 '%s' 不是常量组 '%s' 中的常量", subset.Name, member, group.Name)
+			case members[member]:
+				diags.errorf(subset.Pos, "子集 '%s' 的成员 '%s' 重复", subset.Name, member)
+			}
+			members[member] = true
+		}
+	}
+}
+
+// checkSubsetValues 校验声明了子集的常量组的值不能是布尔类型，以便生成值的集合
+func checkSubsetValues(group *ConstantGroup, diags *Diagnostics) {
+	for _, constant := range group.Constants {
+		if constant.Value != nil && constant.Type == "bool" {
+			diags.errorf(constant.ValuePos(), "声明了子集的常量组 '%s' 的常量 '%s' 的值类型为bool，值不能是布尔类型", group.Name, constant.Name)
+			return
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LabelTemplate 含占位符的标签，如 "用户 {name} 余额不足 {amount:int}"，生成器据此为常量生成格式化函数
+type LabelTemplate struct {
+	Parts  []TemplatePart  // 按顺序排列的字面文本和占位符
+	Params []TemplateParam // 格式化函数的参数，按占位符首次出现的顺序排列
+}
+
+// TemplatePart 标签模板的片段：Param 为空时是字面文本
+type TemplatePart struct {
+	Text  string // 字面文本，{{ 和 }} 已还原为单个花括号
+	Param string // 占位符名
+}
+
+// TemplateParam 占位符声明的参数
+type TemplateParam struct {
+	Name string // 参数名
+	Type string // 数据类型 (int, int64, float, bool, string)，未声明时为 string
+}
+
+// parseLabelTemplate 解析标签中的 {name} 或 {name:type} 占位符，{{ 和 }} 表示字面的花括号；
+// 标签中没有占位符时返回nil。同名占位符可以出现多次，但类型必须一致
+func parseLabelTemplate(label string) (*LabelTemplate, error) {
+	template := &LabelTemplate{}
+	types := make(map[string]string)
+	var text strings.Builder
+
+	for i := 0; i < len(label); i++ {
+		switch c := label[i]; {
+		case c == '{' && strings.HasPrefix(label[i:], "{{"):
+			text.WriteByte('{')
+			i++
+		case c == '}' && strings.HasPrefix(label[i:], "}}"):
+			text.WriteByte('}')
+			i++
+		case c == '}':
+			return nil, fmt.Errorf("标签 '%s' 中有未配对的 '}'（字面的花括号写作 '}}'）", label)
+		case c == '{':
+			end := strings.IndexByte(label[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("标签 '%s' 中的占位符缺少 '}'（字面的花括号写作 '{{'）", label)
+			}
+			token := label[i+1 : i+end]
+			i += end
+
+			name, dataType, typed := strings.Cut(token, ":")
+			name, dataType = strings.TrimSpace(name), strings.TrimSpace(dataType)
+			if !typed {
+				dataType = "string"
+			}
+			if !identifierPattern.MatchString(name) {
+				return nil, fmt.Errorf("标签 '%s' 中的占位符 '{%s}' 不是合法的标识符", label, token)
+			}
+			if _, supported := attributeTypes[dataType]; !supported {
+				return nil, fmt.Errorf("标签 '%s' 中占位符 '%s' 的类型 '%s' 无效，只能是 int、int64、float、bool 或 string", label, name, dataType)
+			}
+			if declared, exists := types[name]; exists {
+				if declared != dataType {
+					return nil, fmt.Errorf("标签 '%s' 中的占位符 '%s' 先后声明为%s和%s", label, name, declared, dataType)
+				}
+			} else {
+				types[name] = dataType
+				template.Params = append(template.Params, TemplateParam{Name: name, Type: dataType})
+			}
+
+			if text.Len() > 0 {
+				template.Parts = append(template.Parts, TemplatePart{Text: text.String()})
+				text.Reset()
+			}
+			template.Parts = append(template.Parts, TemplatePart{Param: name})
+		default:
+			text.WriteByte(c)
+		}
+	}
+
+	if len(template.Params) == 0 {
+		return nil, nil
+	}
+	if text.Len() > 0 {
+		template.Parts = append(template.Parts, TemplatePart{Text: text.String()})
+	}
+	return template, nil
+}
+
+// checkLabelTemplates 解析组内各常量标签中的占位符，格式错误的标签报告为错误
+func checkLabelTemplates(group *ConstantGroup, diags *Diagnostics) {
+	for _, constant := range group.Constants {
+		checkLabelTemplate(constant, diags)
+	}
+}
+
+// checkLabelTemplate 解析常量标签中的占位符
+func checkLabelTemplate(constant *Constant, diags *Diagnostics) {
+	template, err := parseLabelTemplate(constant.Label)
+	if err != nil {
+		diags.errorf(constant.Pos, "常量 '%s' 的%v", constant.Name, err)
+		return
+	}
+	// 格式化函数名由常量名生成，format_value 已用于按值格式化标签
+	if template != nil && strings.EqualFold(constant.Name, "value") {
+		diags.errorf(constant.Pos, "常量 '%s' 的标签含占位符，生成的格式化函数与内置的 format_value 重名", constant.Name)
+		return
+	}
+	constant.Template = template
+}
@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 树形常量组：常量通过parent字段或嵌套在父常量的children中指定同组内的父常量，
+// 用于地区代码、分类体系等层级结构；生成器据此输出查询父节点、子节点和祖先的辅助函数。
+
+// childrenNode 返回映射形式常量的children字段，没有该字段时返回nil
+func childrenNode(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "children" {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// flattenChildren 将嵌套在children中的子常量按深度优先的顺序展开到常量列表中，子常量以外层常量为父常量
+func flattenChildren(constants []*Constant, diags *Diagnostics) []*Constant {
+	var flat []*Constant
+	for _, constant := range constants {
+		flat = append(flat, constant)
+		for _, child := range constant.children {
+			if child.Parent != "" && child.Parent != constant.Name {
+				diags.errorf(child.Pos, "常量 '%s' 嵌套在 '%s' 的children中，不能再指定父常量 '%s'", child.Name, constant.Name, child.Parent)
+			}
+			child.Parent = constant.Name
+		}
+		flat = append(flat, flattenChildren(constant.children, diags)...)
+		constant.children = nil
+	}
+	return flat
+}
+
+// IsTreeGroup 判断常量组是否为树形常量组（有常量指定了父常量）
+func IsTreeGroup(group *ConstantGroup) bool {
+	for _, constant := range group.Constants {
+		if constant.Parent != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTree 校验树形常量组：父常量必须是同组内的其他常量（不能是别名），父子关系不能形成环，
+// 位标志组和记录组不能是树形常量组
+func checkTree(group *ConstantGroup, diags *Diagnostics) {
+	if !IsTreeGroup(group) {
+		return
+	}
+	if group.Flags || group.Record != nil {
+		diags.errorf(group.Pos, "位标志组和记录组不能指定父常量，常量组 '%s' 不能是树形常量组", group.Name)
+		return
+	}
+
+	constants := make(map[string]*Constant)
+	aliases := make(map[string]bool)
+	for _, constant := range group.Constants {
+		constants[constant.Name] = constant
+		for _, alias := range constant.Aliases {
+			aliases[alias.Name] = true
+		}
+	}
+
+	valid := true
+	for _, constant := range group.Constants {
+		if constant.Parent == "" {
+			continue
+		}
+		switch {
+		case constant.Parent == constant.Name:
+			diags.errorf(constant.Pos, "常量 '%s' 的父常量不能是它自身", constant.Name)
+			valid = false
+		case aliases[constant.Parent]:
+			diags.errorf(constant.Pos, "常量 '%s' 的父常量 '%s' 是别名，请使用常量名", constant.Name, constant.Parent)
+			valid = false
+		case constants[constant.Parent] == nil:
+			diags.errorf(constant.Pos, "常量 '%s' 的父常量 '%s' 不是常量组 '%s' 中的常量", constant.Name, constant.Parent, group.Name)
+			valid = false
+		}
+	}
+	if !valid {
+		return
+	}
+
+	// 沿父常量向上查找，回到起点即形成环；每个环只在其中最先定义的常量处报告一次
+	reported := make(map[*Constant]bool)
+	for _, constant := range group.Constants {
+		path := []string{constant.Name}
+		for parent := constants[constant.Parent]; parent != nil && len(path) <= len(group.Constants); parent = constants[parent.Parent] {
+			path = append(path, parent.Name)
+			if parent != constant {
+				continue
+			}
+			if !reported[constant] {
+				diags.errorf(constant.Pos, "常量 '%s' 的父常量形成了环: %s", constant.Name, strings.Join(path, " -> "))
+			}
+			for _, name := range path {
+				reported[constants[name]] = true
+			}
+			break
+		}
+	}
+}
+
+// checkNodeValues 校验树形常量组、状态机组的值：只能是整数或字符串且互不相同，以便按值查询父子节点或后继状态，
+// kind 为诊断信息中常量组的类别
+func checkNodeValues(group *ConstantGroup, kind string, diags *Diagnostics) {
+	values := make(map[string]*Constant)
+	for _, constant := range group.Constants {
+		if constant.Value == nil {
+			// 引用在解析后再校验
+			continue
+		}
+		if !IsIntegerType(constant.Type) && constant.Type != "string" {
+			diags.errorf(constant.ValuePos(), "%s '%s' 的常量 '%s' 的值类型为%s，值只能是整数或字符串", kind, group.Name, constant.Name, constant.Type)
+			continue
+		}
+		key := fmt.Sprint(constant.Value)
+		if other, duplicated := values[key]; duplicated {
+			diags.errorf(constant.ValuePos(), "%s '%s' 的常量 '%s' 与 '%s' 的值 %v 相同（同一节点的其他名称可写作别名）",
+				kind, group.Name, constant.Name, other.Name, constant.Value)
+			continue
+		}
+		values[key] = constant
+	}
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// treeGroup 构造树形常量组，parents 依次给出各常量的名称和父常量（空串表示根节点）
+func treeGroup(parents ...string) *ConstantGroup {
+	group := &ConstantGroup{Name: "region"}
+	for i := 0; i+1 < len(parents); i += 2 {
+		group.Constants = append(group.Constants, &Constant{
+			Name:   parents[i],
+			Parent: parents[i+1],
+			Pos:    Position{Line: i/2 + 2, Column: 3},
+		})
+	}
+	return group
+}
+
+func TestCheckTree(t *testing.T) {
+	tests := []struct {
+		name  string
+		group *ConstantGroup
+		want  []string
+	}{
+		{"有效的树", treeGroup("asia", "", "china", "asia", "beijing", "china", "japan", "asia"), nil},
+		{"父常量是自身", treeGroup("asia", "asia"), []string{"常量 'asia' 的父常量不能是它自身"}},
+		{"父常量不存在", treeGroup("asia", "", "china", "europe"), []string{"父常量 'europe' 不是常量组 'region' 中的常量"}},
+		{"两个常量形成环", treeGroup("a", "b", "b", "a"), []string{"常量 'a' 的父常量形成了环: a -> b -> a"}},
+		{
+			"环上的常量只报告一次，挂在环上的常量不报告",
+			treeGroup("root", "", "a", "c", "b", "a", "c", "b", "leaf", "a"),
+			[]string{"常量 'a' 的父常量形成了环: a -> c -> b -> a"},
+		},
+		{
+			"多个独立的环",
+			treeGroup("a", "b", "b", "a", "c", "d", "d", "c"),
+			[]string{"a -> b -> a", "c -> d -> c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags Diagnostics
+			checkTree(tt.group, &diags)
+			messages := diagnosticMessages(diags, SeverityError)
+			if len(messages) != len(tt.want) {
+				t.Fatalf("checkTree() = %q, want %d errors", messages, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(messages[i], want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, messages[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckTreeAlias(t *testing.T) {
+	group := treeGroup("asia", "", "china", "cn")
+	group.Constants[0].Aliases = []Alias{{Name: "cn"}}
+	var diags Diagnostics
+	checkTree(group, &diags)
+	if messages := diagnosticMessages(diags, SeverityError); len(messages) != 1 || !strings.Contains(messages[0], "是别名") {
+		t.Errorf("checkTree() = %q, want an error about the alias parent", messages)
+	}
+}
@@ -0,0 +1,680 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlReader YAML格式源文件读取器，保留注释作为标签
+type yamlReader struct{}
+
+// Extensions 支持 .yaml 和 .yml
+func (yamlReader) Extensions() []string {
+	return []string{".yaml", ".yml"}
+}
+
+// Read 解析YAML文件并提取注释
+func (yamlReader) Read(data []byte, fileName string) (string, []*ConstantGroup, Diagnostics) {
+	var diags Diagnostics
+	label, groups := parseYAMLWithComments(data, fileName, &diags)
+	return label, groups, diags
+}
+
+// yamlErrorLine 匹配yaml.v3错误信息中的行号，如 "line 3: did not find expected key"
+var yamlErrorLine = regexp.MustCompile(`line (\d+): (.*)`)
+
+// yamlErrors 将yaml.v3返回的错误拆分为带行号的诊断
+func yamlErrors(err error) Diagnostics {
+	var diags Diagnostics
+	for _, line := range strings.Split(strings.TrimPrefix(err.Error(), "yaml: "), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "unmarshal errors:" {
+			continue
+		}
+		if match := yamlErrorLine.FindStringSubmatch(line); match != nil {
+			lineNo, _ := strconv.Atoi(match[1])
+			diags.errorf(Position{Line: lineNo}, "语法错误: %s", match[2])
+		} else {
+			diags.errorf(Position{}, "语法错误: %s", line)
+		}
+	}
+	return diags
+}
+
+// nodePos 返回YAML节点在源文件中的位置
+func nodePos(node *yaml.Node) Position {
+	return Position{Line: node.Line, Column: node.Column}
+}
+
+// parseYAMLWithComments 解析YAML文件并提取注释
+//
+// 顶层的标量或映射形式常量归入以文件名命名的常量组；
+// 值为常量映射的顶层键各自成为一个独立的常量组。
+func parseYAMLWithComments(data []byte, fileName string, diags *Diagnostics) (string, []*ConstantGroup) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		*diags = append(*diags, yamlErrors(err)...)
+		return "", nil
+	}
+
+	// 空文件
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return firstCommentLine(doc.HeadComment, doc.FootComment), nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		diags.errorf(nodePos(root), "顶层必须是键值映射")
+		return "", nil
+	}
+
+	// 提取文件标签（第一条整行注释）
+	label := firstCommentLine(doc.HeadComment, root.HeadComment)
+
+	var constants []*Constant
+	var groups []*ConstantGroup
+	var auto *AutoIncrement
+	var flags bool
+	var record *RecordSchema
+	var attributes []*Attribute
+	var transitions []*Transition
+	var subsets []*Subset
+	var deletions []Deletion
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+
+		if label == "" {
+			label = firstCommentLine(keyNode.HeadComment)
+		}
+
+		// 顶层的auto、flags、record、attributes、transitions、subsets指令作用于以文件名命名的常量组
+		if isAutoDirectiveNode(keyNode, valueNode) {
+			auto = parseAutoNode(valueNode, diags)
+			continue
+		}
+		if isFlagsDirectiveNode(keyNode, valueNode) {
+			flags = parseBoolNode(valueNode)
+			continue
+		}
+		if isRecordDirectiveNode(keyNode, valueNode) {
+			record = parseRecordNode(valueNode, diags)
+			continue
+		}
+		if isAttributesDirective(strings.TrimSpace(keyNode.Value), valueNode) {
+			attributes = parseAttributeDeclarations(valueNode, diags)
+			continue
+		}
+		if isTransitionsDirective(keyNode, valueNode) {
+			transitions = parseTransitionsNode(valueNode, diags)
+			continue
+		}
+		if isSubsetsDirective(keyNode, valueNode) {
+			subsets = parseSubsetsNode(valueNode, diags)
+			continue
+		}
+		if isDeleteDirectiveNode(keyNode, valueNode) {
+			deletions = append(deletions, parseDeleteNode(valueNode)...)
+			continue
+		}
+
+		// 嵌套的常量组
+		if isGroupNode(valueNode) {
+			groups = append(groups, parseGroupNode(keyNode, valueNode, diags))
+			continue
+		}
+
+		// 解析常量节点
+		constant, err := parseConstantNode(keyNode, valueNode, diags)
+		if err != nil {
+			diags.add(err)
+			continue
+		}
+		constants = append(constants, constant)
+	}
+
+	if label == "" {
+		label = firstCommentLine(root.FootComment, doc.FootComment)
+	}
+
+	// 顶层常量组成以文件名命名的常量组
+	if len(constants) > 0 || len(deletions) > 0 {
+		fileGroup := &ConstantGroup{
+			Name:       fileName,
+			Label:      label,
+			Constants:  constants,
+			Auto:       auto,
+			Flags:      flags,
+			Attributes: attributes,
+			Record:     record,
+			Deletions:  deletions,
+			Pos:        nodePos(root),
+
+			Transitions: transitions,
+			Subsets:     subsets,
+		}
+		groups = append([]*ConstantGroup{fileGroup}, groups...)
+	}
+
+	return label, groups
+}
+
+// isAutoDirectiveNode 判断键值节点是否为auto指令
+func isAutoDirectiveNode(keyNode, valueNode *yaml.Node) bool {
+	return valueNode.Kind == yaml.ScalarNode &&
+		isAutoDirective(strings.TrimSpace(keyNode.Value), valueNode.Value, valueNode.ShortTag() == "!!bool")
+}
+
+// isFlagsDirectiveNode 判断键值节点是否为flags指令
+func isFlagsDirectiveNode(keyNode, valueNode *yaml.Node) bool {
+	return valueNode.Kind == yaml.ScalarNode &&
+		isFlagsDirective(strings.TrimSpace(keyNode.Value), valueNode.ShortTag() == "!!bool")
+}
+
+// isRecordDirectiveNode 判断键值节点是否为record指令
+func isRecordDirectiveNode(keyNode, valueNode *yaml.Node) bool {
+	return valueNode.Kind == yaml.ScalarNode &&
+		isRecordDirective(strings.TrimSpace(keyNode.Value), valueNode.Value, valueNode.ShortTag() == "!!bool")
+}
+
+// parseBoolNode 解析布尔值节点（调用方已确认标签为!!bool）
+func parseBoolNode(node *yaml.Node) bool {
+	var value bool
+	_ = node.Decode(&value)
+	return value
+}
+
+// parseAutoNode 解析auto指令节点，无效时记录错误并返回nil
+func parseAutoNode(node *yaml.Node, diags *Diagnostics) *AutoIncrement {
+	auto, err := parseAutoDirective(node.Value)
+	if err != nil {
+		diags.errorf(nodePos(node), "%v", err)
+		return nil
+	}
+	return auto
+}
+
+// parseRecordNode 解析record指令节点，无效时记录错误并返回nil
+func parseRecordNode(node *yaml.Node, diags *Diagnostics) *RecordSchema {
+	record, err := parseRecordDirective(node.Value)
+	if err != nil {
+		diags.errorf(nodePos(node), "%v", err)
+		return nil
+	}
+	return record
+}
+
+// constantFields 映射形式常量允许的字段
+var constantFields = map[string]bool{
+	"value":       true,
+	"label":       true,
+	"description": true,
+	"deprecated":  true,
+	"tags":        true,
+	"type":        true,
+
+	"replaced_by":        true,
+	"aliases":            true,
+	"deprecated_aliases": true,
+	"attributes":         true,
+	"parent":             true,
+	"children":           true,
+}
+
+// isGroupNode 判断节点是否为常量组（列表，或映射且不是映射形式的常量）
+func isGroupNode(node *yaml.Node) bool {
+	if node.Kind == yaml.SequenceNode {
+		return true
+	}
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+
+	hasValue := false
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		field := node.Content[i].Value
+		if !constantFields[field] {
+			return true
+		}
+		if field == "value" {
+			hasValue = isValueNode(node.Content[i+1])
+		}
+	}
+	return !hasValue
+}
+
+// isValueNode 判断value字段的节点是否为常量值：标量、列表（集合值），或含有常量字段以外的键的映射（映射值），
+// 只含常量字段的映射视为名为value的映射形式常量
+func isValueNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode, yaml.AliasNode, yaml.SequenceNode:
+		return true
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if !constantFields[node.Content[i].Value] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseGroupNode 解析常量组节点，组标签取键的行内注释或上方最近的一行注释
+func parseGroupNode(keyNode, valueNode *yaml.Node, diags *Diagnostics) *ConstantGroup {
+	name := strings.TrimSpace(keyNode.Value)
+
+	label := firstCommentLine(keyNode.LineComment, valueNode.LineComment)
+	if label == "" {
+		label = lastCommentLine(keyNode.HeadComment)
+	}
+	if label == "" {
+		label = name
+	}
+
+	group := &ConstantGroup{
+		Name:  name,
+		Label: label,
+		Pos:   nodePos(keyNode),
+	}
+
+	// 列表形式: 按顺序自增赋值
+	if valueNode.Kind == yaml.SequenceNode {
+		group.Auto = &AutoIncrement{Start: 0, Step: 1}
+		for _, itemNode := range valueNode.Content {
+			switch {
+			case itemNode.Kind == yaml.ScalarNode:
+				if itemNode.LineComment == "" {
+					diags.warnf(nodePos(itemNode), "常量 '%s' 缺少行内注释，标签为空", itemNode.Value)
+				}
+				group.Constants = append(group.Constants, &Constant{
+					Name:  strings.TrimSpace(itemNode.Value),
+					Label: firstCommentLine(itemNode.LineComment),
+					Pos:   nodePos(itemNode),
+				})
+			case itemNode.Kind == yaml.MappingNode && len(itemNode.Content) == 2:
+				// - auto: start=1 或 - name: {label: ...}
+				if isAutoDirectiveNode(itemNode.Content[0], itemNode.Content[1]) {
+					group.Auto = parseAutoNode(itemNode.Content[1], diags)
+					continue
+				}
+				if isFlagsDirectiveNode(itemNode.Content[0], itemNode.Content[1]) {
+					group.Flags = parseBoolNode(itemNode.Content[1])
+					continue
+				}
+				if isRecordDirectiveNode(itemNode.Content[0], itemNode.Content[1]) {
+					group.Record = parseRecordNode(itemNode.Content[1], diags)
+					continue
+				}
+				if isAttributesDirective(strings.TrimSpace(itemNode.Content[0].Value), itemNode.Content[1]) {
+					group.Attributes = parseAttributeDeclarations(itemNode.Content[1], diags)
+					continue
+				}
+				if isTransitionsDirective(itemNode.Content[0], itemNode.Content[1]) {
+					group.Transitions = parseTransitionsNode(itemNode.Content[1], diags)
+					continue
+				}
+				if isSubsetsDirective(itemNode.Content[0], itemNode.Content[1]) {
+					group.Subsets = parseSubsetsNode(itemNode.Content[1], diags)
+					continue
+				}
+				if isDeleteDirectiveNode(itemNode.Content[0], itemNode.Content[1]) {
+					group.Deletions = append(group.Deletions, parseDeleteNode(itemNode.Content[1])...)
+					continue
+				}
+				constant, err := parseConstantNode(itemNode.Content[0], itemNode.Content[1], diags)
+				if err != nil {
+					diags.add(err)
+					continue
+				}
+				group.Constants = append(group.Constants, constant)
+			default:
+				diags.errorf(nodePos(itemNode), "常量组 '%s' 的列表项必须是常量名或单个键值", name)
+			}
+		}
+		return group
+	}
+
+	for i := 0; i+1 < len(valueNode.Content); i += 2 {
+		if isAutoDirectiveNode(valueNode.Content[i], valueNode.Content[i+1]) {
+			group.Auto = parseAutoNode(valueNode.Content[i+1], diags)
+			continue
+		}
+		if isFlagsDirectiveNode(valueNode.Content[i], valueNode.Content[i+1]) {
+			group.Flags = parseBoolNode(valueNode.Content[i+1])
+			continue
+		}
+		if isRecordDirectiveNode(valueNode.Content[i], valueNode.Content[i+1]) {
+			group.Record = parseRecordNode(valueNode.Content[i+1], diags)
+			continue
+		}
+		if isAttributesDirective(strings.TrimSpace(valueNode.Content[i].Value), valueNode.Content[i+1]) {
+			group.Attributes = parseAttributeDeclarations(valueNode.Content[i+1], diags)
+			continue
+		}
+		if isTransitionsDirective(valueNode.Content[i], valueNode.Content[i+1]) {
+			group.Transitions = parseTransitionsNode(valueNode.Content[i+1], diags)
+			continue
+		}
+		if isSubsetsDirective(valueNode.Content[i], valueNode.Content[i+1]) {
+			group.Subsets = parseSubsetsNode(valueNode.Content[i+1], diags)
+			continue
+		}
+		if isDeleteDirectiveNode(valueNode.Content[i], valueNode.Content[i+1]) {
+			group.Deletions = append(group.Deletions, parseDeleteNode(valueNode.Content[i+1])...)
+			continue
+		}
+
+		constant, err := parseConstantNode(valueNode.Content[i], valueNode.Content[i+1], diags)
+		if err != nil {
+			diags.add(err)
+			continue
+		}
+		group.Constants = append(group.Constants, constant)
+	}
+
+	return group
+}
+
+// parseConstantNode 解析单个常量键值节点，缺少注释等不影响生成的问题记为警告
+func parseConstantNode(keyNode, valueNode *yaml.Node, diags *Diagnostics) (*Constant, error) {
+	name := strings.TrimSpace(keyNode.Value)
+
+	// 行内注释作为标签
+	comment := firstCommentLine(valueNode.LineComment, keyNode.LineComment)
+
+	// 展开锚点引用
+	if valueNode.Kind == yaml.AliasNode {
+		valueNode = valueNode.Alias
+	}
+
+	// 映射形式: key: {value: 1, label: ..., description: ...}
+	if valueNode.Kind == yaml.MappingNode {
+		constant, err := parseConstantMapping(name, valueNode, comment)
+		if err != nil {
+			return nil, err
+		}
+		constant.Pos = nodePos(keyNode)
+		if children := childrenNode(valueNode); children != nil {
+			constant.children = parseYAMLChildren(children, diags)
+		}
+		return constant, nil
+	}
+
+	// 省略值: key: # 标签，由auto指令赋值
+	if valueNode.Kind == yaml.ScalarNode && valueNode.ShortTag() == "!!null" && valueNode.Style&yaml.TaggedStyle == 0 {
+		if comment == "" {
+			diags.warnf(nodePos(keyNode), "常量 '%s' 缺少行内注释，标签为空", name)
+		}
+		return &Constant{
+			Name:  name,
+			Label: comment,
+			Pos:   nodePos(keyNode),
+		}, nil
+	}
+
+	// 引用: key: ${group.key}，未填写标签时沿用目标常量的标签
+	if ref, err := scalarReference(valueNode); err != nil || ref != nil {
+		if err != nil {
+			return nil, errorAt(nodePos(valueNode), "常量 '%s' 的值无效: %v", name, err)
+		}
+		return &Constant{
+			Name:  name,
+			Label: comment,
+			Ref:   ref,
+			Pos:   nodePos(keyNode),
+		}, nil
+	}
+
+	value, dataType, err := parseScalarValue(valueNode)
+	if err != nil {
+		return nil, errorAt(nodePos(valueNode), "常量 '%s' 的值无效: %v", name, err)
+	}
+
+	if comment == "" {
+		diags.warnf(nodePos(keyNode), "常量 '%s' 缺少行内注释，标签为空", name)
+	}
+
+	return &Constant{
+		Name:    name,
+		Type:    dataType,
+		Label:   comment,
+		Value:   value,
+		Literal: integerLiteral(valueNode.Value, dataType),
+		Pos:     nodePos(keyNode),
+	}, nil
+}
+
+// parseConstantMapping 解析映射形式的常量定义
+func parseConstantMapping(name string, node *yaml.Node, comment string) (*Constant, error) {
+	constant := &Constant{
+		Name:  name,
+		Label: comment,
+		Pos:   nodePos(node),
+	}
+
+	var valueNode *yaml.Node
+	var explicitType string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fieldKey, fieldNode := node.Content[i], node.Content[i+1]
+		field := fieldKey.Value
+
+		var err error
+		switch field {
+		case "value":
+			valueNode = fieldNode
+		case "type":
+			err = fieldNode.Decode(&explicitType)
+		case "label":
+			// 映射形式按语言给出标签，默认语言的标签由 ApplyLocale 确定
+			if fieldNode.Kind == yaml.MappingNode {
+				if err := parseLocalizedLabel(constant, fieldNode); err != nil {
+					return nil, err
+				}
+				break
+			}
+			err = fieldNode.Decode(&constant.Label)
+		case "description":
+			err = fieldNode.Decode(&constant.Description)
+		case "deprecated":
+			// true/false，或直接写废弃说明
+			if fieldNode.ShortTag() == "!!bool" {
+				err = fieldNode.Decode(&constant.Deprecated)
+			} else {
+				err = fieldNode.Decode(&constant.DeprecatedMessage)
+				constant.Deprecated = true
+			}
+		case "replaced_by":
+			err = fieldNode.Decode(&constant.ReplacedBy)
+			constant.Deprecated = true
+		case "aliases", "deprecated_aliases":
+			var names []string
+			names, err = decodeStringList(fieldNode)
+			for _, alias := range names {
+				constant.Aliases = append(constant.Aliases, Alias{Name: alias, Deprecated: field == "deprecated_aliases"})
+			}
+		case "tags":
+			constant.Tags, err = decodeStringList(fieldNode)
+		case "attributes":
+			if err := parseAttributeValues(constant, fieldNode); err != nil {
+				return nil, err
+			}
+		case "parent":
+			err = fieldNode.Decode(&constant.Parent)
+		case "children":
+			// 子常量的写法与映射形式的常量组相同，由调用方按源文件格式解析
+			if fieldNode.Kind != yaml.MappingNode {
+				return nil, errorAt(nodePos(fieldNode), "常量 '%s' 的字段 'children' 必须是映射", name)
+			}
+		default:
+			return nil, errorAt(nodePos(fieldKey), "常量 '%s' 的未知字段 '%s'", name, field)
+		}
+		if err != nil {
+			return nil, errorAt(nodePos(fieldNode), "常量 '%s' 的字段 '%s' 无效: %s", name, field, decodeErrorMessage(err))
+		}
+	}
+
+	// 未给出value时由auto指令赋值
+	if valueNode == nil {
+		if IsCollectionType(explicitType) || explicitType == "regex" {
+			return nil, errorAt(nodePos(node), "常量 '%s' 的类型为%s，必须给出value", name, explicitType)
+		}
+		if explicitType != "" {
+			constant.Type = explicitType
+		}
+		return constant, nil
+	}
+
+	// 显式指定的type优先于推断的类型
+	var err error
+	if valueNode.Kind == yaml.AliasNode {
+		valueNode = valueNode.Alias
+	}
+	if ref, err := scalarReference(valueNode); err != nil || ref != nil {
+		if err != nil {
+			return nil, errorAt(nodePos(valueNode), "常量 '%s' 的值无效: %v", name, err)
+		}
+		constant.Type = explicitType
+		constant.Ref = ref
+		return constant, nil
+	}
+	if valueNode.Kind == yaml.SequenceNode || valueNode.Kind == yaml.MappingNode {
+		constant.Value, constant.Type, err = parseCollectionNode(valueNode, explicitType)
+		if err != nil {
+			return nil, errorAt(nodePos(valueNode), "常量 '%s' 的值无效: %v", name, err)
+		}
+		return constant, nil
+	}
+	if kind, _, ok := CollectionType(explicitType); ok {
+		return nil, errorAt(nodePos(valueNode), "常量 '%s' 的类型为%s，值必须是%s", name, explicitType, collectionKindNames[kind])
+	}
+	if explicitType != "" {
+		if valueNode.Kind != yaml.ScalarNode {
+			return nil, errorAt(nodePos(valueNode), "常量 '%s' 的值必须是标量", name)
+		}
+		constant.Type = explicitType
+		constant.Value, err = ConvertValue(valueNode.Value, explicitType)
+	} else {
+		constant.Value, constant.Type, err = parseScalarValue(valueNode)
+	}
+	if err != nil {
+		return nil, errorAt(nodePos(valueNode), "常量 '%s' 的值无效: %v", name, err)
+	}
+	constant.Literal = integerLiteral(valueNode.Value, constant.Type)
+
+	return constant, nil
+}
+
+// parseYAMLChildren 解析常量的children映射，每个键值是一个子常量，写法与映射形式的常量组中的常量相同
+func parseYAMLChildren(node *yaml.Node, diags *Diagnostics) []*Constant {
+	var children []*Constant
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		child, err := parseConstantNode(node.Content[i], node.Content[i+1], diags)
+		if err != nil {
+			diags.add(err)
+			continue
+		}
+		children = append(children, child)
+	}
+	return children
+}
+
+// decodeStringList 解析字符串列表，单个标量视为只有一项的列表
+func decodeStringList(node *yaml.Node) ([]string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return []string{node.Value}, nil
+	}
+	var list []string
+	err := node.Decode(&list)
+	return list, err
+}
+
+// decodeErrorMessage 去掉yaml.v3解码错误中的前缀和行号，位置由诊断单独给出
+func decodeErrorMessage(err error) string {
+	if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+		return match[2]
+	}
+	return err.Error()
+}
+
+// integerLiteralPattern 整数字面量（十进制或带 0x/0o/0b 前缀，允许下划线分隔）
+var integerLiteralPattern = regexp.MustCompile(`^[-+]?(0[xX][0-9a-fA-F_]+|0[oO][0-7_]+|0[bB][01_]+|[0-9][0-9_]*)$`)
+
+// parseScalarValue 解析标量节点的值并推断类型
+//
+// 类型由YAML标签决定：显式标签（如 !!float 1、!!str 123）必须能转换成功；
+// 隐式推断为数值但无法表示的值（如超出范围的整数）按字符串处理。
+func parseScalarValue(node *yaml.Node) (interface{}, string, error) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.ScalarNode {
+		return nil, "", fmt.Errorf("常量值必须是标量")
+	}
+
+	dataType := "string"
+	switch node.ShortTag() {
+	case "!!int":
+		dataType = "int"
+	case "!!float":
+		dataType = "float"
+		// 超出uint64的整数会被YAML解析为浮点数，按整数处理以免丢失精度
+		if node.Style&yaml.TaggedStyle == 0 && integerLiteralPattern.MatchString(node.Value) {
+			dataType = "int"
+		}
+	case "!!str":
+		// 超出uint64的十六进制等整数会被YAML解析为字符串，未加引号时按整数处理
+		if node.Style == 0 && integerLiteralPattern.MatchString(node.Value) {
+			dataType = "int"
+		}
+	case "!!bool":
+		dataType = "bool"
+	case "!!null":
+		return nil, "", fmt.Errorf("常量值不能为空")
+	}
+
+	value, err := ConvertValue(node.Value, dataType)
+	if err != nil {
+		if node.Style&yaml.TaggedStyle != 0 {
+			return nil, "", err
+		}
+		// 默认为字符串
+		return node.Value, "string", nil
+	}
+
+	return value, dataType, nil
+}
+
+// scalarReference 解析标量节点中的常量引用，带显式标签（如 !!str）的值不视为引用
+func scalarReference(node *yaml.Node) (*Reference, error) {
+	if node.Kind != yaml.ScalarNode || node.Style&yaml.TaggedStyle != 0 {
+		return nil, nil
+	}
+	return parseReference(node.Value)
+}
+
+// lastCommentLine 返回注释块中的最后一行注释内容（去掉#前缀）
+func lastCommentLine(comment string) string {
+	lines := strings.Split(comment, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := firstCommentLine(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// firstCommentLine 返回若干注释块中的第一行注释内容（去掉#前缀）
+func firstCommentLine(comments ...string) string {
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+			if line != "" {
+				return line
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,220 @@
+package translation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// poFormat gettext PO：每个常量标签一个条目，msgctxt 为 "源文件#常量组.常量"，
+// 常量组的说明和常量的描述写作提取注释（#.），常量在源文件中的位置写作引用注释（#:）
+type poFormat struct{}
+
+// Name 返回格式名
+func (poFormat) Name() string {
+	return "po"
+}
+
+// Extension 返回文件扩展名
+func (poFormat) Extension() string {
+	return ".po"
+}
+
+// Write 写出PO文件，头部条目记录目标语言和原文语言
+func (poFormat) Write(w io.Writer, catalog *Catalog) error {
+	var sb strings.Builder
+	sb.WriteString("msgid \"\"\n")
+	sb.WriteString("msgstr \"\"\n")
+	sb.WriteString("\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	sb.WriteString("\"Content-Transfer-Encoding: 8bit\\n\"\n")
+	sb.WriteString(fmt.Sprintf("\"Language: %s\\n\"\n", catalog.TargetLocale))
+	sb.WriteString(fmt.Sprintf("\"X-Source-Language: %s\\n\"\n", catalog.SourceLocale))
+
+	for _, unit := range catalog.Units {
+		sb.WriteString("\n")
+		if label := catalog.groupLabel(unit.File, unit.Group); label != "" {
+			sb.WriteString(fmt.Sprintf("#. %s: %s\n", unit.Group, label))
+		}
+		for _, note := range unit.Notes {
+			for _, line := range strings.Split(note, "\n") {
+				sb.WriteString(fmt.Sprintf("#. %s\n", line))
+			}
+		}
+		if unit.Line > 0 {
+			sb.WriteString(fmt.Sprintf("#: %s:%d\n", unit.File, unit.Line))
+		} else {
+			sb.WriteString(fmt.Sprintf("#: %s\n", unit.File))
+		}
+		sb.WriteString(fmt.Sprintf("msgctxt %s\n", poQuote(unit.File+"#"+unit.ID())))
+		sb.WriteString(fmt.Sprintf("msgid %s\n", poQuote(unit.Source)))
+		sb.WriteString(fmt.Sprintf("msgstr %s\n", poQuote(unit.Target)))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// poEntry PO文件中的一个条目
+type poEntry struct {
+	context string
+	id      string
+	str     string
+	fuzzy   bool
+	line    int
+}
+
+// Read 解析PO文件；标记为 fuzzy 的译文视为尚未翻译，过时条目（#~）被忽略
+func (poFormat) Read(data []byte) (*Catalog, error) {
+	entries, err := parsePOEntries(data)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &Catalog{}
+	for _, entry := range entries {
+		if entry.id == "" && entry.context == "" {
+			for _, line := range strings.Split(entry.str, "\n") {
+				name, value, _ := strings.Cut(line, ":")
+				switch strings.TrimSpace(name) {
+				case "Language":
+					catalog.TargetLocale = strings.TrimSpace(value)
+				case "X-Source-Language":
+					catalog.SourceLocale = strings.TrimSpace(value)
+				}
+			}
+			continue
+		}
+
+		file, id, found := strings.Cut(entry.context, "#")
+		group, key, dotted := strings.Cut(id, ".")
+		if !found || !dotted {
+			return nil, fmt.Errorf("第 %d 行: 条目的 msgctxt '%s' 不是 \"源文件#常量组.常量\" 的形式", entry.line, entry.context)
+		}
+		unit := &Unit{File: file, Group: group, Key: key, Source: entry.id, Line: entry.line}
+		if !entry.fuzzy {
+			unit.Target = entry.str
+		}
+		catalog.Units = append(catalog.Units, unit)
+	}
+	if catalog.TargetLocale == "" {
+		return nil, fmt.Errorf("PO文件头部没有指定目标语言（Language）")
+	}
+	return catalog, nil
+}
+
+// parsePOEntries 按行解析PO文件的条目，支持跨行的字符串，不支持复数形式
+func parsePOEntries(data []byte) ([]*poEntry, error) {
+	var entries []*poEntry
+	var entry *poEntry
+	var field *string
+	fuzzy := false
+
+	flush := func() {
+		if entry != nil {
+			entry.fuzzy = fuzzy
+			entries = append(entries, entry)
+		}
+		entry, field, fuzzy = nil, nil, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		// msgstr 之后出现注释或新的关键字时，上一个条目结束
+		complete := entry != nil && field == &entry.str
+
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+			if complete {
+				flush()
+			}
+			if strings.HasPrefix(line, "#,") && strings.Contains(line, "fuzzy") {
+				fuzzy = true
+			}
+		case strings.HasPrefix(line, "\""):
+			if field == nil {
+				return nil, fmt.Errorf("第 %d 行: 字符串前缺少关键字", lineNumber)
+			}
+			text, err := poUnquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 行: %v", lineNumber, err)
+			}
+			*field += text
+		default:
+			keyword, rest, _ := strings.Cut(line, " ")
+			text, err := poUnquote(strings.TrimSpace(rest))
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 行: %v", lineNumber, err)
+			}
+			if complete && keyword != "msgstr" {
+				flush()
+			}
+			if entry == nil {
+				entry = &poEntry{line: lineNumber}
+			}
+			switch keyword {
+			case "msgctxt":
+				field = &entry.context
+			case "msgid":
+				field = &entry.id
+			case "msgstr":
+				field = &entry.str
+			default:
+				return nil, fmt.Errorf("第 %d 行: 不支持的关键字 '%s'", lineNumber, keyword)
+			}
+			*field = text
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return entries, nil
+}
+
+// poQuote 按PO的转义规则将文本写为双引号字符串
+func poQuote(text string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range text {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// poUnquote 还原PO的双引号字符串
+func poUnquote(text string) (string, error) {
+	if len(text) < 2 || text[0] != '"' || text[len(text)-1] != '"' {
+		return "", fmt.Errorf("'%s' 不是双引号字符串", text)
+	}
+	value, err := strconv.Unquote(text)
+	if err != nil {
+		return "", fmt.Errorf("字符串 %s 的转义无效", text)
+	}
+	return value, nil
+}
@@ -0,0 +1,214 @@
+// Package translation 在常量源文件与翻译工具使用的文件格式（XLIFF、gettext PO）之间导出和导入常量标签的翻译
+package translation
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cons-coder/parser"
+)
+
+// Unit 一条待翻译的常量标签
+type Unit struct {
+	File   string   // 源文件相对于输入目录的路径，以 / 分隔
+	Group  string   // 常量组名
+	Key    string   // 常量名
+	Source string   // 默认语言的标签
+	Target string   // 目标语言的标签，尚未翻译时为空
+	Notes  []string // 给译者的说明，如常量的描述、标签中须原样保留的占位符
+	Line   int      // 常量在源文件中的行号，0表示未知
+}
+
+// ID 返回翻译单元在源文件内的标识，如 user_role.admin
+func (u *Unit) ID() string {
+	return u.Group + "." + u.Key
+}
+
+// SourcePath 返回翻译单元所在源文件的路径，rootDir 为导出时的输入目录
+func (u *Unit) SourcePath(rootDir string) string {
+	return filepath.Join(rootDir, filepath.FromSlash(u.File))
+}
+
+// GroupLabel 常量组的说明，导出时作为组的备注
+type GroupLabel struct {
+	File  string
+	Group string
+	Label string
+}
+
+// Catalog 一种目标语言的全部翻译单元，按源文件、常量组和常量在源文件中的顺序排列
+type Catalog struct {
+	SourceLocale string        // 原文的语言，即默认语言
+	TargetLocale string        // 译文的语言
+	Groups       []*GroupLabel // 有说明的常量组
+	Units        []*Unit
+}
+
+// Collect 从已解析的常量文件中收集目标语言的翻译单元，已有的翻译作为译文；
+// 没有标签的常量（如沿用引用目标标签的常量）不需要翻译
+func Collect(files []*parser.ConstantsFile, rootDir, sourceLocale, targetLocale string) *Catalog {
+	catalog := &Catalog{SourceLocale: sourceLocale, TargetLocale: targetLocale}
+	for _, file := range files {
+		path := file.FilePath
+		if rel, err := filepath.Rel(rootDir, file.FilePath); err == nil {
+			path = rel
+		}
+		path = filepath.ToSlash(path)
+
+		for _, group := range file.Groups {
+			if group.Label != "" && group.Label != group.Name {
+				catalog.Groups = append(catalog.Groups, &GroupLabel{File: path, Group: group.Name, Label: group.Label})
+			}
+			for _, constant := range group.Constants {
+				if constant.Label == "" {
+					continue
+				}
+				unit := &Unit{
+					File:   path,
+					Group:  group.Name,
+					Key:    constant.Name,
+					Source: constant.Label,
+					Target: constant.Labels[targetLocale],
+					Line:   constant.Pos.Line,
+				}
+				if constant.Description != "" {
+					unit.Notes = append(unit.Notes, constant.Description)
+				}
+				if constant.Template != nil {
+					var params []string
+					for _, param := range constant.Template.Params {
+						params = append(params, "{"+param.Name+"}")
+					}
+					unit.Notes = append(unit.Notes, "译文须原样保留占位符 "+strings.Join(params, " "))
+				}
+				catalog.Units = append(catalog.Units, unit)
+			}
+		}
+	}
+	return catalog
+}
+
+// groupLabel 返回常量组的说明，没有时返回空
+func (c *Catalog) groupLabel(file, group string) string {
+	for _, label := range c.Groups {
+		if label.File == file && label.Group == group {
+			return label.Label
+		}
+	}
+	return ""
+}
+
+// Format 翻译文件格式，每种格式各自实现
+type Format interface {
+	// Name 返回格式名，即命令行 --format 的取值
+	Name() string
+	// Extension 返回该格式的文件扩展名（小写，含点号）
+	Extension() string
+	// Write 将翻译单元写为该格式
+	Write(w io.Writer, catalog *Catalog) error
+	// Read 解析该格式的翻译文件
+	Read(data []byte) (*Catalog, error)
+}
+
+// formats 已注册的翻译文件格式
+var formats = []Format{
+	xliffFormat{},
+	poFormat{},
+}
+
+// FormatNames 返回所有支持的格式名
+func FormatNames() []string {
+	var names []string
+	for _, format := range formats {
+		names = append(names, format.Name())
+	}
+	return names
+}
+
+// FormatByName 按格式名选择翻译文件格式，不支持时返回nil
+func FormatByName(name string) Format {
+	for _, format := range formats {
+		if format.Name() == name {
+			return format
+		}
+	}
+	return nil
+}
+
+// FormatOf 按文件扩展名选择翻译文件格式（.xlf 与 .xliff 均为XLIFF），不支持时返回nil
+func FormatOf(path string) Format {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".xliff" {
+		ext = ".xlf"
+	}
+	for _, format := range formats {
+		if format.Extension() == ext {
+			return format
+		}
+	}
+	return nil
+}
+
+// Merge 将翻译文件中的译文与输入目录 rootDir 下源文件的当前内容比对，返回需要写回的翻译单元（Target 为新的译文），
+// 并以警告报告缺少的翻译和过期的翻译：
+//
+//   - 源文件中的常量在翻译文件中没有条目或译文为空，视为缺少翻译
+//   - 翻译文件中的原文与源文件的当前标签不同，或对应的常量已不存在，视为过期，不写回
+func Merge(current, imported *Catalog, rootDir, translationFile string) ([]*Unit, parser.Diagnostics) {
+	var updates []*Unit
+	var diags parser.Diagnostics
+
+	type unitKey struct{ file, id string }
+	importedUnits := make(map[unitKey]*Unit)
+	for _, unit := range imported.Units {
+		importedUnits[unitKey{unit.File, unit.ID()}] = unit
+	}
+
+	seen := make(map[unitKey]bool)
+	for _, unit := range current.Units {
+		key := unitKey{unit.File, unit.ID()}
+		seen[key] = true
+		translated, exists := importedUnits[key]
+		switch {
+		case !exists || translated.Target == "":
+			if unit.Target == "" {
+				diags = append(diags, warning(unit.SourcePath(rootDir), unit.Line, "常量 '%s' 缺少%s翻译", unit.ID(), current.TargetLocale))
+			}
+		case translated.Source != unit.Source:
+			diags = append(diags, warning(unit.SourcePath(rootDir), unit.Line, "常量 '%s' 的原文已从 '%s' 改为 '%s'，%s翻译已过期，未写回",
+				unit.ID(), translated.Source, unit.Source, current.TargetLocale))
+		case translated.Target != unit.Target:
+			update := *unit
+			update.Target = translated.Target
+			updates = append(updates, &update)
+		}
+	}
+
+	var removed []*Unit
+	for _, unit := range imported.Units {
+		if !seen[unitKey{unit.File, unit.ID()}] {
+			removed = append(removed, unit)
+		}
+	}
+	sort.SliceStable(removed, func(i, j int) bool {
+		return removed[i].File < removed[j].File
+	})
+	for _, unit := range removed {
+		diags = append(diags, warning(translationFile, 0, "源文件 '%s' 中已没有常量 '%s'，翻译已过期", unit.File, unit.ID()))
+	}
+
+	return updates, diags
+}
+
+// warning 构造指定文件和行的警告
+func warning(file string, line int, format string, args ...interface{}) *parser.Diagnostic {
+	return &parser.Diagnostic{
+		File:     file,
+		Pos:      parser.Position{Line: line},
+		Severity: parser.SeverityWarning,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,139 @@
+package translation
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xliffNamespace XLIFF 1.2 的命名空间
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:1.2"
+
+// xliffFormat XLIFF 1.2：每个源文件一个 <file>，每个常量组一个 <group>，每个常量标签一个 <trans-unit>
+type xliffFormat struct{}
+
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"xliff"`
+	Xmlns   string      `xml:"xmlns,attr,omitempty"`
+	Version string      `xml:"version,attr"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string       `xml:"original,attr"`
+	SourceLanguage string       `xml:"source-language,attr"`
+	TargetLanguage string       `xml:"target-language,attr,omitempty"`
+	Datatype       string       `xml:"datatype,attr"`
+	Groups         []xliffGroup `xml:"body>group"`
+}
+
+type xliffGroup struct {
+	ID    string      `xml:"id,attr"`
+	Notes []string    `xml:"note"`
+	Units []xliffUnit `xml:"trans-unit"`
+}
+
+type xliffUnit struct {
+	ID      string       `xml:"id,attr"`
+	Resname string       `xml:"resname,attr,omitempty"`
+	Source  string       `xml:"source"`
+	Target  *xliffTarget `xml:"target"`
+	Notes   []string     `xml:"note"`
+}
+
+type xliffTarget struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+// Name 返回格式名
+func (xliffFormat) Name() string {
+	return "xliff"
+}
+
+// Extension 返回文件扩展名
+func (xliffFormat) Extension() string {
+	return ".xlf"
+}
+
+// Write 写出XLIFF文档，尚未翻译的常量不写 <target>
+func (xliffFormat) Write(w io.Writer, catalog *Catalog) error {
+	doc := xliffDocument{Xmlns: xliffNamespace, Version: "1.2"}
+	for _, unit := range catalog.Units {
+		if len(doc.Files) == 0 || doc.Files[len(doc.Files)-1].Original != unit.File {
+			doc.Files = append(doc.Files, xliffFile{
+				Original:       unit.File,
+				SourceLanguage: catalog.SourceLocale,
+				TargetLanguage: catalog.TargetLocale,
+				Datatype:       "plaintext",
+			})
+		}
+		file := &doc.Files[len(doc.Files)-1]
+		if len(file.Groups) == 0 || file.Groups[len(file.Groups)-1].ID != unit.Group {
+			group := xliffGroup{ID: unit.Group}
+			if label := catalog.groupLabel(unit.File, unit.Group); label != "" {
+				group.Notes = append(group.Notes, label)
+			}
+			file.Groups = append(file.Groups, group)
+		}
+		group := &file.Groups[len(file.Groups)-1]
+
+		translationUnit := xliffUnit{ID: unit.ID(), Resname: unit.Key, Source: unit.Source, Notes: unit.Notes}
+		if unit.Target != "" {
+			translationUnit.Target = &xliffTarget{State: "translated", Text: unit.Target}
+		}
+		group.Units = append(group.Units, translationUnit)
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// Read 解析XLIFF文档；状态为 new 或 needs-translation 的译文视为尚未翻译
+func (xliffFormat) Read(data []byte) (*Catalog, error) {
+	var doc xliffDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("XLIFF格式错误: %v", err)
+	}
+	if doc.Version != "" && doc.Version != "1.2" {
+		return nil, fmt.Errorf("不支持XLIFF %s，只支持1.2版", doc.Version)
+	}
+
+	catalog := &Catalog{}
+	for _, file := range doc.Files {
+		if catalog.TargetLocale == "" {
+			catalog.SourceLocale, catalog.TargetLocale = file.SourceLanguage, file.TargetLanguage
+		} else if file.TargetLanguage != catalog.TargetLocale {
+			return nil, fmt.Errorf("文件 '%s' 的目标语言 '%s' 与其他文件的 '%s' 不同", file.Original, file.TargetLanguage, catalog.TargetLocale)
+		}
+
+		for _, group := range file.Groups {
+			for _, translationUnit := range group.Units {
+				key := translationUnit.Resname
+				if key == "" {
+					key = strings.TrimPrefix(translationUnit.ID, group.ID+".")
+				}
+				unit := &Unit{File: file.Original, Group: group.ID, Key: key, Source: translationUnit.Source}
+				if target := translationUnit.Target; target != nil && target.State != "new" && target.State != "needs-translation" {
+					unit.Target = target.Text
+				}
+				catalog.Units = append(catalog.Units, unit)
+			}
+		}
+	}
+	if catalog.TargetLocale == "" {
+		return nil, fmt.Errorf("XLIFF文件没有指定目标语言（target-language）")
+	}
+	return catalog, nil
+}
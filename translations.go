@@ -0,0 +1,282 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cons-coder/parser"
+	"cons-coder/translation"
+
+	flag "github.com/spf13/pflag"
+)
+
+// exportTranslations 实现 export-translations 子命令：为每种目标语言导出一个翻译文件，返回退出码
+func exportTranslations(args []string) int {
+	var (
+		dir           string
+		output        string
+		locales       []string
+		format        string
+		defaultLocale string
+		help          bool
+	)
+
+	flags := flag.NewFlagSet("export-translations", flag.ContinueOnError)
+	flags.StringVarP(&dir, "dir", "d", "", "常量源文件目录（YAML/JSON/CSV），递归扫描子目录 (必填)")
+	flags.StringVarP(&output, "output", "o", "", "翻译文件的输出目录，每种语言一个文件，如 en.xlf (必填)")
+	flags.StringSliceVarP(&locales, "locale", "", nil, "目标语言，多种语言用逗号分隔，如 en,ja (必填)")
+	flags.StringVarP(&format, "format", "f", "xliff", fmt.Sprintf("翻译文件格式 (%s) (可选，默认为xliff)", strings.Join(translation.FormatNames(), "/")))
+	flags.StringVarP(&defaultLocale, "default-locale", "", parser.DefaultLocale, "默认语言，即原文的语言 (可选)")
+	flags.BoolVarP(&help, "help", "h", false, "显示帮助信息")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if help {
+		printSubcommandHelp(flags, "cons-coder export-translations --dir <源文件目录> --output <输出目录> --locale <语言> [选项]")
+		return 0
+	}
+
+	if dir == "" || output == "" || len(locales) == 0 {
+		fmt.Println("错误: 缺少必填参数")
+		printSubcommandHelp(flags, "cons-coder export-translations --dir <源文件目录> --output <输出目录> --locale <语言> [选项]")
+		return 1
+	}
+	translationFormat := translation.FormatByName(format)
+	if translationFormat == nil {
+		fmt.Printf("错误: 不支持的翻译文件格式 '%s'\n", format)
+		fmt.Printf("支持的格式: %s\n", strings.Join(translation.FormatNames(), ", "))
+		return 1
+	}
+	if !checkLocales(append([]string{defaultLocale}, locales...)) {
+		return 1
+	}
+	for _, locale := range locales {
+		if locale == defaultLocale {
+			fmt.Printf("错误: 目标语言 '%s' 与默认语言相同\n", locale)
+			return 1
+		}
+	}
+
+	allConstants, ok := loadTranslationSources(dir, defaultLocale, "未导出翻译文件")
+	if !ok {
+		return 1
+	}
+
+	if err := os.MkdirAll(output, 0755); err != nil {
+		fmt.Printf("错误: 无法创建输出目录 '%s': %v\n", output, err)
+		return 1
+	}
+	for _, locale := range locales {
+		catalog := translation.Collect(allConstants, dir, defaultLocale, locale)
+		path := filepath.Join(output, locale+translationFormat.Extension())
+		file, err := os.Create(path)
+		if err != nil {
+			fmt.Printf("错误: 无法创建翻译文件 '%s': %v\n", path, err)
+			return 1
+		}
+		err = translationFormat.Write(file, catalog)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Printf("错误: 写入翻译文件 '%s' 失败: %v\n", path, err)
+			return 1
+		}
+
+		untranslated := 0
+		for _, unit := range catalog.Units {
+			if unit.Target == "" {
+				untranslated++
+			}
+		}
+		fmt.Printf("已导出 %s: %d 条标签，%d 条尚未翻译\n", path, len(catalog.Units), untranslated)
+	}
+	return 0
+}
+
+// importTranslations 实现 import-translations 子命令：把翻译文件中的译文写回YAML源文件，返回退出码
+func importTranslations(args []string) int {
+	var (
+		dir           string
+		inputs        []string
+		defaultLocale string
+		dryRun        bool
+		help          bool
+	)
+
+	flags := flag.NewFlagSet("import-translations", flag.ContinueOnError)
+	flags.StringVarP(&dir, "dir", "d", "", "常量源文件目录，须与导出时相同 (必填)")
+	flags.StringSliceVarP(&inputs, "input", "i", nil, "翻译文件（.xlf/.xliff/.po），多个文件用逗号分隔 (必填)")
+	flags.StringVarP(&defaultLocale, "default-locale", "", parser.DefaultLocale, "默认语言，即原文的语言 (可选)")
+	flags.BoolVarP(&dryRun, "dry-run", "", false, "只报告缺少和过期的翻译，不修改源文件 (可选)")
+	flags.BoolVarP(&help, "help", "h", false, "显示帮助信息")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if help {
+		printSubcommandHelp(flags, "cons-coder import-translations --dir <源文件目录> --input <翻译文件> [选项]")
+		return 0
+	}
+
+	if dir == "" || len(inputs) == 0 {
+		fmt.Println("错误: 缺少必填参数")
+		printSubcommandHelp(flags, "cons-coder import-translations --dir <源文件目录> --input <翻译文件> [选项]")
+		return 1
+	}
+	if !checkLocales([]string{defaultLocale}) {
+		return 1
+	}
+
+	allConstants, ok := loadTranslationSources(dir, defaultLocale, "未导入翻译")
+	if !ok {
+		return 1
+	}
+
+	failed := 0
+	for _, input := range inputs {
+		if !importTranslationFile(input, dir, allConstants, defaultLocale, dryRun) {
+			failed++
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d 个翻译文件导入失败\n", failed)
+		return 1
+	}
+	return 0
+}
+
+// importTranslationFile 导入单个翻译文件，报告缺少和过期的翻译，失败时返回false
+func importTranslationFile(input, dir string, allConstants []*parser.ConstantsFile, defaultLocale string, dryRun bool) bool {
+	translationFormat := translation.FormatOf(input)
+	if translationFormat == nil {
+		fmt.Fprintf(os.Stderr, "%s: error: 不支持的翻译文件格式 '%s'\n", input, filepath.Ext(input))
+		return false
+	}
+	data, err := os.ReadFile(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: error: 读取文件失败: %v\n", input, err)
+		return false
+	}
+	imported, err := translationFormat.Read(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: error: %v\n", input, err)
+		return false
+	}
+
+	locale := imported.TargetLocale
+	switch {
+	case !parser.IsValidLocale(locale):
+		fmt.Fprintf(os.Stderr, "%s: error: 目标语言 '%s' 不是合法的语言代码\n", input, locale)
+		return false
+	case locale == defaultLocale:
+		fmt.Fprintf(os.Stderr, "%s: error: 目标语言 '%s' 与默认语言相同\n", input, locale)
+		return false
+	case imported.SourceLocale != "" && imported.SourceLocale != defaultLocale:
+		fmt.Fprintf(os.Stderr, "%s: error: 原文语言 '%s' 与默认语言 '%s' 不同\n", input, imported.SourceLocale, defaultLocale)
+		return false
+	}
+
+	current := translation.Collect(allConstants, dir, defaultLocale, locale)
+	updates, diagnostics := translation.Merge(current, imported, dir, input)
+	for _, diag := range diagnostics {
+		fmt.Fprintln(os.Stderr, diag)
+	}
+
+	// 按源文件汇总需要写回的标签，保持源文件的顺序
+	var paths []string
+	labels := make(map[string]map[string]map[string]string)
+	for _, unit := range updates {
+		path := unit.SourcePath(dir)
+		if labels[path] == nil {
+			paths = append(paths, path)
+			labels[path] = make(map[string]map[string]string)
+		}
+		if labels[path][unit.Group] == nil {
+			labels[path][unit.Group] = make(map[string]string)
+		}
+		labels[path][unit.Group][unit.Key] = unit.Target
+	}
+
+	written, ok := 0, true
+	for _, path := range paths {
+		count := 0
+		for _, constants := range labels[path] {
+			count += len(constants)
+		}
+		content, err := parser.LocalizeYAMLFile(path, defaultLocale, locale, labels[path])
+		if err == nil && !dryRun {
+			err = os.WriteFile(path, content, 0644)
+		}
+		if err != nil {
+			printFileError(path, err)
+			ok = false
+			continue
+		}
+		if dryRun {
+			fmt.Printf("将更新 %s: %d 条%s标签\n", path, count, locale)
+			continue
+		}
+		fmt.Printf("已更新 %s: %d 条%s标签\n", path, count, locale)
+		written += count
+	}
+
+	fmt.Printf("%s: 写回 %d 条%s翻译，%d 个警告\n", input, written, locale, len(diagnostics))
+	return ok
+}
+
+// printFileError 以编译器风格输出文件的错误，错误已带位置时原样输出
+func printFileError(path string, err error) {
+	var diag *parser.Diagnostic
+	if errors.As(err, &diag) {
+		fmt.Fprintln(os.Stderr, diag)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s: error: %v\n", path, err)
+}
+
+// loadTranslationSources 解析源文件并输出诊断，存在错误时返回false
+func loadTranslationSources(dir, defaultLocale, failure string) ([]*parser.ConstantsFile, bool) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		fmt.Printf("错误: 目录 '%s' 不存在\n", dir)
+		return nil, false
+	}
+	sourceFiles, err := parser.FindSourceFiles(dir)
+	if err != nil {
+		fmt.Printf("错误: 读取源文件失败: %v\n", err)
+		return nil, false
+	}
+
+	allConstants, diagnostics := loadSources(dir, sourceFiles, nil, defaultLocale)
+	for _, diag := range diagnostics {
+		fmt.Fprintln(os.Stderr, diag)
+	}
+	if diagnostics.HasErrors() {
+		fmt.Fprintf(os.Stderr, "%d 个错误，%d 个警告，%s\n",
+			diagnostics.Count(parser.SeverityError), diagnostics.Count(parser.SeverityWarning), failure)
+		return nil, false
+	}
+	return allConstants, true
+}
+
+// checkLocales 校验语言代码，不合法时输出错误并返回false
+func checkLocales(locales []string) bool {
+	for _, locale := range locales {
+		if !parser.IsValidLocale(locale) {
+			fmt.Printf("错误: 语言 '%s' 不是合法的语言代码（如 zh、en、zh-Hant）\n", locale)
+			return false
+		}
+	}
+	return true
+}
+
+// printSubcommandHelp 输出子命令的用法和选项
+func printSubcommandHelp(flags *flag.FlagSet, usage string) {
+	fmt.Println("用法:")
+	fmt.Printf("  %s\n", usage)
+	fmt.Println()
+	fmt.Println("选项:")
+	flags.PrintDefaults()
+}